@@ -0,0 +1,175 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/multiversx/mx-chain-vm-v1_4-go/config"
+	gasschedules "github.com/multiversx/mx-chain-vm-v1_4-go/scenario/gasSchedules"
+)
+
+var errInvalidGasSchedule = errors.New("invalid gas schedule")
+
+func loadGasSchedule(path string) (config.GasScheduleMap, error) {
+	fileContents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return gasschedules.LoadGasScheduleConfig(string(fileContents))
+}
+
+func diffGasSchedules(writer io.Writer, oldPath string, newPath string, tracePaths []string) error {
+	oldMap, err := loadGasSchedule(oldPath)
+	if err != nil {
+		return err
+	}
+	newMap, err := loadGasSchedule(newPath)
+	if err != nil {
+		return err
+	}
+
+	section := ""
+	for _, change := range config.DiffGasSchedules(oldMap, newMap) {
+		if change.Section != section {
+			section = change.Section
+			_, _ = fmt.Fprintf(writer, "[%s]\n", section)
+		}
+
+		switch {
+		case !change.InOld:
+			_, _ = fmt.Fprintf(writer, "  + %s = %d\n", change.Key, change.NewCost)
+		case !change.InNew:
+			_, _ = fmt.Fprintf(writer, "  - %s = %d\n", change.Key, change.OldCost)
+		default:
+			_, _ = fmt.Fprintf(writer, "  ~ %s: %d -> %d (%+d)\n", change.Key, change.OldCost, change.NewCost, change.Delta())
+		}
+	}
+
+	_, _ = fmt.Fprintf(writer, "\nchecking %s\n", newPath)
+	writeGasScheduleIssues(writer, validateGasSchedule(newMap))
+
+	if len(tracePaths) == 0 {
+		return nil
+	}
+
+	tracedGas, err := readFoldedGasTraces(tracePaths)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(writer, "\nestimated impact per endpoint\n")
+	for _, impact := range config.EstimateGasScheduleImpact(oldMap, newMap, tracedGas) {
+		_, _ = fmt.Fprintf(writer, "  %s: %d -> %d (%+d), unattributed %d\n",
+			impact.Endpoint, impact.OldGas, impact.NewGas, impact.Delta(), impact.UnattributedGas)
+	}
+
+	return nil
+}
+
+func checkGasSchedule(writer io.Writer, path string) error {
+	gasMap, err := loadGasSchedule(path)
+	if err != nil {
+		return err
+	}
+
+	issues := validateGasSchedule(gasMap)
+	writeGasScheduleIssues(writer, issues)
+	for _, issue := range issues {
+		if issue.Kind != config.UnknownGasCost {
+			return errInvalidGasSchedule
+		}
+	}
+
+	return nil
+}
+
+func validateGasSchedule(gasMap config.GasScheduleMap) []*config.GasScheduleIssue {
+	issues, err := config.ValidateGasSchedule(gasMap)
+	validationErr, ok := err.(*config.GasScheduleValidationError)
+	if ok {
+		issues = append(issues, validationErr.Issues...)
+	}
+
+	return issues
+}
+
+func writeGasScheduleIssues(writer io.Writer, issues []*config.GasScheduleIssue) {
+	if len(issues) == 0 {
+		_, _ = fmt.Fprintln(writer, "  no issues")
+		return
+	}
+
+	for _, issue := range issues {
+		consequence := "ignored"
+		switch {
+		case issue.Kind.Rejected():
+			consequence = "rejected"
+		case issue.Kind == config.InconsistentGasCost:
+			consequence = "rejected by strict validation"
+		}
+		_, _ = fmt.Fprintf(writer, "  %s key %s.%s (%s)\n", issue.Kind, issue.Section, issue.Key, consequence)
+	}
+}
+
+// readFoldedGasTraces sums the gas of folded-stack files, as written by vmhost.WriteFoldedGasStacks,
+// per root endpoint and per EI function; the gas of the other frames is kept under an empty name
+func readFoldedGasTraces(paths []string) (map[string]map[string]uint64, error) {
+	tracedGas := make(map[string]map[string]uint64)
+	for _, path := range paths {
+		err := readFoldedGasTrace(path, tracedGas)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tracedGas, nil
+}
+
+func readFoldedGasTrace(path string, tracedGas map[string]map[string]uint64) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 {
+			continue
+		}
+
+		separator := strings.LastIndexByte(line, ' ')
+		if separator < 0 {
+			return fmt.Errorf("%s:%d: missing gas value", path, lineNumber)
+		}
+		gas, err := strconv.ParseUint(line[separator+1:], 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s:%d: %w", path, lineNumber, err)
+		}
+
+		labels := strings.Split(line[:separator], ";")
+		endpoint := labels[0][strings.IndexByte(labels[0], ':')+1:]
+		eiFunctionName := ""
+		if strings.HasPrefix(labels[len(labels)-1], "ei:") {
+			eiFunctionName = strings.TrimPrefix(labels[len(labels)-1], "ei:")
+		}
+
+		if tracedGas[endpoint] == nil {
+			tracedGas[endpoint] = make(map[string]uint64)
+		}
+		tracedGas[endpoint][eiFunctionName] += gas
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	cli "github.com/urfave/cli/v2"
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "gasschedulediff"
+	app.Usage = "compares gas schedule TOML files and estimates the impact of switching between them"
+	app.Version = "VM 1.4 internal"
+	app.Commands = []*cli.Command{
+		{
+			Name:  "diff",
+			Usage: "diff the OLD and NEW gas schedules section by section, checking that NEW is accepted by the VM",
+			Flags: []cli.Flag{
+				&cli.StringSliceFlag{
+					Name:  "traces",
+					Usage: "estimates the gas impact per endpoint from the folded-stack gas call trees in `FILE`, recorded with the OLD schedule",
+				},
+			},
+			Action: func(cCtx *cli.Context) error {
+				if cCtx.Args().Len() != 2 {
+					return errors.New("the OLD and NEW gas schedule paths are required")
+				}
+
+				return diffGasSchedules(os.Stdout, cCtx.Args().Get(0), cCtx.Args().Get(1), cCtx.StringSlice("traces"))
+			},
+		},
+		{
+			Name:  "check",
+			Usage: "check the keys of a gas schedule against the ones read by the VM",
+			Action: func(cCtx *cli.Context) error {
+				if cCtx.Args().Len() != 1 {
+					return errors.New("one gas schedule path argument required")
+				}
+
+				return checkGasSchedule(os.Stdout, cCtx.Args().First())
+			},
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(fmt.Errorf("gasschedulediff: %w", err))
+	}
+}
@@ -1,20 +1,48 @@
 package main
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+
 	scenclibase "github.com/multiversx/mx-chain-scenario-go/clibase"
+	scenexec "github.com/multiversx/mx-chain-scenario-go/scenario/executor"
 	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
 
 	vmscenario "github.com/multiversx/mx-chain-vm-v1_4-go/scenario"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/compiledstore"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/contexts"
 	cli "github.com/urfave/cli/v2"
 )
 
+// compiledCodeMaxEntrySize is the size limit of a compiled contract kept in the compiled code cache
+const compiledCodeMaxEntrySize = 16 * 1024 * 1024
+
+// compiledCodeMaxTotalSize is the size limit of all the compiled contracts kept in the compiled code cache
+const compiledCodeMaxTotalSize = 512 * 1024 * 1024
+
 var _ scenclibase.CLIRunConfig = (*vm14Flags)(nil)
+var _ vmscenario.CLIRunnerHook = (*vm14Flags)(nil)
 
 func main() {
-	scenclibase.ScenariosCLI("VM 1.4 internal", &vm14Flags{})
+	vmscenario.ScenariosCLI("VM 1.4 internal", &vm14Flags{})
 }
 
-type vm14Flags struct{}
+type vm14Flags struct {
+	vmBuilder          *vmscenario.ScenarioVMHostBuilder
+	compiledCacheDir   string
+	coverageReportPath string
+	coverageTracker    vmhost.CoverageTracker
+	debug              bool
+	stepIDPattern      string
+	tag                string
+	stopAfterStep      int
+	stateDumpPath      string
+	resumePath         string
+	fromStep           int
+}
 
 func (*vm14Flags) GetFlags() []cli.Flag {
 	return []cli.Flag{
@@ -23,18 +51,174 @@ func (*vm14Flags) GetFlags() []cli.Flag {
 			Aliases: []string{"g"},
 			Usage:   "overrides the traceGas option in the scenarios`",
 		},
+		&cli.StringFlag{
+			Name:  "compiled-cache-dir",
+			Usage: "keeps the compiled contracts in `DIR`, reusing them across the runs",
+		},
+		&cli.StringFlag{
+			Name:  "coverage",
+			Usage: "writes a JSON report of the endpoints and EI functions exercised by the scenarios to the given `FILE`",
+		},
+		&cli.BoolFlag{
+			Name:    "debug",
+			Aliases: []string{"d"},
+			Usage:   "pauses before each step and at each nested call boundary, reading debugger commands from stdin",
+		},
+		&cli.StringFlag{
+			Name:  "step-id",
+			Usage: "runs only the steps whose id matches the regular expression `REGEX`; setState steps always run",
+		},
+		&cli.StringFlag{
+			Name:  "tag",
+			Usage: "runs only the steps whose comment contains #`TAG`; setState steps always run",
+		},
+		&cli.IntFlag{
+			Name:  "stop-after",
+			Usage: "stops the execution after the step at position `N`",
+		},
+		&cli.StringFlag{
+			Name:  "dump-state",
+			Usage: "writes the world state at the stop point to `FILE`, to be used with --resume",
+		},
+		&cli.StringFlag{
+			Name:  "resume",
+			Usage: "restores the world state from `FILE`, written with --dump-state, before running the scenario",
+		},
+		&cli.IntFlag{
+			Name:  "from-step",
+			Usage: "skips the steps before position `N`, normally together with --resume",
+		},
 	}
 }
 
-func (*vm14Flags) ParseFlags(cCtx *cli.Context) scenclibase.CLIRunOptions {
+func (flags *vm14Flags) ParseFlags(cCtx *cli.Context) scenclibase.CLIRunOptions {
 	runOptions := &scenio.RunScenarioOptions{
 		ForceTraceGas: cCtx.Bool("force-trace-gas"),
 	}
 
-	vmBuilder := vmscenario.NewScenarioVMHostBuilder()
+	flags.vmBuilder = vmscenario.NewScenarioVMHostBuilder()
+	flags.compiledCacheDir = cCtx.String("compiled-cache-dir")
+
+	flags.coverageReportPath = cCtx.String("coverage")
+	if len(flags.coverageReportPath) > 0 {
+		flags.coverageTracker = contexts.NewEnabledCoverageTracker()
+		flags.vmBuilder.CoverageTracker = flags.coverageTracker
+	}
+
+	flags.debug = cCtx.Bool("debug")
+	flags.stepIDPattern = cCtx.String("step-id")
+	flags.tag = cCtx.String("tag")
+	flags.stopAfterStep = cCtx.Int("stop-after")
+	flags.stateDumpPath = cCtx.String("dump-state")
+	flags.resumePath = cCtx.String("resume")
+	flags.fromStep = cCtx.Int("from-step")
 
 	return scenclibase.CLIRunOptions{
 		RunOptions: runOptions,
-		VMBuilder:  vmBuilder,
+		VMBuilder:  flags.vmBuilder,
+	}
+}
+
+// NewRunner opens the compiled code cache and wraps the executor with the debugger and the step
+// filter, when requested
+func (flags *vm14Flags) NewRunner(executor *scenexec.ScenarioExecutor) (scenio.ScenarioRunner, error) {
+	if len(flags.compiledCacheDir) > 0 {
+		compiledCodeStore, err := compiledstore.NewFileCompiledCodeStore(compiledstore.ArgsFileCompiledCodeStore{
+			Directory:    flags.compiledCacheDir,
+			MaxEntrySize: compiledCodeMaxEntrySize,
+			MaxTotalSize: compiledCodeMaxTotalSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+		flags.vmBuilder.CompiledCodeStore = compiledCodeStore
+	}
+
+	var runner scenio.ScenarioRunner = executor
+	if flags.debug {
+		debugger := vmscenario.NewScenarioDebugger(executor, os.Stdin, os.Stdout)
+		flags.vmBuilder.CallDebugger = debugger
+		runner = debugger
+	}
+	if flags.filtersSteps() {
+		return flags.newStepFilter(runner, executor)
 	}
+
+	return runner, nil
+}
+
+// AfterRun prints how to resume a stopped execution and writes the coverage report
+func (flags *vm14Flags) AfterRun(err error) error {
+	if errors.Is(err, vmscenario.ErrScenarioStopped) {
+		if len(flags.stateDumpPath) > 0 {
+			fmt.Printf("world state written to %s, resume with --resume %s --from-step %d\n",
+				flags.stateDumpPath,
+				flags.stateDumpPath,
+				flags.stopAfterStep+1)
+		}
+		err = nil
+	}
+
+	reportErr := flags.writeCoverageReport()
+	if reportErr != nil {
+		fmt.Printf("ERROR: could not write coverage report: %s\n", reportErr.Error())
+	}
+
+	return err
+}
+
+func (flags *vm14Flags) filtersSteps() bool {
+	return len(flags.stepIDPattern) > 0 ||
+		len(flags.tag) > 0 ||
+		flags.stopAfterStep > 0 ||
+		len(flags.resumePath) > 0 ||
+		flags.fromStep > 0
+}
+
+func (flags *vm14Flags) newStepFilter(runner scenio.ScenarioRunner, executor *scenexec.ScenarioExecutor) (scenio.ScenarioRunner, error) {
+	args := vmscenario.ArgsScenarioStepFilter{
+		Runner:        runner,
+		World:         executor.World,
+		Tag:           flags.tag,
+		FromStep:      flags.fromStep,
+		StopAfterStep: flags.stopAfterStep,
+		StateDumpPath: flags.stateDumpPath,
+	}
+
+	if len(flags.stepIDPattern) > 0 {
+		stepIDPattern, err := regexp.Compile(flags.stepIDPattern)
+		if err != nil {
+			return nil, err
+		}
+		args.StepIDPattern = stepIDPattern
+	}
+
+	if len(flags.resumePath) > 0 {
+		if flags.fromStep == 0 {
+			return nil, errors.New("--from-step is required when resuming from a world state")
+		}
+
+		resumeState, err := scenio.ParseScenariosScenarioDefaultParser(flags.resumePath)
+		if err != nil {
+			return nil, err
+		}
+		args.ResumeState = resumeState
+	}
+
+	return vmscenario.NewScenarioStepFilter(args)
+}
+
+func (flags *vm14Flags) writeCoverageReport() error {
+	if flags.coverageTracker == nil {
+		return nil
+	}
+
+	report := vmscenario.NewCoverageReport(flags.coverageTracker.GetCoverage())
+	err := report.WriteJSONFile(flags.coverageReportPath)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("coverage report written to %s\n", flags.coverageReportPath)
+	return nil
 }
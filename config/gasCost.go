@@ -21,6 +21,10 @@ type BaseOperationCost struct {
 	CompilePerByte    uint64
 	AoTPreparePerByte uint64
 	GetCode           uint64
+
+	// MemoryGrowPerPage is charged for each page of linear memory grown by a contract call;
+	// it is optional, so the gas schedules without it do not charge the memory growth
+	MemoryGrowPerPage uint64 `gasSchedule:"optional"`
 }
 
 type BaseOpsAPICost struct {
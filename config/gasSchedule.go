@@ -124,6 +124,9 @@ func checkForZeroUint64Fields(arg interface{}) error {
 		if field.Kind() != reflect.Uint64 && field.Kind() != reflect.Uint32 {
 			continue
 		}
+		if isOptionalGasCost(v.Type().Field(i)) {
+			continue
+		}
 		if field.Uint() == 0 {
 			name := v.Type().Field(i).Name
 			return fmt.Errorf("gas cost for operation %s has been set to 0 or is not set", name)
@@ -133,6 +136,11 @@ func checkForZeroUint64Fields(arg interface{}) error {
 	return nil
 }
 
+// isOptionalGasCost returns true for the gas costs which may be missing from the gas schedule
+func isOptionalGasCost(field reflect.StructField) bool {
+	return field.Tag.Get("gasSchedule") == "optional"
+}
+
 func MakeGasMap(value, asyncCallbackGasLock uint64) GasScheduleMap {
 	gasMap := make(GasScheduleMap)
 	gasMap = FillGasMap(gasMap, value, asyncCallbackGasLock)
@@ -0,0 +1,217 @@
+package config
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// GasScheduleIssueKind identifies the problems of a gas schedule key
+type GasScheduleIssueKind uint8
+
+const (
+	// MissingGasCost is a key expected by CreateGasConfig which is missing, so the schedule is rejected
+	MissingGasCost GasScheduleIssueKind = iota
+
+	// ZeroGasCost is a key set to 0, so the schedule is rejected by CreateGasConfig
+	ZeroGasCost
+
+	// OverflowingGasCost is a key whose value does not fit in its field, so the schedule is rejected by CreateGasConfig
+	OverflowingGasCost
+
+	// UnknownGasCost is a key not read by CreateGasConfig, which is silently ignored
+	UnknownGasCost
+
+	// InconsistentGasCost is a key whose cost differs from the cost of an equivalent key
+	InconsistentGasCost
+)
+
+// String returns a human-readable name for the gas schedule issue kind
+func (kind GasScheduleIssueKind) String() string {
+	switch kind {
+	case MissingGasCost:
+		return "missing"
+	case ZeroGasCost:
+		return "zero"
+	case OverflowingGasCost:
+		return "overflowing"
+	case UnknownGasCost:
+		return "unknown"
+	case InconsistentGasCost:
+		return "inconsistent"
+	default:
+		return "unexpected"
+	}
+}
+
+// Rejected returns true if the issue causes CreateGasConfig to reject the gas schedule
+func (kind GasScheduleIssueKind) Rejected() bool {
+	return kind == MissingGasCost || kind == ZeroGasCost || kind == OverflowingGasCost
+}
+
+// GasScheduleIssue is a problem found for a key of a gas schedule section
+type GasScheduleIssue struct {
+	Section string
+	Key     string
+	Kind    GasScheduleIssueKind
+}
+
+// GasCostChange is the difference between the costs of a key in two gas schedules
+type GasCostChange struct {
+	Section string
+	Key     string
+	OldCost uint64
+	NewCost uint64
+	InOld   bool
+	InNew   bool
+}
+
+// Delta returns the change of the cost, as a signed value
+func (change *GasCostChange) Delta() int64 {
+	return int64(change.NewCost) - int64(change.OldCost)
+}
+
+type gasScheduleField struct {
+	name     string
+	maxCost  uint64
+	optional bool
+}
+
+// GasScheduleSections returns the sections of the gas schedule read by CreateGasConfig, with
+// the keys expected in each of them, sorted
+func GasScheduleSections() map[string][]string {
+	sections := make(map[string][]string)
+	for section, fields := range gasScheduleFields() {
+		keys := make([]string, 0, len(fields))
+		for _, field := range fields {
+			keys = append(keys, field.name)
+		}
+		sort.Strings(keys)
+		sections[section] = keys
+	}
+
+	return sections
+}
+
+func gasScheduleFields() map[string][]gasScheduleField {
+	sections := make(map[string][]gasScheduleField)
+
+	gasCostType := reflect.TypeOf(GasCost{})
+	for i := 0; i < gasCostType.NumField(); i++ {
+		sectionType := gasCostType.Field(i).Type
+		fields := make([]gasScheduleField, 0, sectionType.NumField())
+		for j := 0; j < sectionType.NumField(); j++ {
+			field := sectionType.Field(j)
+			switch field.Type.Kind() {
+			case reflect.Uint64:
+				fields = append(fields, gasScheduleField{name: field.Name, maxCost: math.MaxUint64, optional: isOptionalGasCost(field)})
+			case reflect.Uint32:
+				fields = append(fields, gasScheduleField{name: field.Name, maxCost: math.MaxUint32, optional: isOptionalGasCost(field)})
+			}
+		}
+		sections[gasCostType.Field(i).Name] = fields
+	}
+
+	return sections
+}
+
+// CheckGasScheduleKeys finds the keys of the sections read by CreateGasConfig which are
+// missing, set to 0 or overflowing, all causing the schedule to be rejected, as well as the
+// unknown keys, which are ignored; the sections not read by the VM are not checked, and
+// the optional keys may be missing or set to 0
+func CheckGasScheduleKeys(gasMap GasScheduleMap) []*GasScheduleIssue {
+	issues := make([]*GasScheduleIssue, 0)
+	for section, fields := range gasScheduleFields() {
+		costs := lowerCaseKeys(gasMap[section])
+		expected := make(map[string]struct{}, len(fields))
+
+		for _, field := range fields {
+			lowerName := strings.ToLower(field.name)
+			expected[lowerName] = struct{}{}
+
+			cost, ok := costs[lowerName]
+			switch {
+			case field.optional && (!ok || cost == 0):
+			case !ok:
+				issues = append(issues, &GasScheduleIssue{Section: section, Key: field.name, Kind: MissingGasCost})
+			case cost == 0:
+				issues = append(issues, &GasScheduleIssue{Section: section, Key: field.name, Kind: ZeroGasCost})
+			case cost > field.maxCost:
+				issues = append(issues, &GasScheduleIssue{Section: section, Key: field.name, Kind: OverflowingGasCost})
+			}
+		}
+
+		for key := range gasMap[section] {
+			if _, ok := expected[strings.ToLower(key)]; !ok {
+				issues = append(issues, &GasScheduleIssue{Section: section, Key: key, Kind: UnknownGasCost})
+			}
+		}
+	}
+
+	sortGasScheduleIssues(issues)
+
+	return issues
+}
+
+func sortGasScheduleIssues(issues []*GasScheduleIssue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Section != issues[j].Section {
+			return issues[i].Section < issues[j].Section
+		}
+		return issues[i].Key < issues[j].Key
+	})
+}
+
+// mapstructure matches the keys to the field names regardless of case
+func lowerCaseKeys(costs map[string]uint64) map[string]uint64 {
+	lowered := make(map[string]uint64, len(costs))
+	for key, cost := range costs {
+		lowered[strings.ToLower(key)] = cost
+	}
+
+	return lowered
+}
+
+// DiffGasSchedules returns the changes between the costs of two gas schedules, covering all
+// their sections, sorted by section and key; unchanged costs are left out
+func DiffGasSchedules(oldMap GasScheduleMap, newMap GasScheduleMap) []*GasCostChange {
+	changes := make([]*GasCostChange, 0)
+	for _, section := range unionOfKeys(oldMap, newMap) {
+		oldCosts := oldMap[section]
+		newCosts := newMap[section]
+		for _, key := range unionOfKeys(oldCosts, newCosts) {
+			oldCost, inOld := oldCosts[key]
+			newCost, inNew := newCosts[key]
+			if inOld == inNew && oldCost == newCost {
+				continue
+			}
+
+			changes = append(changes, &GasCostChange{
+				Section: section,
+				Key:     key,
+				OldCost: oldCost,
+				NewCost: newCost,
+				InOld:   inOld,
+				InNew:   inNew,
+			})
+		}
+	}
+
+	return changes
+}
+
+func unionOfKeys[V any](left map[string]V, right map[string]V) []string {
+	keys := make([]string, 0, len(left)+len(right))
+	for key := range left {
+		keys = append(keys, key)
+	}
+	for key := range right {
+		if _, ok := left[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
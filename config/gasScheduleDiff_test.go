@@ -0,0 +1,69 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckGasScheduleKeys(t *testing.T) {
+	gasMap := MakeGasMapForTests()
+	require.Len(t, CheckGasScheduleKeys(gasMap), 0)
+
+	delete(gasMap["BaseOpsAPICost"], "GetArgument")
+	gasMap["BigIntAPICost"]["BigIntAdd"] = 0
+	gasMap["WASMOpcodeCost"]["I32Add"] = 1 << 40
+	gasMap["WASMOpcodeCost"]["NotAnOpcode"] = 1
+
+	issues := CheckGasScheduleKeys(gasMap)
+	require.Equal(t, []*GasScheduleIssue{
+		{Section: "BaseOpsAPICost", Key: "GetArgument", Kind: MissingGasCost},
+		{Section: "BigIntAPICost", Key: "BigIntAdd", Kind: ZeroGasCost},
+		{Section: "WASMOpcodeCost", Key: "I32Add", Kind: OverflowingGasCost},
+		{Section: "WASMOpcodeCost", Key: "NotAnOpcode", Kind: UnknownGasCost},
+	}, issues)
+	require.False(t, UnknownGasCost.Rejected())
+	require.True(t, MissingGasCost.Rejected())
+}
+
+func TestDiffGasSchedules(t *testing.T) {
+	oldMap := GasScheduleMap{
+		"BaseOpsAPICost": {"GetArgument": 10, "Finish": 20, "Removed": 5},
+		"BuiltInCost":    {"ClaimDeveloperRewards": 100},
+	}
+	newMap := GasScheduleMap{
+		"BaseOpsAPICost": {"GetArgument": 15, "Finish": 20, "Added": 7},
+		"BuiltInCost":    {"ClaimDeveloperRewards": 90},
+	}
+
+	changes := DiffGasSchedules(oldMap, newMap)
+	require.Equal(t, []*GasCostChange{
+		{Section: "BaseOpsAPICost", Key: "Added", NewCost: 7, InNew: true},
+		{Section: "BaseOpsAPICost", Key: "GetArgument", OldCost: 10, NewCost: 15, InOld: true, InNew: true},
+		{Section: "BaseOpsAPICost", Key: "Removed", OldCost: 5, InOld: true},
+		{Section: "BuiltInCost", Key: "ClaimDeveloperRewards", OldCost: 100, NewCost: 90, InOld: true, InNew: true},
+	}, changes)
+	require.Equal(t, int64(-10), changes[3].Delta())
+}
+
+func TestEstimateGasScheduleImpact(t *testing.T) {
+	oldMap := GasScheduleMap{
+		"BaseOpsAPICost": {"GetArgument": 10, "Finish": 20},
+		"BigIntAPICost":  {"BigIntAdd": 4},
+	}
+	newMap := GasScheduleMap{
+		"BaseOpsAPICost": {"GetArgument": 20, "Finish": 20},
+		"BigIntAPICost":  {"BigIntAdd": 2},
+	}
+	tracedGas := map[string]map[string]uint64{
+		"adder::add": {"getArgument": 30, "bigIntAdd": 40, "": 1000},
+		"adder::get": {"finish": 20},
+	}
+
+	impacts := EstimateGasScheduleImpact(oldMap, newMap, tracedGas)
+	require.Equal(t, []*EndpointGasImpact{
+		{Endpoint: "adder::add", OldGas: 1070, NewGas: 1080, UnattributedGas: 1000},
+		{Endpoint: "adder::get", OldGas: 20, NewGas: 20},
+	}, impacts)
+	require.Equal(t, int64(10), impacts[0].Delta())
+}
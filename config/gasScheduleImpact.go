@@ -0,0 +1,78 @@
+package config
+
+import (
+	"sort"
+	"strings"
+)
+
+var apiCostSections = []string{
+	"BaseOpsAPICost",
+	"BigIntAPICost",
+	"BigFloatAPICost",
+	"ManagedBufferAPICost",
+	"CryptoAPICost",
+	"EthAPICost",
+}
+
+// EndpointGasImpact is the estimated gas of an endpoint after switching gas schedules
+type EndpointGasImpact struct {
+	Endpoint        string
+	OldGas          uint64
+	NewGas          uint64
+	UnattributedGas uint64
+}
+
+// Delta returns the estimated change of the gas of the endpoint, as a signed value
+func (impact *EndpointGasImpact) Delta() int64 {
+	return int64(impact.NewGas) - int64(impact.OldGas)
+}
+
+// FindAPICostKey returns the section and the key holding the base cost of the given EI
+// function, matched by name regardless of case
+func FindAPICostKey(gasMap GasScheduleMap, eiFunctionName string) (string, string, bool) {
+	for _, section := range apiCostSections {
+		for key := range gasMap[section] {
+			if strings.EqualFold(key, eiFunctionName) {
+				return section, key, true
+			}
+		}
+	}
+
+	return "", "", false
+}
+
+// EstimateGasScheduleImpact estimates the gas of each endpoint under the new gas schedule, from
+// the gas traced under the old one, given per endpoint and per EI function; the gas of each EI
+// function is scaled by the ratio between its new and old base costs, while the gas which
+// cannot be matched to an API cost, such as the WASM execution, is kept unchanged
+func EstimateGasScheduleImpact(
+	oldMap GasScheduleMap,
+	newMap GasScheduleMap,
+	tracedGas map[string]map[string]uint64,
+) []*EndpointGasImpact {
+	impacts := make([]*EndpointGasImpact, 0, len(tracedGas))
+	for endpoint, gasPerFunction := range tracedGas {
+		impact := &EndpointGasImpact{Endpoint: endpoint}
+		for eiFunctionName, gas := range gasPerFunction {
+			impact.OldGas += gas
+
+			section, key, found := FindAPICostKey(oldMap, eiFunctionName)
+			oldCost := oldMap[section][key]
+			newCost, inNew := newMap[section][key]
+			if !found || !inNew || oldCost == 0 {
+				impact.UnattributedGas += gas
+				impact.NewGas += gas
+				continue
+			}
+
+			impact.NewGas += uint64(float64(gas) * float64(newCost) / float64(oldCost))
+		}
+		impacts = append(impacts, impact)
+	}
+
+	sort.Slice(impacts, func(i, j int) bool {
+		return impacts[i].Endpoint < impacts[j].Endpoint
+	})
+
+	return impacts
+}
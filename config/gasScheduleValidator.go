@@ -0,0 +1,91 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// equivalentGasCosts lists the groups of keys which price the same operation through
+// different EI functions, so they are expected to have the same cost
+var equivalentGasCosts = []struct {
+	section string
+	keys    []string
+}{
+	{section: "BaseOpsAPICost", keys: []string{"StorageLoad", "Int64StorageLoad"}},
+	{section: "BaseOpsAPICost", keys: []string{"StorageStore", "Int64StorageStore"}},
+}
+
+// GasScheduleValidationError holds all the issues which make a gas schedule invalid
+type GasScheduleValidationError struct {
+	Issues []*GasScheduleIssue
+}
+
+// Error returns the issues, one per line
+func (err *GasScheduleValidationError) Error() string {
+	lines := make([]string, 0, len(err.Issues)+1)
+	lines = append(lines, fmt.Sprintf("invalid gas schedule, %d issues", len(err.Issues)))
+	for _, issue := range err.Issues {
+		lines = append(lines, fmt.Sprintf("%s key %s.%s", issue.Kind, issue.Section, issue.Key))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// ValidateGasSchedule reports all the problems of a gas schedule at once, instead of the
+// first one only, as CreateGasConfig does: missing keys, costs set to 0 or overflowing, and
+// opcode or API costs inconsistent with the costs of their equivalents. The unknown keys,
+// which CreateGasConfig ignores, are returned separately, as they do not make the schedule invalid.
+func ValidateGasSchedule(gasMap GasScheduleMap) (unknownKeys []*GasScheduleIssue, err error) {
+	unknownKeys = make([]*GasScheduleIssue, 0)
+	invalid := make([]*GasScheduleIssue, 0)
+	for _, issue := range CheckGasScheduleKeys(gasMap) {
+		if issue.Kind == UnknownGasCost {
+			unknownKeys = append(unknownKeys, issue)
+		} else {
+			invalid = append(invalid, issue)
+		}
+	}
+
+	invalid = append(invalid, checkGasCostConsistency(gasMap)...)
+	if len(invalid) == 0 {
+		return unknownKeys, nil
+	}
+
+	sortGasScheduleIssues(invalid)
+	return unknownKeys, &GasScheduleValidationError{Issues: invalid}
+}
+
+func checkGasCostConsistency(gasMap GasScheduleMap) []*GasScheduleIssue {
+	issues := make([]*GasScheduleIssue, 0)
+
+	// the signed and unsigned variants of an opcode perform the same work
+	opcodeCosts := gasMap["WASMOpcodeCost"]
+	for key, cost := range opcodeCosts {
+		if !strings.HasSuffix(key, "S") {
+			continue
+		}
+
+		unsignedKey := strings.TrimSuffix(key, "S") + "U"
+		unsignedCost, ok := opcodeCosts[unsignedKey]
+		if ok && unsignedCost != cost {
+			issues = append(issues, &GasScheduleIssue{Section: "WASMOpcodeCost", Key: unsignedKey, Kind: InconsistentGasCost})
+		}
+	}
+
+	for _, group := range equivalentGasCosts {
+		costs := gasMap[group.section]
+		referenceCost, ok := costs[group.keys[0]]
+		if !ok {
+			continue
+		}
+
+		for _, key := range group.keys[1:] {
+			cost, ok := costs[key]
+			if ok && cost != referenceCost {
+				issues = append(issues, &GasScheduleIssue{Section: group.section, Key: key, Kind: InconsistentGasCost})
+			}
+		}
+	}
+
+	return issues
+}
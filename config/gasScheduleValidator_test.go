@@ -0,0 +1,40 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateGasSchedule_Valid(t *testing.T) {
+	gasMap := MakeGasMapForTests()
+	gasMap["BigIntAPICost"]["BigIntByteLength"] = 1
+
+	unknownKeys, err := ValidateGasSchedule(gasMap)
+	require.Nil(t, err)
+	require.Equal(t, []*GasScheduleIssue{
+		{Section: "BigIntAPICost", Key: "BigIntByteLength", Kind: UnknownGasCost},
+	}, unknownKeys)
+}
+
+func TestValidateGasSchedule_ReportsAllIssues(t *testing.T) {
+	gasMap := MakeGasMapForTests()
+	delete(gasMap["BaseOpsAPICost"], "GetArgument")
+	gasMap["BaseOpsAPICost"]["GetArgumnet"] = 1
+	gasMap["CryptoAPICost"]["SHA256"] = 0
+	gasMap["WASMOpcodeCost"]["I64ShrU"] = 3
+	gasMap["BaseOpsAPICost"]["Int64StorageStore"] = 2
+
+	unknownKeys, err := ValidateGasSchedule(gasMap)
+	require.Len(t, unknownKeys, 1)
+
+	validationErr, ok := err.(*GasScheduleValidationError)
+	require.True(t, ok)
+	require.Equal(t, []*GasScheduleIssue{
+		{Section: "BaseOpsAPICost", Key: "GetArgument", Kind: MissingGasCost},
+		{Section: "BaseOpsAPICost", Key: "Int64StorageStore", Kind: InconsistentGasCost},
+		{Section: "CryptoAPICost", Key: "SHA256", Kind: ZeroGasCost},
+		{Section: "WASMOpcodeCost", Key: "I64ShrU", Kind: InconsistentGasCost},
+	}, validationErr.Issues)
+	require.Contains(t, err.Error(), "missing key BaseOpsAPICost.GetArgument")
+}
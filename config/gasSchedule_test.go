@@ -80,3 +80,20 @@ func TestDecode_ZeroGasCostError(t *testing.T) {
 	err = checkForZeroUint64Fields(*wasmCosts)
 	assert.Error(t, err)
 }
+
+func TestCreateGasConfig_OptionalGasCost(t *testing.T) {
+	gasMap := MakeGasMapForTests()
+	_, found := gasMap["BaseOperationCost"]["MemoryGrowPerPage"]
+	assert.False(t, found)
+
+	gasCost, err := CreateGasConfig(gasMap)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), gasCost.BaseOperationCost.MemoryGrowPerPage)
+	assert.Empty(t, CheckGasScheduleKeys(gasMap))
+
+	gasMap["BaseOperationCost"]["MemoryGrowPerPage"] = 7
+	gasCost, err = CreateGasConfig(gasMap)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(7), gasCost.BaseOperationCost.MemoryGrowPerPage)
+	assert.Empty(t, CheckGasScheduleKeys(gasMap))
+}
@@ -0,0 +1,327 @@
+package interpreter
+
+import (
+	"fmt"
+)
+
+// maxLocals bounds the number of locals declared by a function, as Wasmer does
+const maxLocals = 4000
+
+// blockTypeEmpty is the block type of the blocks which neither take nor return values
+const blockTypeEmpty = -64
+
+// instruction is a decoded operator, with its immediates and, for the control operators,
+// the positions of the matching else and end
+type instruction struct {
+	opcode   uint16
+	operator *operator
+	// offset is the offset of the instruction in the module
+	offset int
+
+	// index holds the label depth, function, local, global, type or data index, or the memory offset
+	index uint32
+	value uint64
+
+	numParams  int
+	numResults int
+	elsePC     int
+	endPC      int
+	brTable    []uint32
+}
+
+// compiledFunction is a function body decoded into instructions
+type compiledFunction struct {
+	index      uint32
+	typ        *functionType
+	locals     []valueType
+	code       []*instruction
+	codeOffset int
+}
+
+func (m *module) compileFunctions() error {
+	m.compiled = make([]*compiledFunction, len(m.bodies))
+	for i, body := range m.bodies {
+		functionIndex := m.numImportedFunc + uint32(i)
+		typ, _ := m.functionType(functionIndex)
+		function, err := m.compileFunction(functionIndex, typ, body)
+		if err != nil {
+			return fmt.Errorf("function %d: %w", functionIndex, err)
+		}
+		m.compiled[i] = function
+	}
+
+	err := m.checkIndices()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *module) checkIndices() error {
+	if m.startFunction != nil {
+		typ, ok := m.functionType(*m.startFunction)
+		if !ok {
+			return fmt.Errorf("%w: unknown start function %d", ErrInvalidModule, *m.startFunction)
+		}
+		if len(typ.params) > 0 || len(typ.results) > 0 {
+			return fmt.Errorf("%w: start function must not take nor return values", ErrInvalidModule)
+		}
+	}
+
+	for name, exp := range m.exports {
+		valid := true
+		switch exp.kind {
+		case externalFunction:
+			valid = exp.index < m.numFunctions()
+		case externalTable:
+			valid = m.table != nil && exp.index == 0
+		case externalMemory:
+			valid = m.memory != nil && exp.index == 0
+		case externalGlobal:
+			valid = exp.index < uint32(len(m.globals))
+		}
+		if !valid {
+			return fmt.Errorf("%w: export %s refers to an unknown item", ErrInvalidModule, name)
+		}
+	}
+
+	for _, segment := range m.elements {
+		if m.table == nil {
+			return fmt.Errorf("%w: element segment without table", ErrInvalidModule)
+		}
+		for _, functionIndex := range segment.functionIndices {
+			if functionIndex >= m.numFunctions() {
+				return fmt.Errorf("%w: element segment refers to unknown function %d", ErrInvalidModule, functionIndex)
+			}
+		}
+	}
+
+	if len(m.data) > 0 && m.memory == nil {
+		return fmt.Errorf("%w: data segment without memory", ErrInvalidModule)
+	}
+
+	return nil
+}
+
+func (m *module) compileFunction(functionIndex uint32, typ *functionType, body *functionBody) (*compiledFunction, error) {
+	function := &compiledFunction{
+		index:      functionIndex,
+		typ:        typ,
+		locals:     body.locals,
+		codeOffset: body.codeOffset,
+	}
+	numLocals := uint32(len(typ.params) + len(body.locals))
+
+	r := newReader(body.code)
+	openBlocks := []int{-1}
+	for r.hasMore() {
+		if len(openBlocks) == 0 {
+			return nil, r.errorf("instructions after the end of the function")
+		}
+
+		in := &instruction{offset: body.codeOffset + r.offset, elsePC: -1, endPC: -1}
+		err := m.decodeInstruction(r, in)
+		if err != nil {
+			return nil, err
+		}
+
+		pc := len(function.code)
+		switch in.opcode {
+		case opBlock, opLoop, opIf:
+			openBlocks = append(openBlocks, pc)
+		case opElse:
+			opening := openBlocks[len(openBlocks)-1]
+			if opening < 0 || function.code[opening].opcode != opIf || function.code[opening].elsePC >= 0 {
+				return nil, r.errorf("else without if")
+			}
+			function.code[opening].elsePC = pc
+		case opEnd:
+			opening := openBlocks[len(openBlocks)-1]
+			openBlocks = openBlocks[:len(openBlocks)-1]
+			if opening >= 0 {
+				function.code[opening].endPC = pc
+				elsePC := function.code[opening].elsePC
+				if elsePC >= 0 {
+					function.code[elsePC].endPC = pc
+				}
+			}
+		case opBr, opBrIf:
+			if in.index >= uint32(len(openBlocks)) {
+				return nil, r.errorf("unknown label %d", in.index)
+			}
+		case opBrTable:
+			for _, depth := range in.brTable {
+				if depth >= uint32(len(openBlocks)) {
+					return nil, r.errorf("unknown label %d", depth)
+				}
+			}
+		case opLocalGet, opLocalSet, opLocalTee:
+			if in.index >= numLocals {
+				return nil, r.errorf("unknown local %d", in.index)
+			}
+		}
+
+		function.code = append(function.code, in)
+	}
+
+	if len(openBlocks) != 0 {
+		return nil, fmt.Errorf("%w: function body not terminated", ErrInvalidModule)
+	}
+
+	return function, nil
+}
+
+func (m *module) decodeInstruction(r *reader, in *instruction) error {
+	b, err := r.readByte()
+	if err != nil {
+		return err
+	}
+	in.opcode = uint16(b)
+	if b == prefixBulkMemory {
+		subOpcode, err := r.readU32()
+		if err != nil {
+			return err
+		}
+		if subOpcode > 0xff {
+			return fmt.Errorf("%w: opcode 0x%x 0x%x", ErrUnsupportedFeature, b, subOpcode)
+		}
+		in.opcode = bulkMemoryOpcode(subOpcode)
+	}
+
+	op, ok := operators[in.opcode]
+	if !ok {
+		return fmt.Errorf("%w: opcode 0x%x at offset %d", ErrUnsupportedFeature, in.opcode, in.offset)
+	}
+	in.operator = op
+
+	switch op.immediate {
+	case immediateBlockType:
+		return m.decodeBlockType(r, in)
+	case immediateLabel:
+		in.index, err = r.readU32()
+	case immediateBrTable:
+		err = readVector(r, func() error {
+			depth, err := r.readU32()
+			in.brTable = append(in.brTable, depth)
+			return err
+		})
+		if err == nil {
+			var defaultDepth uint32
+			defaultDepth, err = r.readU32()
+			in.brTable = append(in.brTable, defaultDepth)
+		}
+	case immediateFunction:
+		in.index, err = r.readU32()
+		if err == nil && in.index >= m.numFunctions() {
+			err = r.errorf("unknown function %d", in.index)
+		}
+	case immediateCallIndirect:
+		in.index, err = r.readU32()
+		if err == nil && in.index >= uint32(len(m.types)) {
+			err = r.errorf("unknown type %d", in.index)
+		}
+		if err == nil {
+			err = m.readZeroIndex(r, m.table != nil, "table")
+		}
+	case immediateLocal:
+		in.index, err = r.readU32()
+	case immediateGlobal:
+		in.index, err = r.readU32()
+		if err == nil && in.index >= uint32(len(m.globals)) {
+			err = r.errorf("unknown global %d", in.index)
+		}
+		if err == nil && in.opcode == opGlobalSet && !m.globals[in.index].mutable {
+			err = r.errorf("global %d is immutable", in.index)
+		}
+	case immediateMemArg:
+		if m.memory == nil {
+			return r.errorf("unknown memory")
+		}
+		_, err = r.readU32()
+		if err == nil {
+			in.index, err = r.readU32()
+		}
+	case immediateMemoryIndex:
+		err = m.readZeroIndex(r, m.memory != nil, "memory")
+	case immediateI32:
+		var value int32
+		value, err = r.readS32()
+		in.value = uint64(uint32(value))
+	case immediateI64:
+		var value int64
+		value, err = r.readS64()
+		in.value = uint64(value)
+	case immediateValueTypes:
+		var types []valueType
+		types, err = readValueTypes(r)
+		if err == nil && len(types) != 1 {
+			err = r.errorf("typed select must have one type")
+		}
+	case immediateDataMemory:
+		err = m.readDataIndex(r, in)
+		if err == nil {
+			err = m.readZeroIndex(r, m.memory != nil, "memory")
+		}
+	case immediateData:
+		err = m.readDataIndex(r, in)
+	case immediateTwoMemories:
+		err = m.readZeroIndex(r, m.memory != nil, "memory")
+		if err == nil {
+			err = m.readZeroIndex(r, true, "memory")
+		}
+	}
+
+	return err
+}
+
+func (m *module) decodeBlockType(r *reader, in *instruction) error {
+	blockType, err := r.readS33()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case blockType == blockTypeEmpty:
+	case blockType < 0:
+		vt := valueType(byte(blockType & 0x7f))
+		if vt != valueTypeI32 && vt != valueTypeI64 {
+			return fmt.Errorf("%w: block type %d", ErrUnsupportedFeature, blockType)
+		}
+		in.numResults = 1
+	default:
+		if blockType >= int64(len(m.types)) {
+			return r.errorf("unknown type %d", blockType)
+		}
+		in.numParams = len(m.types[blockType].params)
+		in.numResults = len(m.types[blockType].results)
+	}
+
+	return nil
+}
+
+func (m *module) readDataIndex(r *reader, in *instruction) error {
+	var err error
+	in.index, err = r.readU32()
+	if err == nil && in.index >= uint32(len(m.data)) {
+		err = r.errorf("unknown data segment %d", in.index)
+	}
+
+	return err
+}
+
+// readZeroIndex reads the reserved byte standing for the index of the single table or memory
+func (m *module) readZeroIndex(r *reader, exists bool, kind string) error {
+	index, err := r.readByte()
+	if err != nil {
+		return err
+	}
+	if index != 0 {
+		return fmt.Errorf("%w: %s index %d", ErrUnsupportedFeature, kind, index)
+	}
+	if !exists {
+		return r.errorf("unknown %s", kind)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package interpreter
+
+import (
+	"sync"
+
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/vmhooksmeta"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/wasmer"
+)
+
+var _ vmhost.WasmEngine = (*Engine)(nil)
+
+// Engine is a vmhost.WasmEngine executing the contracts with the interpreter, in pure Go; it is
+// meant for debugging, being much slower than Wasmer. The instances are metered with the same
+// opcode costs, stop on the same runtime breakpoints and enforce the same memory limits, so
+// that the gas used can be cross-checked against Wasmer. The floating point and vector
+// instructions are not supported, and the modules are expected to be well typed.
+type Engine struct {
+	mutEngine   sync.RWMutex
+	bindings    map[string]map[string]*vmhooksmeta.EIFunctionBinding
+	opcodeCosts [wasmer.OpcodeCount]uint32
+	stepHandler StepHandler
+}
+
+// NewEngine creates a new interpreter Engine
+func NewEngine() *Engine {
+	return &Engine{}
+}
+
+// SetImports sets the EI functions imported by the contracts
+func (engine *Engine) SetImports(imports *vmhooksmeta.EIFunctions) error {
+	if imports == nil {
+		return ErrNilImports
+	}
+
+	bindings := vmhooksmeta.NewEIFunctionBindings(imports)
+
+	engine.mutEngine.Lock()
+	engine.bindings = bindings
+	engine.mutEngine.Unlock()
+
+	return nil
+}
+
+// SetOpcodeCosts sets the opcode costs charged by the instances created afterwards
+func (engine *Engine) SetOpcodeCosts(opcodeCosts *[wasmer.OpcodeCount]uint32) {
+	engine.mutEngine.Lock()
+	engine.opcodeCosts = *opcodeCosts
+	engine.mutEngine.Unlock()
+}
+
+// SetStepHandler sets the handler receiving each instruction executed by the instances created
+// afterwards; a nil handler disables stepping
+func (engine *Engine) SetStepHandler(handler StepHandler) {
+	engine.mutEngine.Lock()
+	engine.stepHandler = handler
+	engine.mutEngine.Unlock()
+}
+
+// NewInstanceWithOptions decodes the contract code and creates an instance of it
+func (engine *Engine) NewInstanceWithOptions(
+	contractCode []byte,
+	options wasmer.CompilationOptions,
+) (wasmer.InstanceHandler, error) {
+	m, err := decodeModule(contractCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return newInstance(m, engine, options)
+}
+
+// NewInstanceFromCompiledCodeWithOptions creates an instance from the code returned by Instance.Cache,
+// which is the contract code itself
+func (engine *Engine) NewInstanceFromCompiledCodeWithOptions(
+	compiledCode []byte,
+	options wasmer.CompilationOptions,
+) (wasmer.InstanceHandler, error) {
+	return engine.NewInstanceWithOptions(compiledCode, options)
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (engine *Engine) IsInterfaceNil() bool {
+	return engine == nil
+}
@@ -0,0 +1,48 @@
+package interpreter
+
+import "errors"
+
+// ErrInvalidModule indicates that the bytecode is not a valid WASM module
+var ErrInvalidModule = errors.New("invalid WASM module")
+
+// ErrUnsupportedFeature indicates that the module uses a WASM feature the interpreter does not support
+var ErrUnsupportedFeature = errors.New("unsupported WASM feature")
+
+// ErrUnresolvedImport indicates that the module imports a function which is not an EI function
+var ErrUnresolvedImport = errors.New("unresolved import")
+
+// ErrImportSignatureMismatch indicates that an imported function is declared with another signature than the EI function
+var ErrImportSignatureMismatch = errors.New("import signature mismatch")
+
+// ErrNilImports indicates that the engine creates instances before receiving the EI functions
+var ErrNilImports = errors.New("nil imports")
+
+// ErrTrap indicates that the execution has trapped
+var ErrTrap = errors.New("wasm trap")
+
+// ErrUnreachable indicates that the execution reached an unreachable instruction
+var ErrUnreachable = errors.New("unreachable executed")
+
+// ErrOutOfBoundsMemoryAccess indicates an access outside the linear memory
+var ErrOutOfBoundsMemoryAccess = errors.New("out of bounds memory access")
+
+// ErrIntegerDivideByZero indicates an integer division by zero
+var ErrIntegerDivideByZero = errors.New("integer divide by zero")
+
+// ErrIntegerOverflow indicates the overflow of a signed integer division
+var ErrIntegerOverflow = errors.New("integer overflow")
+
+// ErrUndefinedElement indicates a call_indirect through an index outside the table or not initialized
+var ErrUndefinedElement = errors.New("undefined element")
+
+// ErrIndirectCallTypeMismatch indicates a call_indirect to a function of another type
+var ErrIndirectCallTypeMismatch = errors.New("indirect call type mismatch")
+
+// ErrCallStackExhausted indicates that the calls are nested deeper than the interpreter allows
+var ErrCallStackExhausted = errors.New("call stack exhausted")
+
+// ErrRuntimeBreakpoint indicates that the execution was stopped by a runtime breakpoint
+var ErrRuntimeBreakpoint = errors.New("execution stopped by runtime breakpoint")
+
+// ErrInstanceCleaned indicates the use of an instance after it was cleaned
+var ErrInstanceCleaned = errors.New("instance already cleaned")
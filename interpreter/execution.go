@@ -0,0 +1,678 @@
+package interpreter
+
+import (
+	"encoding/binary"
+	"math"
+	"math/bits"
+
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/wasmer"
+)
+
+// label is the target of the branches out of a block, a loop, an if or the function itself
+type label struct {
+	// arity is the number of values carried by a branch to the label
+	arity int
+	// height is the height of the stack when the block was entered, excluding its parameters
+	height int
+	// target is the instruction executed after a branch to the label
+	target int
+	isLoop bool
+}
+
+// call executes the function with the given index, its arguments being on top of the stack;
+// the arguments are replaced by the results
+func (instance *Instance) call(functionIndex uint32) error {
+	if functionIndex < instance.module.numImportedFunc {
+		return instance.callImport(functionIndex)
+	}
+
+	if instance.callDepth >= maxCallDepth {
+		return ErrCallStackExhausted
+	}
+	instance.callDepth++
+	defer func() {
+		instance.callDepth--
+	}()
+
+	function := instance.module.compiled[functionIndex-instance.module.numImportedFunc]
+	numParams := len(function.typ.params)
+	base := len(instance.stack) - numParams
+	locals := make([]uint64, numParams+len(function.locals))
+	copy(locals, instance.stack[base:])
+	instance.stack = instance.stack[:base]
+
+	if uint64(len(function.locals)) > instance.options.UnmeteredLocals {
+		numMeteredLocals := uint64(len(function.locals)) - instance.options.UnmeteredLocals
+		err := instance.useLocalAllocationPoints(numMeteredLocals)
+		if err != nil {
+			return err
+		}
+	}
+
+	return instance.execute(function, locals, base)
+}
+
+func (instance *Instance) callImport(functionIndex uint32) error {
+	binding := instance.imports[functionIndex]
+	numArguments := len(binding.Function.FunctionInputs)
+	base := len(instance.stack) - numArguments
+	arguments := append([]uint64(nil), instance.stack[base:]...)
+	instance.stack = instance.stack[:base]
+
+	results, err := binding.Call(instance.context.Pointer(), arguments...)
+	if err != nil {
+		return err
+	}
+	instance.stack = append(instance.stack, results...)
+
+	if instance.options.RuntimeBreakpoints && instance.GetBreakpointValue() != uint64(vmhost.BreakpointNone) {
+		return ErrRuntimeBreakpoint
+	}
+	if instance.options.Metering && instance.pointsUsed > instance.gasLimit {
+		return instance.stopOnBreakpoint(vmhost.BreakpointOutOfGas)
+	}
+
+	return nil
+}
+
+func (instance *Instance) stopOnBreakpoint(breakpoint vmhost.BreakpointValue) error {
+	instance.SetBreakpointValue(uint64(breakpoint))
+	return ErrRuntimeBreakpoint
+}
+
+func (instance *Instance) usePoints(points uint64) error {
+	if !instance.options.Metering {
+		return nil
+	}
+
+	instance.pointsUsed += points
+	if instance.pointsUsed > instance.gasLimit {
+		return instance.stopOnBreakpoint(vmhost.BreakpointOutOfGas)
+	}
+
+	return nil
+}
+
+func (instance *Instance) useLocalAllocationPoints(numLocals uint64) error {
+	return instance.usePoints(numLocals * uint64(instance.opcodeCosts[wasmer.OpcodeLocalAllocate]))
+}
+
+func (instance *Instance) push(value uint64) {
+	instance.stack = append(instance.stack, value)
+}
+
+func (instance *Instance) pop() uint64 {
+	top := len(instance.stack) - 1
+	value := instance.stack[top]
+	instance.stack = instance.stack[:top]
+	return value
+}
+
+func (instance *Instance) pushI32(value uint32) {
+	instance.push(uint64(value))
+}
+
+func (instance *Instance) popI32() uint32 {
+	return uint32(instance.pop())
+}
+
+func (instance *Instance) pushBool(value bool) {
+	if value {
+		instance.push(1)
+		return
+	}
+	instance.push(0)
+}
+
+// branch moves the values carried to the label and returns the remaining labels and the next instruction
+func (instance *Instance) branch(labels []label, depth uint32) ([]label, int) {
+	targetIndex := len(labels) - 1 - int(depth)
+	target := labels[targetIndex]
+
+	top := len(instance.stack)
+	copy(instance.stack[target.height:], instance.stack[top-target.arity:top])
+	instance.stack = instance.stack[:target.height+target.arity]
+
+	if target.isLoop {
+		return labels[:targetIndex+1], target.target
+	}
+	return labels[:targetIndex], target.target
+}
+
+func (instance *Instance) step(function *compiledFunction, pc int, locals []uint64, base int) error {
+	in := function.code[pc]
+	return instance.stepHandler(&Step{
+		FunctionIndex: function.index,
+		FunctionName:  instance.module.functionNames[function.index],
+		PC:            pc,
+		Offset:        in.offset,
+		Opcode:        in.operator.name,
+		Locals:        append([]uint64(nil), locals...),
+		Stack:         append([]uint64(nil), instance.stack[base:]...),
+		CallDepth:     instance.callDepth,
+		PointsUsed:    instance.pointsUsed,
+	})
+}
+
+// execute runs the body of a function, charging each instruction with its opcode cost before executing it
+func (instance *Instance) execute(function *compiledFunction, locals []uint64, base int) error {
+	code := function.code
+	labels := make([]label, 1, 8)
+	labels[0] = label{arity: len(function.typ.results), height: base, target: len(code)}
+
+	var err error
+	pc := 0
+	for pc < len(code) {
+		in := code[pc]
+		if instance.stepHandler != nil {
+			err = instance.step(function, pc, locals, base)
+			if err != nil {
+				return err
+			}
+		}
+		err = instance.usePoints(uint64(instance.opcodeCosts[in.operator.costIndex]))
+		if err != nil {
+			return err
+		}
+
+		switch in.opcode {
+		case opUnreachable:
+			return ErrUnreachable
+		case opNop:
+		case opBlock:
+			labels = append(labels, label{
+				arity:  in.numResults,
+				height: len(instance.stack) - in.numParams,
+				target: in.endPC + 1,
+			})
+		case opLoop:
+			labels = append(labels, label{
+				arity:  in.numParams,
+				height: len(instance.stack) - in.numParams,
+				target: pc + 1,
+				isLoop: true,
+			})
+		case opIf:
+			condition := instance.popI32()
+			labels = append(labels, label{
+				arity:  in.numResults,
+				height: len(instance.stack) - in.numParams,
+				target: in.endPC + 1,
+			})
+			if condition == 0 {
+				if in.elsePC >= 0 {
+					pc = in.elsePC + 1
+				} else {
+					pc = in.endPC
+				}
+				continue
+			}
+		case opElse:
+			labels = labels[:len(labels)-1]
+			pc = in.endPC + 1
+			continue
+		case opEnd:
+			labels = labels[:len(labels)-1]
+		case opBr:
+			labels, pc = instance.branch(labels, in.index)
+			continue
+		case opBrIf:
+			if instance.popI32() != 0 {
+				labels, pc = instance.branch(labels, in.index)
+				continue
+			}
+		case opBrTable:
+			index := instance.popI32()
+			defaultIndex := uint32(len(in.brTable) - 1)
+			if index > defaultIndex {
+				index = defaultIndex
+			}
+			labels, pc = instance.branch(labels, in.brTable[index])
+			continue
+		case opReturn:
+			labels, pc = instance.branch(labels, uint32(len(labels)-1))
+			continue
+		case opCall:
+			err = instance.call(in.index)
+		case opCallIndirect:
+			err = instance.callIndirect(in.index)
+		case opDrop:
+			instance.pop()
+		case opSelect, opTypedSelect:
+			condition := instance.popI32()
+			second := instance.pop()
+			first := instance.pop()
+			if condition != 0 {
+				instance.push(first)
+			} else {
+				instance.push(second)
+			}
+		case opLocalGet:
+			instance.push(locals[in.index])
+		case opLocalSet:
+			locals[in.index] = instance.pop()
+		case opLocalTee:
+			locals[in.index] = instance.stack[len(instance.stack)-1]
+		case opGlobalGet:
+			instance.push(instance.globals[in.index])
+		case opGlobalSet:
+			instance.globals[in.index] = instance.pop()
+		case opMemorySize:
+			instance.pushI32(instance.memory.pages())
+		case opMemoryGrow:
+			err = instance.memoryGrow()
+		case opI32Const, opI64Const:
+			instance.push(in.value)
+		case opMemoryInit, opDataDrop, opMemoryCopy, opMemoryFill:
+			err = instance.executeBulkMemory(in)
+		default:
+			err = instance.executeNumeric(in)
+		}
+		if err != nil {
+			return err
+		}
+
+		pc++
+	}
+
+	return nil
+}
+
+func (instance *Instance) callIndirect(typeIndex uint32) error {
+	elementIndex := instance.popI32()
+	if uint64(elementIndex) >= uint64(len(instance.table)) || instance.table[elementIndex] < 0 {
+		return ErrUndefinedElement
+	}
+
+	functionIndex := uint32(instance.table[elementIndex])
+	typ, _ := instance.module.functionType(functionIndex)
+	if !typ.equals(instance.module.types[typeIndex]) {
+		return ErrIndirectCallTypeMismatch
+	}
+
+	return instance.call(functionIndex)
+}
+
+// memoryGrow grows the memory within the limits of the runtime breakpoints, pushing -1 if the
+// memory cannot grow past the maximum declared by the module
+func (instance *Instance) memoryGrow() error {
+	delta := instance.popI32()
+	if instance.options.RuntimeBreakpoints {
+		instance.numMemoryGrows++
+		if instance.numMemoryGrows > instance.options.MaxMemoryGrow || uint64(delta) > instance.options.MaxMemoryGrowDelta {
+			return instance.stopOnBreakpoint(vmhost.BreakpointMemoryLimit)
+		}
+	}
+
+	previousPages := instance.memory.pages()
+	err := instance.memory.Grow(delta)
+	if err != nil {
+		instance.pushI32(math.MaxUint32)
+		return nil
+	}
+
+	instance.pushI32(previousPages)
+	return nil
+}
+
+func (instance *Instance) executeBulkMemory(in *instruction) error {
+	if in.opcode == opDataDrop {
+		instance.droppedData[in.index] = true
+		return nil
+	}
+
+	length := instance.popI32()
+	source := instance.popI32()
+	destination := instance.popI32()
+	memory := instance.memory
+	destinationAddress, err := memory.effectiveAddress(destination, 0, length)
+	if err != nil {
+		return err
+	}
+
+	switch in.opcode {
+	case opMemoryInit:
+		var segment []byte
+		if !instance.droppedData[in.index] {
+			segment = instance.module.data[in.index].data
+		}
+		if uint64(source)+uint64(length) > uint64(len(segment)) {
+			return ErrOutOfBoundsMemoryAccess
+		}
+		copy(memory.data[destinationAddress:], segment[source:source+length])
+	case opMemoryCopy:
+		sourceAddress, err := memory.effectiveAddress(source, 0, length)
+		if err != nil {
+			return err
+		}
+		copy(memory.data[destinationAddress:], memory.data[sourceAddress:sourceAddress+uint64(length)])
+	case opMemoryFill:
+		value := byte(source)
+		for i := uint64(0); i < uint64(length); i++ {
+			memory.data[destinationAddress+i] = value
+		}
+	}
+
+	return nil
+}
+
+func (instance *Instance) load(in *instruction, size uint32) ([]byte, error) {
+	address, err := instance.memory.effectiveAddress(instance.popI32(), in.index, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return instance.memory.data[address : address+uint64(size)], nil
+}
+
+func (instance *Instance) store(in *instruction, value uint64, size uint32) error {
+	address, err := instance.memory.effectiveAddress(instance.popI32(), in.index, size)
+	if err != nil {
+		return err
+	}
+
+	target := instance.memory.data[address : address+uint64(size)]
+	switch size {
+	case 1:
+		target[0] = byte(value)
+	case 2:
+		binary.LittleEndian.PutUint16(target, uint16(value))
+	case 4:
+		binary.LittleEndian.PutUint32(target, uint32(value))
+	case 8:
+		binary.LittleEndian.PutUint64(target, value)
+	}
+
+	return nil
+}
+
+func (instance *Instance) executeMemoryAccess(in *instruction) error {
+	switch in.opcode {
+	case opI32Store, opI64Store32:
+		return instance.store(in, instance.pop(), 4)
+	case opI64Store:
+		return instance.store(in, instance.pop(), 8)
+	case opI32Store8, opI64Store8:
+		return instance.store(in, instance.pop(), 1)
+	case opI32Store16, opI64Store16:
+		return instance.store(in, instance.pop(), 2)
+	}
+
+	var size uint32
+	switch in.opcode {
+	case opI32Load8S, opI32Load8U, opI64Load8S, opI64Load8U:
+		size = 1
+	case opI32Load16S, opI32Load16U, opI64Load16S, opI64Load16U:
+		size = 2
+	case opI32Load, opI64Load32S, opI64Load32U:
+		size = 4
+	case opI64Load:
+		size = 8
+	}
+
+	data, err := instance.load(in, size)
+	if err != nil {
+		return err
+	}
+
+	switch in.opcode {
+	case opI32Load, opI64Load32U:
+		instance.push(uint64(binary.LittleEndian.Uint32(data)))
+	case opI64Load:
+		instance.push(binary.LittleEndian.Uint64(data))
+	case opI32Load8S:
+		instance.pushI32(uint32(int32(int8(data[0]))))
+	case opI32Load8U, opI64Load8U:
+		instance.push(uint64(data[0]))
+	case opI32Load16S:
+		instance.pushI32(uint32(int32(int16(binary.LittleEndian.Uint16(data)))))
+	case opI32Load16U, opI64Load16U:
+		instance.push(uint64(binary.LittleEndian.Uint16(data)))
+	case opI64Load8S:
+		instance.push(uint64(int64(int8(data[0]))))
+	case opI64Load16S:
+		instance.push(uint64(int64(int16(binary.LittleEndian.Uint16(data)))))
+	case opI64Load32S:
+		instance.push(uint64(int64(int32(binary.LittleEndian.Uint32(data)))))
+	}
+
+	return nil
+}
+
+func (instance *Instance) executeNumeric(in *instruction) error {
+	switch {
+	case in.opcode >= opI32Load && in.opcode <= opI64Store32:
+		return instance.executeMemoryAccess(in)
+	case in.opcode == opI32Eqz:
+		instance.pushBool(instance.popI32() == 0)
+		return nil
+	case in.opcode >= opI32Eq && in.opcode <= opI32GeU:
+		b := instance.popI32()
+		a := instance.popI32()
+		instance.pushBool(compareI32(in.opcode, a, b))
+		return nil
+	case in.opcode == opI64Eqz:
+		instance.pushBool(instance.pop() == 0)
+		return nil
+	case in.opcode >= opI64Eq && in.opcode <= opI64GeU:
+		b := instance.pop()
+		a := instance.pop()
+		instance.pushBool(compareI64(in.opcode, a, b))
+		return nil
+	case in.opcode >= opI32Clz && in.opcode <= opI32Popcnt:
+		instance.pushI32(unaryI32(in.opcode, instance.popI32()))
+		return nil
+	case in.opcode >= opI32Add && in.opcode <= opI32Rotr:
+		b := instance.popI32()
+		a := instance.popI32()
+		result, err := binaryI32(in.opcode, a, b)
+		if err != nil {
+			return err
+		}
+		instance.pushI32(result)
+		return nil
+	case in.opcode >= opI64Clz && in.opcode <= opI64Popcnt:
+		instance.push(unaryI64(in.opcode, instance.pop()))
+		return nil
+	case in.opcode >= opI64Add && in.opcode <= opI64Rotr:
+		b := instance.pop()
+		a := instance.pop()
+		result, err := binaryI64(in.opcode, a, b)
+		if err != nil {
+			return err
+		}
+		instance.push(result)
+		return nil
+	}
+
+	instance.push(convert(in.opcode, instance.pop()))
+	return nil
+}
+
+func compareI32(opcode uint16, a uint32, b uint32) bool {
+	switch opcode {
+	case opI32Eq:
+		return a == b
+	case opI32Ne:
+		return a != b
+	case opI32LtS:
+		return int32(a) < int32(b)
+	case opI32LtU:
+		return a < b
+	case opI32GtS:
+		return int32(a) > int32(b)
+	case opI32GtU:
+		return a > b
+	case opI32LeS:
+		return int32(a) <= int32(b)
+	case opI32LeU:
+		return a <= b
+	case opI32GeS:
+		return int32(a) >= int32(b)
+	}
+	return a >= b
+}
+
+func compareI64(opcode uint16, a uint64, b uint64) bool {
+	switch opcode {
+	case opI64Eq:
+		return a == b
+	case opI64Ne:
+		return a != b
+	case opI64LtS:
+		return int64(a) < int64(b)
+	case opI64LtU:
+		return a < b
+	case opI64GtS:
+		return int64(a) > int64(b)
+	case opI64GtU:
+		return a > b
+	case opI64LeS:
+		return int64(a) <= int64(b)
+	case opI64LeU:
+		return a <= b
+	case opI64GeS:
+		return int64(a) >= int64(b)
+	}
+	return a >= b
+}
+
+func unaryI32(opcode uint16, a uint32) uint32 {
+	switch opcode {
+	case opI32Clz:
+		return uint32(bits.LeadingZeros32(a))
+	case opI32Ctz:
+		return uint32(bits.TrailingZeros32(a))
+	}
+	return uint32(bits.OnesCount32(a))
+}
+
+func unaryI64(opcode uint16, a uint64) uint64 {
+	switch opcode {
+	case opI64Clz:
+		return uint64(bits.LeadingZeros64(a))
+	case opI64Ctz:
+		return uint64(bits.TrailingZeros64(a))
+	}
+	return uint64(bits.OnesCount64(a))
+}
+
+func binaryI32(opcode uint16, a uint32, b uint32) (uint32, error) {
+	switch opcode {
+	case opI32Add:
+		return a + b, nil
+	case opI32Sub:
+		return a - b, nil
+	case opI32Mul:
+		return a * b, nil
+	case opI32DivS:
+		if b == 0 {
+			return 0, ErrIntegerDivideByZero
+		}
+		if int32(a) == math.MinInt32 && int32(b) == -1 {
+			return 0, ErrIntegerOverflow
+		}
+		return uint32(int32(a) / int32(b)), nil
+	case opI32DivU:
+		if b == 0 {
+			return 0, ErrIntegerDivideByZero
+		}
+		return a / b, nil
+	case opI32RemS:
+		if b == 0 {
+			return 0, ErrIntegerDivideByZero
+		}
+		return uint32(int32(a) % int32(b)), nil
+	case opI32RemU:
+		if b == 0 {
+			return 0, ErrIntegerDivideByZero
+		}
+		return a % b, nil
+	case opI32And:
+		return a & b, nil
+	case opI32Or:
+		return a | b, nil
+	case opI32Xor:
+		return a ^ b, nil
+	case opI32Shl:
+		return a << (b & 31), nil
+	case opI32ShrS:
+		return uint32(int32(a) >> (b & 31)), nil
+	case opI32ShrU:
+		return a >> (b & 31), nil
+	case opI32Rotl:
+		return bits.RotateLeft32(a, int(b&31)), nil
+	}
+	return bits.RotateLeft32(a, -int(b&31)), nil
+}
+
+func binaryI64(opcode uint16, a uint64, b uint64) (uint64, error) {
+	switch opcode {
+	case opI64Add:
+		return a + b, nil
+	case opI64Sub:
+		return a - b, nil
+	case opI64Mul:
+		return a * b, nil
+	case opI64DivS:
+		if b == 0 {
+			return 0, ErrIntegerDivideByZero
+		}
+		if int64(a) == math.MinInt64 && int64(b) == -1 {
+			return 0, ErrIntegerOverflow
+		}
+		return uint64(int64(a) / int64(b)), nil
+	case opI64DivU:
+		if b == 0 {
+			return 0, ErrIntegerDivideByZero
+		}
+		return a / b, nil
+	case opI64RemS:
+		if b == 0 {
+			return 0, ErrIntegerDivideByZero
+		}
+		return uint64(int64(a) % int64(b)), nil
+	case opI64RemU:
+		if b == 0 {
+			return 0, ErrIntegerDivideByZero
+		}
+		return a % b, nil
+	case opI64And:
+		return a & b, nil
+	case opI64Or:
+		return a | b, nil
+	case opI64Xor:
+		return a ^ b, nil
+	case opI64Shl:
+		return a << (b & 63), nil
+	case opI64ShrS:
+		return uint64(int64(a) >> (b & 63)), nil
+	case opI64ShrU:
+		return a >> (b & 63), nil
+	case opI64Rotl:
+		return bits.RotateLeft64(a, int(b&63)), nil
+	}
+	return bits.RotateLeft64(a, -int(b&63)), nil
+}
+
+// convert executes the integer conversions and sign extensions
+func convert(opcode uint16, a uint64) uint64 {
+	switch opcode {
+	case opI32WrapI64:
+		return uint64(uint32(a))
+	case opI64ExtendS:
+		return uint64(int64(int32(a)))
+	case opI64ExtendU:
+		return uint64(uint32(a))
+	case opI32Extend8S:
+		return uint64(uint32(int32(int8(a))))
+	case opI32Extend16S:
+		return uint64(uint32(int32(int16(a))))
+	case opI64Extend8S:
+		return uint64(int64(int8(a)))
+	case opI64Extend16S:
+		return uint64(int64(int16(a)))
+	}
+	return uint64(int64(int32(a)))
+}
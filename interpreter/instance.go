@@ -0,0 +1,403 @@
+package interpreter
+
+import (
+	"fmt"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/vmhooksmeta"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/wasmer"
+)
+
+var _ wasmer.InstanceHandler = (*Instance)(nil)
+var _ wasmer.GlobalsHandler = (*Instance)(nil)
+var _ vmhost.HostReferenceHandler = (*Instance)(nil)
+
+// maxCallDepth bounds the nesting of the calls made by the WASM functions of an instance
+const maxCallDepth = 1000
+
+// Instance executes a WASM module with the interpreter; it implements wasmer.InstanceHandler
+type Instance struct {
+	module      *module
+	options     wasmer.CompilationOptions
+	opcodeCosts [wasmer.OpcodeCount]uint32
+	stepHandler StepHandler
+
+	imports    []*vmhooksmeta.EIFunctionBinding
+	context    *vmhost.GoInstanceContext
+	data       uintptr
+	exports    wasmer.ExportsMap
+	signatures wasmer.ExportSignaturesMap
+
+	memory      *Memory
+	globals     []uint64
+	table       []int64
+	droppedData []bool
+	stack       []uint64
+	callDepth   int
+
+	pointsUsed uint64
+	gasLimit   uint64
+	// breakpointValue is accessed atomically, as the host sets it from other goroutines
+	breakpointValue uint64
+	numMemoryGrows  uint64
+
+	alreadyCleaned bool
+}
+
+func newInstance(m *module, engine *Engine, options wasmer.CompilationOptions) (*Instance, error) {
+	engine.mutEngine.RLock()
+	bindings := engine.bindings
+	instance := &Instance{
+		module:      m,
+		options:     options,
+		opcodeCosts: engine.opcodeCosts,
+		stepHandler: engine.stepHandler,
+		gasLimit:    options.GasLimit,
+	}
+	engine.mutEngine.RUnlock()
+
+	if bindings == nil {
+		return nil, ErrNilImports
+	}
+	err := instance.resolveImports(bindings)
+	if err != nil {
+		return nil, err
+	}
+
+	instance.exports = make(wasmer.ExportsMap)
+	instance.signatures = make(wasmer.ExportSignaturesMap)
+	for name, exp := range m.exports {
+		if exp.kind != externalFunction {
+			continue
+		}
+		typ, _ := m.functionType(exp.index)
+		instance.exports[name] = &wasmer.ExportedFunctionCallInfo{FuncName: name}
+		instance.signatures[name] = &wasmer.ExportedFunctionSignature{
+			InputArity:  len(typ.params),
+			OutputArity: len(typ.results),
+		}
+	}
+
+	err = instance.initialize()
+	if err != nil {
+		return nil, err
+	}
+
+	instance.context = vmhost.NewGoInstanceContext()
+	if m.startFunction != nil {
+		err = instance.call(*m.startFunction)
+		if err != nil {
+			instance.Clean()
+			return nil, err
+		}
+	}
+
+	return instance, nil
+}
+
+func (instance *Instance) resolveImports(bindings map[string]map[string]*vmhooksmeta.EIFunctionBinding) error {
+	instance.imports = make([]*vmhooksmeta.EIFunctionBinding, len(instance.module.imports))
+	for i, imported := range instance.module.imports {
+		binding, ok := bindings[imported.module][imported.name]
+		if !ok {
+			return fmt.Errorf("%w: %s.%s", ErrUnresolvedImport, imported.module, imported.name)
+		}
+
+		typ := instance.module.types[imported.typeIndex]
+		if !signatureMatches(typ, binding.Function) {
+			return fmt.Errorf("%w: %s.%s", ErrImportSignatureMismatch, imported.module, imported.name)
+		}
+
+		instance.imports[i] = binding
+	}
+
+	return nil
+}
+
+func signatureMatches(typ *functionType, function vmhooksmeta.EIFunction) bool {
+	return valueTypesMatch(typ.params, function.FunctionInputs) &&
+		valueTypesMatch(typ.results, function.FunctionOutputs)
+}
+
+func valueTypesMatch(types []valueType, eiTypes []vmhooksmeta.EIFunctionValue) bool {
+	if len(types) != len(eiTypes) {
+		return false
+	}
+
+	for i, vt := range types {
+		switch {
+		case vt == valueTypeI32 && eiTypes[i] == vmhooksmeta.EIFunctionValueInt32:
+		case vt == valueTypeI64 && eiTypes[i] == vmhooksmeta.EIFunctionValueInt64:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// initialize sets the globals, the table and the memory to their state at instantiation
+func (instance *Instance) initialize() error {
+	m := instance.module
+
+	instance.globals = make([]uint64, len(m.globals))
+	for i, g := range m.globals {
+		instance.globals[i] = instance.evaluate(g.init)
+	}
+
+	if m.table != nil {
+		instance.table = make([]int64, m.table.min)
+		for i := range instance.table {
+			instance.table[i] = -1
+		}
+		for _, segment := range m.elements {
+			offset := uint64(uint32(instance.evaluate(segment.offset)))
+			if offset+uint64(len(segment.functionIndices)) > uint64(len(instance.table)) {
+				return fmt.Errorf("%w: element segment does not fit", ErrInvalidModule)
+			}
+			for i, functionIndex := range segment.functionIndices {
+				instance.table[offset+uint64(i)] = int64(functionIndex)
+			}
+		}
+	}
+
+	instance.droppedData = make([]bool, len(m.data))
+	if m.memory != nil {
+		instance.memory = newMemory(m.memory)
+		for i, segment := range m.data {
+			if segment.passive {
+				continue
+			}
+
+			offset := uint64(uint32(instance.evaluate(segment.offset)))
+			if offset+uint64(len(segment.data)) > uint64(len(instance.memory.data)) {
+				return fmt.Errorf("%w: data segment does not fit", ErrInvalidModule)
+			}
+			copy(instance.memory.data[offset:], segment.data)
+			instance.droppedData[i] = true
+		}
+	}
+
+	instance.stack = instance.stack[:0]
+	instance.numMemoryGrows = 0
+	return nil
+}
+
+func (instance *Instance) evaluate(expression constantExpression) uint64 {
+	if expression.isGlobalGet {
+		return instance.globals[expression.globalIndex]
+	}
+	return expression.value
+}
+
+// HasMemory returns true if the module defines a linear memory
+func (instance *Instance) HasMemory() bool {
+	return instance.memory != nil
+}
+
+// HasFunction returns true if the module exports a function with the given name
+func (instance *Instance) HasFunction(funcName string) bool {
+	_, ok := instance.exports[funcName]
+	return ok
+}
+
+// CallFunction executes the exported function with the given name, which must not take arguments
+func (instance *Instance) CallFunction(funcName string) (result wasmer.Value, err error) {
+	if instance.alreadyCleaned {
+		return wasmer.Void(), ErrInstanceCleaned
+	}
+
+	exp, ok := instance.module.exports[funcName]
+	if !ok || exp.kind != externalFunction {
+		return wasmer.Void(), wasmer.ErrExportNotFound
+	}
+	signature := instance.signatures[funcName]
+	if signature.InputArity != 0 {
+		return wasmer.Void(), fmt.Errorf("missing %d argument(s) when calling the `%s` exported function",
+			signature.InputArity, funcName)
+	}
+
+	stackHeight := len(instance.stack)
+	callDepth := instance.callDepth
+	defer func() {
+		recovered := recover()
+		if recovered != nil {
+			err = fmt.Errorf("%w: %v", ErrTrap, recovered)
+		}
+		if err != nil {
+			result = wasmer.Void()
+			instance.stack = instance.stack[:stackHeight]
+			instance.callDepth = callDepth
+		}
+	}()
+
+	err = instance.call(exp.index)
+	if err != nil {
+		return wasmer.Void(), err
+	}
+
+	typ, _ := instance.module.functionType(exp.index)
+	results := instance.stack[stackHeight:]
+	instance.stack = instance.stack[:stackHeight]
+	if len(results) == 0 {
+		return wasmer.Void(), nil
+	}
+	if typ.results[0] == valueTypeI64 {
+		return wasmer.I64(int64(results[0])), nil
+	}
+	return wasmer.I32(int32(results[0])), nil
+}
+
+// SetContextData sets the data returned by GetData; the host reference passed to the EI functions is
+// set through SetHostReference
+func (instance *Instance) SetContextData(data uintptr) {
+	instance.data = data
+}
+
+// SetHostReference sets the host passed to the EI functions, through the instance context
+func (instance *Instance) SetHostReference(host *vmhost.VMHost) {
+	instance.data = uintptr(unsafe.Pointer(host))
+	instance.context.SetHost(host)
+}
+
+// GetData returns the data set by SetContextData
+func (instance *Instance) GetData() uintptr {
+	return instance.data
+}
+
+// GetPointsUsed returns the points used by the executions
+func (instance *Instance) GetPointsUsed() uint64 {
+	return instance.pointsUsed
+}
+
+// SetPointsUsed sets the points used by the executions
+func (instance *Instance) SetPointsUsed(points uint64) {
+	instance.pointsUsed = points
+}
+
+// SetGasLimit sets the points after which the execution stops with the out of gas breakpoint
+func (instance *Instance) SetGasLimit(gasLimit uint64) {
+	instance.gasLimit = gasLimit
+}
+
+// SetBreakpointValue sets the runtime breakpoint, which stops the execution when the current EI function returns;
+// it may be called from another goroutine than the one executing the instance
+func (instance *Instance) SetBreakpointValue(value uint64) {
+	atomic.StoreUint64(&instance.breakpointValue, value)
+}
+
+// GetBreakpointValue returns the runtime breakpoint
+func (instance *Instance) GetBreakpointValue() uint64 {
+	return atomic.LoadUint64(&instance.breakpointValue)
+}
+
+// Cache returns the bytecode of the module, from which NewInstanceFromCompiledCodeWithOptions recreates the instance
+func (instance *Instance) Cache() ([]byte, error) {
+	if instance.alreadyCleaned {
+		return nil, ErrInstanceCleaned
+	}
+
+	return append([]byte(nil), instance.module.bytecode...), nil
+}
+
+// Clean releases the instance context and the memory
+func (instance *Instance) Clean() bool {
+	if instance.alreadyCleaned {
+		return false
+	}
+
+	instance.alreadyCleaned = true
+	instance.context.Release()
+	if instance.memory != nil {
+		instance.memory.Destroy()
+	}
+	return true
+}
+
+// AlreadyCleaned returns true if the instance was cleaned
+func (instance *Instance) AlreadyCleaned() bool {
+	return instance.alreadyCleaned
+}
+
+// GetExports returns the exported functions
+func (instance *Instance) GetExports() wasmer.ExportsMap {
+	return instance.exports
+}
+
+// GetSignature returns the signature of the exported function with the given name
+func (instance *Instance) GetSignature(functionName string) (*wasmer.ExportedFunctionSignature, bool) {
+	signature, ok := instance.signatures[functionName]
+	return signature, ok
+}
+
+// GetInstanceCtxMemory returns the linear memory
+func (instance *Instance) GetInstanceCtxMemory() wasmer.MemoryHandler {
+	return instance.GetMemory()
+}
+
+// GetMemory returns the linear memory
+func (instance *Instance) GetMemory() wasmer.MemoryHandler {
+	if instance.memory == nil {
+		return nil
+	}
+	return instance.memory
+}
+
+// SetMemory overwrites the linear memory with the given data, which must have the size of the memory
+func (instance *Instance) SetMemory(data []byte) bool {
+	if instance.alreadyCleaned || instance.memory == nil || len(instance.memory.data) != len(data) {
+		return false
+	}
+
+	copy(instance.memory.data, data)
+	return true
+}
+
+// GetGlobals returns a copy of the values of the globals
+func (instance *Instance) GetGlobals() []uint64 {
+	globals := make([]uint64, len(instance.globals))
+	copy(globals, instance.globals)
+	return globals
+}
+
+// SetGlobals overwrites the values of the globals with the given ones, which must be as many as the globals
+func (instance *Instance) SetGlobals(values []uint64) bool {
+	if instance.alreadyCleaned || len(instance.globals) != len(values) {
+		return false
+	}
+
+	copy(instance.globals, values)
+	return true
+}
+
+// IsFunctionImported returns true if the module imports the EI function with the given name
+func (instance *Instance) IsFunctionImported(name string) bool {
+	for _, imported := range instance.module.imports {
+		if imported.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Reset brings the memory, the globals and the table back to their state at instantiation
+func (instance *Instance) Reset() bool {
+	if instance.alreadyCleaned {
+		return false
+	}
+
+	err := instance.initialize()
+	return err == nil
+}
+
+// ID returns an identifier of the instance
+func (instance *Instance) ID() string {
+	return fmt.Sprintf("%p", instance)
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (instance *Instance) IsInterfaceNil() bool {
+	return instance == nil
+}
@@ -0,0 +1,481 @@
+package interpreter
+
+import (
+	"runtime"
+	"testing"
+	"unsafe"
+
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/vmhooksmeta"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/wasmer"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	i32 = byte(valueTypeI32)
+	i64 = byte(valueTypeI64)
+)
+
+// testModule assembles a WASM module from its sections
+type testModule struct {
+	types     [][]byte
+	imports   [][]byte
+	functions [][]byte
+	memory    [][]byte
+	globals   [][]byte
+	exports   [][]byte
+	bodies    [][]byte
+	data      [][]byte
+}
+
+func leb(value uint32) []byte {
+	var result []byte
+	for {
+		b := byte(value & 0x7f)
+		value >>= 7
+		if value == 0 {
+			return append(result, b)
+		}
+		result = append(result, b|0x80)
+	}
+}
+
+func name(s string) []byte {
+	return append(leb(uint32(len(s))), s...)
+}
+
+func vector(items ...[]byte) []byte {
+	result := leb(uint32(len(items)))
+	for _, item := range items {
+		result = append(result, item...)
+	}
+	return result
+}
+
+func concat(parts ...[]byte) []byte {
+	var result []byte
+	for _, part := range parts {
+		result = append(result, part...)
+	}
+	return result
+}
+
+func (tm *testModule) addType(params []byte, results []byte) uint32 {
+	tm.types = append(tm.types, concat([]byte{functionTypeForm}, vector(bytesToItems(params)...), vector(bytesToItems(results)...)))
+	return uint32(len(tm.types) - 1)
+}
+
+func (tm *testModule) addImport(function string, typeIndex uint32) {
+	tm.imports = append(tm.imports, concat(name("env"), name(function), []byte{externalFunction}, leb(typeIndex)))
+}
+
+func (tm *testModule) addFunction(export string, typeIndex uint32, locals []byte, code ...byte) {
+	index := uint32(len(tm.imports) + len(tm.functions))
+	tm.functions = append(tm.functions, leb(typeIndex))
+	var localEntries [][]byte
+	for _, vt := range locals {
+		localEntries = append(localEntries, []byte{1, vt})
+	}
+	body := concat(vector(localEntries...), code)
+	tm.bodies = append(tm.bodies, concat(leb(uint32(len(body))), body))
+	if export != "" {
+		tm.exports = append(tm.exports, concat(name(export), []byte{externalFunction}, leb(index)))
+	}
+}
+
+func (tm *testModule) bytes() []byte {
+	result := concat(wasmMagic, wasmVersion)
+	sections := []struct {
+		id    byte
+		items [][]byte
+	}{
+		{sectionType, tm.types},
+		{sectionImport, tm.imports},
+		{sectionFunction, tm.functions},
+		{sectionMemory, tm.memory},
+		{sectionGlobal, tm.globals},
+		{sectionExport, tm.exports},
+		{sectionCode, tm.bodies},
+		{sectionData, tm.data},
+	}
+	for _, section := range sections {
+		if len(section.items) == 0 {
+			continue
+		}
+		content := vector(section.items...)
+		result = append(result, section.id)
+		result = append(result, leb(uint32(len(content)))...)
+		result = append(result, content...)
+	}
+	return result
+}
+
+func bytesToItems(types []byte) [][]byte {
+	items := make([][]byte, len(types))
+	for i, vt := range types {
+		items[i] = []byte{vt}
+	}
+	return items
+}
+
+// factorialModule exports "main", computing the factorial of 5 recursively
+func factorialModule() []byte {
+	tm := &testModule{}
+	mainType := tm.addType(nil, []byte{i32})
+	factorialType := tm.addType([]byte{i32}, []byte{i32})
+	tm.addFunction("main", mainType, nil,
+		opI32Const, 5, opCall, 1, opEnd)
+	tm.addFunction("", factorialType, nil,
+		opLocalGet, 0, opI32Eqz,
+		opIf, i32,
+		opI32Const, 1,
+		opElse,
+		opLocalGet, 0, opLocalGet, 0, opI32Const, 1, opI32Sub, opCall, 1, opI32Mul,
+		opEnd,
+		opEnd)
+	return tm.bytes()
+}
+
+// sumModule exports "main", summing the numbers from 1 to 10 in a loop
+func sumModule() []byte {
+	tm := &testModule{}
+	mainType := tm.addType(nil, []byte{i64})
+	tm.addFunction("main", mainType, []byte{i64, i64},
+		opLoop, 0x40,
+		opLocalGet, 0, opI64Const, 1, opI64Add, opLocalTee, 0,
+		opLocalGet, 1, opI64Add, opLocalSet, 1,
+		opLocalGet, 0, opI64Const, 10, opI64LtU, opBrIf, 0,
+		opEnd,
+		opLocalGet, 1,
+		opEnd)
+	return tm.bytes()
+}
+
+func newTestEngine(t *testing.T, imports *vmhooksmeta.EIFunctions, opcodeCosts map[int]uint32) *Engine {
+	engine := NewEngine()
+	if imports == nil {
+		imports = vmhooksmeta.NewEIFunctions()
+	}
+	require.Nil(t, engine.SetImports(imports))
+
+	costs := [wasmer.OpcodeCount]uint32{}
+	for index, cost := range opcodeCosts {
+		costs[index] = cost
+	}
+	engine.SetOpcodeCosts(&costs)
+	return engine
+}
+
+func newTestInstance(t *testing.T, engine *Engine, code []byte, options wasmer.CompilationOptions) *Instance {
+	instance, err := engine.NewInstanceWithOptions(code, options)
+	require.Nil(t, err)
+	t.Cleanup(func() {
+		instance.Clean()
+	})
+	return instance.(*Instance)
+}
+
+func meteredOptions(gasLimit uint64) wasmer.CompilationOptions {
+	return wasmer.CompilationOptions{
+		GasLimit:           gasLimit,
+		MaxMemoryGrow:      10,
+		MaxMemoryGrowDelta: 10,
+		Metering:           true,
+		RuntimeBreakpoints: true,
+	}
+}
+
+func TestInstance_CallFunction(t *testing.T) {
+	engine := newTestEngine(t, nil, map[int]uint32{wasmer.OpcodeCall: 10})
+	instance := newTestInstance(t, engine, factorialModule(), meteredOptions(1000))
+
+	require.True(t, instance.HasFunction("main"))
+	require.False(t, instance.HasFunction("factorial"))
+	signature, ok := instance.GetSignature("main")
+	require.True(t, ok)
+	require.Equal(t, &wasmer.ExportedFunctionSignature{InputArity: 0, OutputArity: 1}, signature)
+
+	result, err := instance.CallFunction("main")
+	require.Nil(t, err)
+	require.Equal(t, wasmer.I32(120), result)
+	// main calls the factorial of 5, which calls itself down to the factorial of 0
+	require.Equal(t, uint64(60), instance.GetPointsUsed())
+
+	_, err = instance.CallFunction("missing")
+	require.Equal(t, wasmer.ErrExportNotFound, err)
+}
+
+func TestInstance_OutOfGas(t *testing.T) {
+	engine := newTestEngine(t, nil, map[int]uint32{wasmer.OpcodeCall: 10})
+	instance := newTestInstance(t, engine, factorialModule(), meteredOptions(50))
+
+	_, err := instance.CallFunction("main")
+	require.Equal(t, ErrRuntimeBreakpoint, err)
+	require.Equal(t, uint64(vmhost.BreakpointOutOfGas), instance.GetBreakpointValue())
+	require.Empty(t, instance.stack)
+}
+
+func TestInstance_LoopAndLocalAllocation(t *testing.T) {
+	engine := newTestEngine(t, nil, map[int]uint32{wasmer.OpcodeLocalAllocate: 3})
+
+	options := meteredOptions(1000)
+	instance := newTestInstance(t, engine, sumModule(), options)
+	result, err := instance.CallFunction("main")
+	require.Nil(t, err)
+	require.Equal(t, wasmer.I64(55), result)
+	require.Equal(t, uint64(6), instance.GetPointsUsed())
+
+	options.UnmeteredLocals = 1
+	instance = newTestInstance(t, engine, sumModule(), options)
+	_, err = instance.CallFunction("main")
+	require.Nil(t, err)
+	require.Equal(t, uint64(3), instance.GetPointsUsed())
+}
+
+func TestInstance_StepHandler(t *testing.T) {
+	engine := newTestEngine(t, nil, map[int]uint32{wasmer.OpcodeI64Add: 1})
+
+	var steps []*Step
+	engine.SetStepHandler(func(step *Step) error {
+		steps = append(steps, step)
+		return nil
+	})
+	instance := newTestInstance(t, engine, sumModule(), meteredOptions(1000))
+
+	_, err := instance.CallFunction("main")
+	require.Nil(t, err)
+	require.Equal(t, uint64(20), instance.GetPointsUsed())
+
+	last := steps[len(steps)-1]
+	require.Equal(t, "main", last.FunctionName)
+	require.Equal(t, "end", last.Opcode)
+	require.Equal(t, []uint64{10, 55}, last.Locals)
+	require.Equal(t, []uint64{55}, last.Stack)
+	require.Equal(t, 1, last.CallDepth)
+	require.Equal(t, uint64(20), last.PointsUsed)
+
+	comparisons := 0
+	for _, step := range steps {
+		if step.Opcode == "i64.lt_u" {
+			require.Len(t, step.Stack, 2)
+			require.Equal(t, uint64(10), step.Stack[1])
+			comparisons++
+		}
+	}
+	require.Equal(t, 10, comparisons)
+
+	errStop := vmhooksmeta.NewImportedFunctionError("stop", "stop")
+	engine.SetStepHandler(func(step *Step) error {
+		return errStop
+	})
+	instance = newTestInstance(t, engine, sumModule(), meteredOptions(1000))
+	_, err = instance.CallFunction("main")
+	require.Equal(t, errStop, err)
+}
+
+func TestInstance_MemoryLimits(t *testing.T) {
+	tm := &testModule{}
+	mainType := tm.addType(nil, []byte{i32})
+	tm.memory = [][]byte{{1, 1, 3}}
+	tm.addFunction("main", mainType, nil,
+		opI32Const, 2, opMemoryGrow, 0, opEnd)
+	code := tm.bytes()
+	engine := newTestEngine(t, nil, nil)
+
+	options := meteredOptions(1000)
+	instance := newTestInstance(t, engine, code, options)
+	result, err := instance.CallFunction("main")
+	require.Nil(t, err)
+	require.Equal(t, wasmer.I32(1), result)
+	require.Equal(t, uint32(3*pageSize), instance.GetMemory().Length())
+
+	result, err = instance.CallFunction("main")
+	require.Nil(t, err)
+	require.Equal(t, wasmer.I32(-1), result)
+
+	options.MaxMemoryGrowDelta = 1
+	instance = newTestInstance(t, engine, code, options)
+	_, err = instance.CallFunction("main")
+	require.Equal(t, ErrRuntimeBreakpoint, err)
+	require.Equal(t, uint64(vmhost.BreakpointMemoryLimit), instance.GetBreakpointValue())
+
+	options = meteredOptions(1000)
+	options.MaxMemoryGrow = 1
+	instance = newTestInstance(t, engine, code, options)
+	_, err = instance.CallFunction("main")
+	require.Nil(t, err)
+	_, err = instance.CallFunction("main")
+	require.Equal(t, ErrRuntimeBreakpoint, err)
+	require.Equal(t, uint64(vmhost.BreakpointMemoryLimit), instance.GetBreakpointValue())
+}
+
+func TestInstance_Imports(t *testing.T) {
+	var instance *Instance
+	numDoubleCalls := 0
+	imports := vmhooksmeta.NewEIFunctions()
+	require.Nil(t, imports.Append("double", func(context unsafe.Pointer, value int64) int64 {
+		require.Equal(t, instance.context.Pointer(), context)
+		numDoubleCalls++
+		return 2 * value
+	}, nil))
+	require.Nil(t, imports.Append("fail", func(_ unsafe.Pointer) {
+		instance.SetBreakpointValue(uint64(vmhost.BreakpointExecutionFailed))
+	}, nil))
+	engine := newTestEngine(t, imports, nil)
+
+	tm := &testModule{}
+	doubleType := tm.addType([]byte{i64}, []byte{i64})
+	failType := tm.addType(nil, nil)
+	mainType := tm.addType(nil, []byte{i64})
+	tm.addImport("double", doubleType)
+	tm.addImport("fail", failType)
+	tm.addFunction("main", mainType, nil,
+		opI64Const, 21, opCall, 0, opEnd)
+	tm.addFunction("fail", failType, nil,
+		opCall, 1, opUnreachable, opEnd)
+
+	instance = newTestInstance(t, engine, tm.bytes(), meteredOptions(1000))
+	require.True(t, instance.IsFunctionImported("double"))
+	require.False(t, instance.IsFunctionImported("main"))
+
+	result, err := instance.CallFunction("main")
+	require.Nil(t, err)
+	require.Equal(t, wasmer.I64(42), result)
+	require.Equal(t, 1, numDoubleCalls)
+
+	_, err = instance.CallFunction("fail")
+	require.Equal(t, ErrRuntimeBreakpoint, err)
+
+	tm = &testModule{}
+	tm.addImport("triple", tm.addType([]byte{i64}, []byte{i64}))
+	_, err = engine.NewInstanceWithOptions(tm.bytes(), meteredOptions(1000))
+	require.ErrorIs(t, err, ErrUnresolvedImport)
+
+	tm = &testModule{}
+	tm.addImport("double", tm.addType([]byte{i32}, []byte{i64}))
+	_, err = engine.NewInstanceWithOptions(tm.bytes(), meteredOptions(1000))
+	require.ErrorIs(t, err, ErrImportSignatureMismatch)
+}
+
+func TestInstance_BreakpointSetFromAnotherGoroutine(t *testing.T) {
+	var instance *Instance
+	imports := vmhooksmeta.NewEIFunctions()
+	require.Nil(t, imports.Append("waitForBreakpoint", func(_ unsafe.Pointer) {
+		go instance.SetBreakpointValue(uint64(vmhost.BreakpointExecutionFailed))
+		for instance.GetBreakpointValue() == uint64(vmhost.BreakpointNone) {
+			runtime.Gosched()
+		}
+	}, nil))
+	engine := newTestEngine(t, imports, nil)
+
+	tm := &testModule{}
+	waitType := tm.addType(nil, nil)
+	tm.addImport("waitForBreakpoint", waitType)
+	tm.addFunction("main", waitType, nil,
+		opCall, 0, opEnd)
+
+	instance = newTestInstance(t, engine, tm.bytes(), meteredOptions(1000))
+	_, err := instance.CallFunction("main")
+	require.Equal(t, ErrRuntimeBreakpoint, err)
+	require.Equal(t, uint64(vmhost.BreakpointExecutionFailed), instance.GetBreakpointValue())
+}
+
+func TestInstance_Traps(t *testing.T) {
+	tm := &testModule{}
+	mainType := tm.addType(nil, []byte{i32})
+	tm.memory = [][]byte{{0, 1}}
+	tm.addFunction("divideByZero", mainType, nil,
+		opI32Const, 1, opI32Const, 0, opI32DivU, opEnd)
+	tm.addFunction("outOfBounds", mainType, nil,
+		opI32Const, 0x80, 0x80, 0x04, opI32Load, 2, 0, opEnd)
+	tm.addFunction("unreachable", mainType, nil,
+		opUnreachable, opEnd)
+	instance := newTestInstance(t, newTestEngine(t, nil, nil), tm.bytes(), meteredOptions(1000))
+
+	_, err := instance.CallFunction("divideByZero")
+	require.Equal(t, ErrIntegerDivideByZero, err)
+	_, err = instance.CallFunction("outOfBounds")
+	require.Equal(t, ErrOutOfBoundsMemoryAccess, err)
+	_, err = instance.CallFunction("unreachable")
+	require.Equal(t, ErrUnreachable, err)
+	require.Empty(t, instance.stack)
+}
+
+func TestInstance_ResetAndCache(t *testing.T) {
+	tm := &testModule{}
+	mainType := tm.addType(nil, nil)
+	tm.memory = [][]byte{{0, 1}}
+	tm.globals = [][]byte{{i32, 1, opI32Const, 7, opEnd}}
+	tm.data = [][]byte{concat([]byte{0, opI32Const, 0, opEnd}, name("abc"))}
+	tm.addFunction("main", mainType, nil,
+		opI32Const, 0, opI32Const, 'z'|0x80, 0, opI32Store8, 0, 0,
+		opI32Const, 8, opGlobalSet, 0,
+		opEnd)
+	code := tm.bytes()
+	engine := newTestEngine(t, nil, nil)
+	instance := newTestInstance(t, engine, code, meteredOptions(1000))
+
+	require.Equal(t, []byte("abc"), instance.GetMemory().Data()[:3])
+	_, err := instance.CallFunction("main")
+	require.Nil(t, err)
+	require.Equal(t, []byte("zbc"), instance.GetMemory().Data()[:3])
+	require.Equal(t, uint64(8), instance.globals[0])
+
+	require.True(t, instance.Reset())
+	require.Equal(t, []byte("abc"), instance.GetMemory().Data()[:3])
+	require.Equal(t, uint64(7), instance.globals[0])
+
+	compiledCode, err := instance.Cache()
+	require.Nil(t, err)
+	fromCache, err := engine.NewInstanceFromCompiledCodeWithOptions(compiledCode, meteredOptions(1000))
+	require.Nil(t, err)
+	require.True(t, fromCache.HasFunction("main"))
+	fromCache.Clean()
+
+	require.True(t, instance.Clean())
+	require.True(t, instance.AlreadyCleaned())
+	require.False(t, instance.Reset())
+	_, err = instance.CallFunction("main")
+	require.Equal(t, ErrInstanceCleaned, err)
+}
+
+func TestInstance_GetAndSetGlobals(t *testing.T) {
+	tm := &testModule{}
+	tm.globals = [][]byte{{i32, 1, opI32Const, 7, opEnd}, {i64, 1, opI64Const, 9, opEnd}}
+	instance := newTestInstance(t, newTestEngine(t, nil, nil), tm.bytes(), meteredOptions(1000))
+
+	globals := instance.GetGlobals()
+	require.Equal(t, []uint64{7, 9}, globals)
+	globals[0] = 1
+	require.Equal(t, []uint64{7, 9}, instance.GetGlobals())
+
+	require.False(t, instance.SetGlobals([]uint64{1}))
+	require.True(t, instance.SetGlobals([]uint64{1, 2}))
+	require.Equal(t, []uint64{1, 2}, instance.GetGlobals())
+
+	require.True(t, instance.Clean())
+	require.False(t, instance.SetGlobals([]uint64{3, 4}))
+}
+
+func TestEngine_RejectsUnsupportedModules(t *testing.T) {
+	engine := newTestEngine(t, nil, nil)
+
+	_, err := engine.NewInstanceWithOptions([]byte("not wasm"), meteredOptions(1000))
+	require.ErrorIs(t, err, ErrInvalidModule)
+
+	tm := &testModule{}
+	tm.addFunction("float", tm.addType(nil, nil), nil,
+		0x43, 0, 0, 0, 0, opDrop, opEnd)
+	_, err = engine.NewInstanceWithOptions(tm.bytes(), meteredOptions(1000))
+	require.ErrorIs(t, err, ErrUnsupportedFeature)
+
+	tm = &testModule{}
+	tm.memory = [][]byte{{0, 1}}
+	tm.addFunction("grow", tm.addType(nil, nil), nil,
+		opI32Const, 1, opMemoryGrow, 1, opDrop, opEnd)
+	_, err = engine.NewInstanceWithOptions(tm.bytes(), meteredOptions(1000))
+	require.ErrorIs(t, err, ErrUnsupportedFeature)
+
+	_, err = NewEngine().NewInstanceWithOptions(sumModule(), meteredOptions(1000))
+	require.Equal(t, ErrNilImports, err)
+}
@@ -0,0 +1,75 @@
+package interpreter
+
+import (
+	"fmt"
+
+	"github.com/multiversx/mx-chain-vm-v1_4-go/wasmer"
+)
+
+var _ wasmer.MemoryHandler = (*Memory)(nil)
+
+// pageSize is the size of a page of linear memory
+const pageSize = 65536
+
+// Memory is the linear memory of an interpreter instance
+type Memory struct {
+	data     []byte
+	maxPages uint32
+}
+
+func newMemory(memoryLimits *limits) *Memory {
+	memory := &Memory{
+		data:     make([]byte, int(memoryLimits.min)*pageSize),
+		maxPages: maxMemoryPages,
+	}
+	if memoryLimits.hasMax {
+		memory.maxPages = memoryLimits.max
+	}
+
+	return memory
+}
+
+// Length returns the size of the memory, in bytes
+func (memory *Memory) Length() uint32 {
+	return uint32(len(memory.data))
+}
+
+// Data returns the contents of the memory
+func (memory *Memory) Data() []byte {
+	return memory.data
+}
+
+// Grow grows the memory by the given number of pages
+func (memory *Memory) Grow(pages uint32) error {
+	if uint64(memory.pages())+uint64(pages) > uint64(memory.maxPages) {
+		return fmt.Errorf("cannot grow memory of %d pages by %d pages, the maximum is %d pages",
+			memory.pages(), pages, memory.maxPages)
+	}
+
+	memory.data = append(memory.data, make([]byte, int(pages)*pageSize)...)
+	return nil
+}
+
+// Destroy releases the contents of the memory
+func (memory *Memory) Destroy() {
+	memory.data = nil
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (memory *Memory) IsInterfaceNil() bool {
+	return memory == nil
+}
+
+func (memory *Memory) pages() uint32 {
+	return uint32(len(memory.data) / pageSize)
+}
+
+// effectiveAddress checks that the given number of bytes can be accessed at the address and offset
+func (memory *Memory) effectiveAddress(address uint32, offset uint32, size uint32) (uint64, error) {
+	effective := uint64(address) + uint64(offset)
+	if effective+uint64(size) > uint64(len(memory.data)) {
+		return 0, ErrOutOfBoundsMemoryAccess
+	}
+
+	return effective, nil
+}
@@ -0,0 +1,643 @@
+package interpreter
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// valueType is the type of a WASM value; only the integer types are supported
+type valueType byte
+
+const (
+	valueTypeI32 valueType = 0x7f
+	valueTypeI64 valueType = 0x7e
+)
+
+const (
+	sectionCustom    = 0
+	sectionType      = 1
+	sectionImport    = 2
+	sectionFunction  = 3
+	sectionTable     = 4
+	sectionMemory    = 5
+	sectionGlobal    = 6
+	sectionExport    = 7
+	sectionStart     = 8
+	sectionElement   = 9
+	sectionCode      = 10
+	sectionData      = 11
+	sectionDataCount = 12
+)
+
+const (
+	externalFunction = 0x00
+	externalTable    = 0x01
+	externalMemory   = 0x02
+	externalGlobal   = 0x03
+)
+
+const funcRefType = 0x70
+const functionTypeForm = 0x60
+
+// maxMemoryPages is the number of pages of the largest linear memory addressable with 32 bits
+const maxMemoryPages = 65536
+
+// maxDeclaredMemoryPages bounds the pages of the memory declared by a module, as Wasmer does
+const maxDeclaredMemoryPages = 20
+
+var wasmMagic = []byte{0x00, 0x61, 0x73, 0x6d}
+var wasmVersion = []byte{0x01, 0x00, 0x00, 0x00}
+
+type functionType struct {
+	params  []valueType
+	results []valueType
+}
+
+func (ft *functionType) equals(other *functionType) bool {
+	return bytes.Equal(valueTypesToBytes(ft.params), valueTypesToBytes(other.params)) &&
+		bytes.Equal(valueTypesToBytes(ft.results), valueTypesToBytes(other.results))
+}
+
+type importedFunction struct {
+	module    string
+	name      string
+	typeIndex uint32
+}
+
+type limits struct {
+	min    uint32
+	max    uint32
+	hasMax bool
+}
+
+type global struct {
+	valueType valueType
+	mutable   bool
+	init      constantExpression
+}
+
+// constantExpression is an initializer: either a constant or the value of an imported global
+type constantExpression struct {
+	value       uint64
+	globalIndex uint32
+	isGlobalGet bool
+}
+
+type export struct {
+	kind  byte
+	index uint32
+}
+
+type elementSegment struct {
+	offset          constantExpression
+	functionIndices []uint32
+}
+
+type dataSegment struct {
+	offset  constantExpression
+	data    []byte
+	passive bool
+}
+
+type functionBody struct {
+	locals []valueType
+	code   []byte
+	// codeOffset is the offset of the code in the module, reported by the step handler
+	codeOffset int
+}
+
+// module is a decoded WASM module, shared by all the instances created from the same bytecode
+type module struct {
+	types           []*functionType
+	imports         []*importedFunction
+	functionTypes   []uint32
+	table           *limits
+	memory          *limits
+	globals         []*global
+	exports         map[string]*export
+	startFunction   *uint32
+	elements        []*elementSegment
+	bodies          []*functionBody
+	data            []*dataSegment
+	functionNames   map[uint32]string
+	compiled        []*compiledFunction
+	bytecode        []byte
+	numImportedFunc uint32
+}
+
+func decodeModule(bytecode []byte) (*module, error) {
+	r := newReader(bytecode)
+	magic, err := r.readBytes(4)
+	if err != nil || !bytes.Equal(magic, wasmMagic) {
+		return nil, fmt.Errorf("%w: wrong magic number", ErrInvalidModule)
+	}
+	version, err := r.readBytes(4)
+	if err != nil || !bytes.Equal(version, wasmVersion) {
+		return nil, fmt.Errorf("%w: unsupported version", ErrInvalidModule)
+	}
+
+	m := &module{
+		exports:       make(map[string]*export),
+		functionNames: make(map[uint32]string),
+		bytecode:      bytecode,
+	}
+
+	lastSection := 0
+	for r.hasMore() {
+		sectionID, err := r.readByte()
+		if err != nil {
+			return nil, err
+		}
+		size, err := r.readU32()
+		if err != nil {
+			return nil, err
+		}
+		sectionStart := r.offset
+		content, err := r.readBytes(size)
+		if err != nil {
+			return nil, err
+		}
+
+		if sectionID != sectionCustom {
+			order := sectionOrder(sectionID)
+			if order <= lastSection {
+				return nil, r.errorf("unexpected section %d", sectionID)
+			}
+			lastSection = order
+		}
+
+		sr := &reader{data: bytecode[:sectionStart+len(content)], offset: sectionStart}
+		err = m.decodeSection(sectionID, sr)
+		if err != nil {
+			return nil, err
+		}
+		if sr.hasMore() {
+			return nil, sr.errorf("section %d size mismatch", sectionID)
+		}
+	}
+
+	if len(m.functionTypes) != len(m.bodies) {
+		return nil, fmt.Errorf("%w: function and code section sizes differ", ErrInvalidModule)
+	}
+
+	for name, exp := range m.exports {
+		if exp.kind == externalFunction {
+			m.functionNames[exp.index] = name
+		}
+	}
+
+	err = m.compileFunctions()
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (m *module) decodeSection(sectionID byte, r *reader) error {
+	switch sectionID {
+	case sectionCustom:
+		r.offset = len(r.data)
+		return nil
+	case sectionType:
+		return m.decodeTypes(r)
+	case sectionImport:
+		return m.decodeImports(r)
+	case sectionFunction:
+		return m.decodeFunctions(r)
+	case sectionTable:
+		return m.decodeTable(r)
+	case sectionMemory:
+		return m.decodeMemory(r)
+	case sectionGlobal:
+		return m.decodeGlobals(r)
+	case sectionExport:
+		return m.decodeExports(r)
+	case sectionStart:
+		index, err := r.readU32()
+		if err != nil {
+			return err
+		}
+		m.startFunction = &index
+		return nil
+	case sectionElement:
+		return m.decodeElements(r)
+	case sectionCode:
+		return m.decodeCode(r)
+	case sectionData:
+		return m.decodeData(r)
+	case sectionDataCount:
+		_, err := r.readU32()
+		return err
+	}
+
+	return r.errorf("unknown section %d", sectionID)
+}
+
+// sectionOrder returns the position of the section in the module, the data count section
+// preceding the code section despite its greater id
+func sectionOrder(sectionID byte) int {
+	if sectionID == sectionDataCount {
+		return 2*sectionCode - 1
+	}
+	return 2 * int(sectionID)
+}
+
+func readVector(r *reader, decodeItem func() error) error {
+	count, err := r.readU32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < count; i++ {
+		err = decodeItem()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readValueType(r *reader) (valueType, error) {
+	b, err := r.readByte()
+	if err != nil {
+		return 0, err
+	}
+
+	switch valueType(b) {
+	case valueTypeI32, valueTypeI64:
+		return valueType(b), nil
+	}
+
+	return 0, fmt.Errorf("%w: value type 0x%x", ErrUnsupportedFeature, b)
+}
+
+func readValueTypes(r *reader) ([]valueType, error) {
+	types := make([]valueType, 0)
+	err := readVector(r, func() error {
+		vt, err := readValueType(r)
+		types = append(types, vt)
+		return err
+	})
+
+	return types, err
+}
+
+func (m *module) decodeTypes(r *reader) error {
+	return readVector(r, func() error {
+		form, err := r.readByte()
+		if err != nil {
+			return err
+		}
+		if form != functionTypeForm {
+			return r.errorf("unknown type form 0x%x", form)
+		}
+
+		params, err := readValueTypes(r)
+		if err != nil {
+			return err
+		}
+		results, err := readValueTypes(r)
+		if err != nil {
+			return err
+		}
+
+		m.types = append(m.types, &functionType{params: params, results: results})
+		return nil
+	})
+}
+
+func (m *module) decodeImports(r *reader) error {
+	return readVector(r, func() error {
+		moduleName, err := r.readName()
+		if err != nil {
+			return err
+		}
+		name, err := r.readName()
+		if err != nil {
+			return err
+		}
+		kind, err := r.readByte()
+		if err != nil {
+			return err
+		}
+		if kind != externalFunction {
+			return fmt.Errorf("%w: import of kind %d (%s.%s)", ErrUnsupportedFeature, kind, moduleName, name)
+		}
+
+		typeIndex, err := r.readU32()
+		if err != nil {
+			return err
+		}
+		if typeIndex >= uint32(len(m.types)) {
+			return r.errorf("unknown type %d", typeIndex)
+		}
+
+		m.imports = append(m.imports, &importedFunction{module: moduleName, name: name, typeIndex: typeIndex})
+		m.numImportedFunc++
+		return nil
+	})
+}
+
+func (m *module) decodeFunctions(r *reader) error {
+	return readVector(r, func() error {
+		typeIndex, err := r.readU32()
+		if err != nil {
+			return err
+		}
+		if typeIndex >= uint32(len(m.types)) {
+			return r.errorf("unknown type %d", typeIndex)
+		}
+
+		m.functionTypes = append(m.functionTypes, typeIndex)
+		return nil
+	})
+}
+
+func readLimits(r *reader) (*limits, error) {
+	flags, err := r.readByte()
+	if err != nil {
+		return nil, err
+	}
+	if flags > 1 {
+		return nil, fmt.Errorf("%w: limits flags 0x%x", ErrUnsupportedFeature, flags)
+	}
+
+	l := &limits{hasMax: flags == 1}
+	l.min, err = r.readU32()
+	if err != nil {
+		return nil, err
+	}
+	if l.hasMax {
+		l.max, err = r.readU32()
+		if err != nil {
+			return nil, err
+		}
+		if l.max < l.min {
+			return nil, r.errorf("size minimum must not be greater than maximum")
+		}
+	}
+
+	return l, nil
+}
+
+func (m *module) decodeTable(r *reader) error {
+	return readVector(r, func() error {
+		if m.table != nil {
+			return fmt.Errorf("%w: multiple tables", ErrUnsupportedFeature)
+		}
+
+		elementType, err := r.readByte()
+		if err != nil {
+			return err
+		}
+		if elementType != funcRefType {
+			return fmt.Errorf("%w: table element type 0x%x", ErrUnsupportedFeature, elementType)
+		}
+
+		m.table, err = readLimits(r)
+		return err
+	})
+}
+
+func (m *module) decodeMemory(r *reader) error {
+	return readVector(r, func() error {
+		if m.memory != nil {
+			return fmt.Errorf("%w: multiple memories", ErrUnsupportedFeature)
+		}
+
+		var err error
+		m.memory, err = readLimits(r)
+		if err != nil {
+			return err
+		}
+		if m.memory.min > maxDeclaredMemoryPages || (m.memory.hasMax && m.memory.max > maxDeclaredMemoryPages) {
+			return r.errorf("memory size must be at most %d pages", maxDeclaredMemoryPages)
+		}
+
+		return nil
+	})
+}
+
+func (m *module) decodeGlobals(r *reader) error {
+	return readVector(r, func() error {
+		vt, err := readValueType(r)
+		if err != nil {
+			return err
+		}
+		mutability, err := r.readByte()
+		if err != nil {
+			return err
+		}
+		if mutability > 1 {
+			return r.errorf("invalid global mutability")
+		}
+		init, err := m.readConstantExpression(r, vt)
+		if err != nil {
+			return err
+		}
+
+		m.globals = append(m.globals, &global{valueType: vt, mutable: mutability == 1, init: init})
+		return nil
+	})
+}
+
+func (m *module) readConstantExpression(r *reader, vt valueType) (constantExpression, error) {
+	var expression constantExpression
+	opcode, err := r.readByte()
+	if err != nil {
+		return expression, err
+	}
+
+	switch {
+	case opcode == 0x41 && vt == valueTypeI32:
+		var value int32
+		value, err = r.readS32()
+		expression.value = uint64(uint32(value))
+	case opcode == 0x42 && vt == valueTypeI64:
+		var value int64
+		value, err = r.readS64()
+		expression.value = uint64(value)
+	case opcode == 0x23:
+		expression.isGlobalGet = true
+		expression.globalIndex, err = r.readU32()
+		if err == nil && expression.globalIndex >= uint32(len(m.globals)) {
+			err = r.errorf("unknown global %d", expression.globalIndex)
+		}
+	default:
+		return expression, fmt.Errorf("%w: constant expression opcode 0x%x", ErrUnsupportedFeature, opcode)
+	}
+	if err != nil {
+		return expression, err
+	}
+
+	end, err := r.readByte()
+	if err != nil {
+		return expression, err
+	}
+	if end != 0x0b {
+		return expression, r.errorf("constant expression not terminated")
+	}
+
+	return expression, nil
+}
+
+func (m *module) decodeExports(r *reader) error {
+	return readVector(r, func() error {
+		name, err := r.readName()
+		if err != nil {
+			return err
+		}
+		kind, err := r.readByte()
+		if err != nil {
+			return err
+		}
+		index, err := r.readU32()
+		if err != nil {
+			return err
+		}
+		if kind > externalGlobal {
+			return r.errorf("unknown export kind %d", kind)
+		}
+		if _, duplicate := m.exports[name]; duplicate {
+			return r.errorf("duplicate export %s", name)
+		}
+
+		m.exports[name] = &export{kind: kind, index: index}
+		return nil
+	})
+}
+
+func (m *module) decodeElements(r *reader) error {
+	return readVector(r, func() error {
+		flags, err := r.readU32()
+		if err != nil {
+			return err
+		}
+		if flags != 0 {
+			return fmt.Errorf("%w: element segment flags %d", ErrUnsupportedFeature, flags)
+		}
+
+		offset, err := m.readConstantExpression(r, valueTypeI32)
+		if err != nil {
+			return err
+		}
+
+		segment := &elementSegment{offset: offset}
+		err = readVector(r, func() error {
+			functionIndex, err := r.readU32()
+			segment.functionIndices = append(segment.functionIndices, functionIndex)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		m.elements = append(m.elements, segment)
+		return nil
+	})
+}
+
+func (m *module) decodeCode(r *reader) error {
+	return readVector(r, func() error {
+		size, err := r.readU32()
+		if err != nil {
+			return err
+		}
+		bodyStart := r.offset
+		bodyBytes, err := r.readBytes(size)
+		if err != nil {
+			return err
+		}
+
+		br := &reader{data: r.data[:bodyStart+len(bodyBytes)], offset: bodyStart}
+		body := &functionBody{}
+		err = readVector(br, func() error {
+			count, err := br.readU32()
+			if err != nil {
+				return err
+			}
+			vt, err := readValueType(br)
+			if err != nil {
+				return err
+			}
+			if uint64(len(body.locals))+uint64(count) > maxLocals {
+				return br.errorf("too many locals")
+			}
+
+			for i := uint32(0); i < count; i++ {
+				body.locals = append(body.locals, vt)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		body.codeOffset = br.offset
+		body.code = br.data[br.offset:]
+		m.bodies = append(m.bodies, body)
+		return nil
+	})
+}
+
+func (m *module) decodeData(r *reader) error {
+	return readVector(r, func() error {
+		flags, err := r.readU32()
+		if err != nil {
+			return err
+		}
+
+		segment := &dataSegment{}
+		switch flags {
+		case 0:
+			segment.offset, err = m.readConstantExpression(r, valueTypeI32)
+		case 1:
+			segment.passive = true
+		default:
+			return fmt.Errorf("%w: data segment flags %d", ErrUnsupportedFeature, flags)
+		}
+		if err != nil {
+			return err
+		}
+
+		length, err := r.readU32()
+		if err != nil {
+			return err
+		}
+		segment.data, err = r.readBytes(length)
+		if err != nil {
+			return err
+		}
+
+		m.data = append(m.data, segment)
+		return nil
+	})
+}
+
+// functionType returns the type of the function with the given index, the imported functions coming first
+func (m *module) functionType(functionIndex uint32) (*functionType, bool) {
+	if functionIndex < m.numImportedFunc {
+		return m.types[m.imports[functionIndex].typeIndex], true
+	}
+
+	localIndex := functionIndex - m.numImportedFunc
+	if localIndex >= uint32(len(m.functionTypes)) {
+		return nil, false
+	}
+
+	return m.types[m.functionTypes[localIndex]], true
+}
+
+func (m *module) numFunctions() uint32 {
+	return m.numImportedFunc + uint32(len(m.functionTypes))
+}
+
+func valueTypesToBytes(types []valueType) []byte {
+	result := make([]byte, len(types))
+	for i, vt := range types {
+		result[i] = byte(vt)
+	}
+	return result
+}
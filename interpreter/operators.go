@@ -0,0 +1,280 @@
+package interpreter
+
+import (
+	"github.com/multiversx/mx-chain-vm-v1_4-go/wasmer"
+)
+
+// immediateKind tells how the immediates of an operator are encoded
+type immediateKind byte
+
+const (
+	immediateNone immediateKind = iota
+	immediateBlockType
+	immediateLabel
+	immediateBrTable
+	immediateFunction
+	immediateCallIndirect
+	immediateLocal
+	immediateGlobal
+	immediateMemArg
+	immediateMemoryIndex
+	immediateI32
+	immediateI64
+	immediateValueTypes
+	immediateDataMemory
+	immediateData
+	immediateTwoMemories
+)
+
+// prefixBulkMemory prefixes the bulk memory operators, encoded as 0xfc followed by a u32
+const prefixBulkMemory = 0xfc
+
+// bulkMemoryOpcode combines the prefix and the u32 of a prefixed operator into the opcode used internally
+func bulkMemoryOpcode(subOpcode uint32) uint16 {
+	return prefixBulkMemory<<8 | uint16(subOpcode)
+}
+
+type operator struct {
+	name      string
+	costIndex int
+	immediate immediateKind
+}
+
+const (
+	opUnreachable  = 0x00
+	opNop          = 0x01
+	opBlock        = 0x02
+	opLoop         = 0x03
+	opIf           = 0x04
+	opElse         = 0x05
+	opEnd          = 0x0b
+	opBr           = 0x0c
+	opBrIf         = 0x0d
+	opBrTable      = 0x0e
+	opReturn       = 0x0f
+	opCall         = 0x10
+	opCallIndirect = 0x11
+	opDrop         = 0x1a
+	opSelect       = 0x1b
+	opTypedSelect  = 0x1c
+	opLocalGet     = 0x20
+	opLocalSet     = 0x21
+	opLocalTee     = 0x22
+	opGlobalGet    = 0x23
+	opGlobalSet    = 0x24
+	opI32Load      = 0x28
+	opI64Load      = 0x29
+	opI32Load8S    = 0x2c
+	opI32Load8U    = 0x2d
+	opI32Load16S   = 0x2e
+	opI32Load16U   = 0x2f
+	opI64Load8S    = 0x30
+	opI64Load8U    = 0x31
+	opI64Load16S   = 0x32
+	opI64Load16U   = 0x33
+	opI64Load32S   = 0x34
+	opI64Load32U   = 0x35
+	opI32Store     = 0x36
+	opI64Store     = 0x37
+	opI32Store8    = 0x3a
+	opI32Store16   = 0x3b
+	opI64Store8    = 0x3c
+	opI64Store16   = 0x3d
+	opI64Store32   = 0x3e
+	opMemorySize   = 0x3f
+	opMemoryGrow   = 0x40
+	opI32Const     = 0x41
+	opI64Const     = 0x42
+	opI32Eqz       = 0x45
+	opI32Eq        = 0x46
+	opI32Ne        = 0x47
+	opI32LtS       = 0x48
+	opI32LtU       = 0x49
+	opI32GtS       = 0x4a
+	opI32GtU       = 0x4b
+	opI32LeS       = 0x4c
+	opI32LeU       = 0x4d
+	opI32GeS       = 0x4e
+	opI32GeU       = 0x4f
+	opI64Eqz       = 0x50
+	opI64Eq        = 0x51
+	opI64Ne        = 0x52
+	opI64LtS       = 0x53
+	opI64LtU       = 0x54
+	opI64GtS       = 0x55
+	opI64GtU       = 0x56
+	opI64LeS       = 0x57
+	opI64LeU       = 0x58
+	opI64GeS       = 0x59
+	opI64GeU       = 0x5a
+	opI32Clz       = 0x67
+	opI32Ctz       = 0x68
+	opI32Popcnt    = 0x69
+	opI32Add       = 0x6a
+	opI32Sub       = 0x6b
+	opI32Mul       = 0x6c
+	opI32DivS      = 0x6d
+	opI32DivU      = 0x6e
+	opI32RemS      = 0x6f
+	opI32RemU      = 0x70
+	opI32And       = 0x71
+	opI32Or        = 0x72
+	opI32Xor       = 0x73
+	opI32Shl       = 0x74
+	opI32ShrS      = 0x75
+	opI32ShrU      = 0x76
+	opI32Rotl      = 0x77
+	opI32Rotr      = 0x78
+	opI64Clz       = 0x79
+	opI64Ctz       = 0x7a
+	opI64Popcnt    = 0x7b
+	opI64Add       = 0x7c
+	opI64Sub       = 0x7d
+	opI64Mul       = 0x7e
+	opI64DivS      = 0x7f
+	opI64DivU      = 0x80
+	opI64RemS      = 0x81
+	opI64RemU      = 0x82
+	opI64And       = 0x83
+	opI64Or        = 0x84
+	opI64Xor       = 0x85
+	opI64Shl       = 0x86
+	opI64ShrS      = 0x87
+	opI64ShrU      = 0x88
+	opI64Rotl      = 0x89
+	opI64Rotr      = 0x8a
+	opI32WrapI64   = 0xa7
+	opI64ExtendS   = 0xac
+	opI64ExtendU   = 0xad
+	opI32Extend8S  = 0xc0
+	opI32Extend16S = 0xc1
+	opI64Extend8S  = 0xc2
+	opI64Extend16S = 0xc3
+	opI64Extend32S = 0xc4
+)
+
+var (
+	opMemoryInit = bulkMemoryOpcode(8)
+	opDataDrop   = bulkMemoryOpcode(9)
+	opMemoryCopy = bulkMemoryOpcode(10)
+	opMemoryFill = bulkMemoryOpcode(11)
+)
+
+// operators holds the supported operators, with the index of their cost in the opcode costs;
+// the floating point and the vector operators are not supported
+var operators = map[uint16]*operator{
+	opUnreachable:  {"unreachable", wasmer.OpcodeUnreachable, immediateNone},
+	opNop:          {"nop", wasmer.OpcodeNop, immediateNone},
+	opBlock:        {"block", wasmer.OpcodeBlock, immediateBlockType},
+	opLoop:         {"loop", wasmer.OpcodeLoop, immediateBlockType},
+	opIf:           {"if", wasmer.OpcodeIf, immediateBlockType},
+	opElse:         {"else", wasmer.OpcodeElse, immediateNone},
+	opEnd:          {"end", wasmer.OpcodeEnd, immediateNone},
+	opBr:           {"br", wasmer.OpcodeBr, immediateLabel},
+	opBrIf:         {"br_if", wasmer.OpcodeBrIf, immediateLabel},
+	opBrTable:      {"br_table", wasmer.OpcodeBrTable, immediateBrTable},
+	opReturn:       {"return", wasmer.OpcodeReturn, immediateNone},
+	opCall:         {"call", wasmer.OpcodeCall, immediateFunction},
+	opCallIndirect: {"call_indirect", wasmer.OpcodeCallIndirect, immediateCallIndirect},
+	opDrop:         {"drop", wasmer.OpcodeDrop, immediateNone},
+	opSelect:       {"select", wasmer.OpcodeSelect, immediateNone},
+	opTypedSelect:  {"select", wasmer.OpcodeTypedSelect, immediateValueTypes},
+	opLocalGet:     {"local.get", wasmer.OpcodeLocalGet, immediateLocal},
+	opLocalSet:     {"local.set", wasmer.OpcodeLocalSet, immediateLocal},
+	opLocalTee:     {"local.tee", wasmer.OpcodeLocalTee, immediateLocal},
+	opGlobalGet:    {"global.get", wasmer.OpcodeGlobalGet, immediateGlobal},
+	opGlobalSet:    {"global.set", wasmer.OpcodeGlobalSet, immediateGlobal},
+	opI32Load:      {"i32.load", wasmer.OpcodeI32Load, immediateMemArg},
+	opI64Load:      {"i64.load", wasmer.OpcodeI64Load, immediateMemArg},
+	opI32Load8S:    {"i32.load8_s", wasmer.OpcodeI32Load8S, immediateMemArg},
+	opI32Load8U:    {"i32.load8_u", wasmer.OpcodeI32Load8U, immediateMemArg},
+	opI32Load16S:   {"i32.load16_s", wasmer.OpcodeI32Load16S, immediateMemArg},
+	opI32Load16U:   {"i32.load16_u", wasmer.OpcodeI32Load16U, immediateMemArg},
+	opI64Load8S:    {"i64.load8_s", wasmer.OpcodeI64Load8S, immediateMemArg},
+	opI64Load8U:    {"i64.load8_u", wasmer.OpcodeI64Load8U, immediateMemArg},
+	opI64Load16S:   {"i64.load16_s", wasmer.OpcodeI64Load16S, immediateMemArg},
+	opI64Load16U:   {"i64.load16_u", wasmer.OpcodeI64Load16U, immediateMemArg},
+	opI64Load32S:   {"i64.load32_s", wasmer.OpcodeI64Load32S, immediateMemArg},
+	opI64Load32U:   {"i64.load32_u", wasmer.OpcodeI64Load32U, immediateMemArg},
+	opI32Store:     {"i32.store", wasmer.OpcodeI32Store, immediateMemArg},
+	opI64Store:     {"i64.store", wasmer.OpcodeI64Store, immediateMemArg},
+	opI32Store8:    {"i32.store8", wasmer.OpcodeI32Store8, immediateMemArg},
+	opI32Store16:   {"i32.store16", wasmer.OpcodeI32Store16, immediateMemArg},
+	opI64Store8:    {"i64.store8", wasmer.OpcodeI64Store8, immediateMemArg},
+	opI64Store16:   {"i64.store16", wasmer.OpcodeI64Store16, immediateMemArg},
+	opI64Store32:   {"i64.store32", wasmer.OpcodeI64Store32, immediateMemArg},
+	opMemorySize:   {"memory.size", wasmer.OpcodeMemorySize, immediateMemoryIndex},
+	opMemoryGrow:   {"memory.grow", wasmer.OpcodeMemoryGrow, immediateMemoryIndex},
+	opI32Const:     {"i32.const", wasmer.OpcodeI32Const, immediateI32},
+	opI64Const:     {"i64.const", wasmer.OpcodeI64Const, immediateI64},
+	opI32Eqz:       {"i32.eqz", wasmer.OpcodeI32Eqz, immediateNone},
+	opI32Eq:        {"i32.eq", wasmer.OpcodeI32Eq, immediateNone},
+	opI32Ne:        {"i32.ne", wasmer.OpcodeI32Ne, immediateNone},
+	opI32LtS:       {"i32.lt_s", wasmer.OpcodeI32LtS, immediateNone},
+	opI32LtU:       {"i32.lt_u", wasmer.OpcodeI32LtU, immediateNone},
+	opI32GtS:       {"i32.gt_s", wasmer.OpcodeI32GtS, immediateNone},
+	opI32GtU:       {"i32.gt_u", wasmer.OpcodeI32GtU, immediateNone},
+	opI32LeS:       {"i32.le_s", wasmer.OpcodeI32LeS, immediateNone},
+	opI32LeU:       {"i32.le_u", wasmer.OpcodeI32LeU, immediateNone},
+	opI32GeS:       {"i32.ge_s", wasmer.OpcodeI32GeS, immediateNone},
+	opI32GeU:       {"i32.ge_u", wasmer.OpcodeI32GeU, immediateNone},
+	opI64Eqz:       {"i64.eqz", wasmer.OpcodeI64Eqz, immediateNone},
+	opI64Eq:        {"i64.eq", wasmer.OpcodeI64Eq, immediateNone},
+	opI64Ne:        {"i64.ne", wasmer.OpcodeI64Ne, immediateNone},
+	opI64LtS:       {"i64.lt_s", wasmer.OpcodeI64LtS, immediateNone},
+	opI64LtU:       {"i64.lt_u", wasmer.OpcodeI64LtU, immediateNone},
+	opI64GtS:       {"i64.gt_s", wasmer.OpcodeI64GtS, immediateNone},
+	opI64GtU:       {"i64.gt_u", wasmer.OpcodeI64GtU, immediateNone},
+	opI64LeS:       {"i64.le_s", wasmer.OpcodeI64LeS, immediateNone},
+	opI64LeU:       {"i64.le_u", wasmer.OpcodeI64LeU, immediateNone},
+	opI64GeS:       {"i64.ge_s", wasmer.OpcodeI64GeS, immediateNone},
+	opI64GeU:       {"i64.ge_u", wasmer.OpcodeI64GeU, immediateNone},
+	opI32Clz:       {"i32.clz", wasmer.OpcodeI32Clz, immediateNone},
+	opI32Ctz:       {"i32.ctz", wasmer.OpcodeI32Ctz, immediateNone},
+	opI32Popcnt:    {"i32.popcnt", wasmer.OpcodeI32Popcnt, immediateNone},
+	opI32Add:       {"i32.add", wasmer.OpcodeI32Add, immediateNone},
+	opI32Sub:       {"i32.sub", wasmer.OpcodeI32Sub, immediateNone},
+	opI32Mul:       {"i32.mul", wasmer.OpcodeI32Mul, immediateNone},
+	opI32DivS:      {"i32.div_s", wasmer.OpcodeI32DivS, immediateNone},
+	opI32DivU:      {"i32.div_u", wasmer.OpcodeI32DivU, immediateNone},
+	opI32RemS:      {"i32.rem_s", wasmer.OpcodeI32RemS, immediateNone},
+	opI32RemU:      {"i32.rem_u", wasmer.OpcodeI32RemU, immediateNone},
+	opI32And:       {"i32.and", wasmer.OpcodeI32And, immediateNone},
+	opI32Or:        {"i32.or", wasmer.OpcodeI32Or, immediateNone},
+	opI32Xor:       {"i32.xor", wasmer.OpcodeI32Xor, immediateNone},
+	opI32Shl:       {"i32.shl", wasmer.OpcodeI32Shl, immediateNone},
+	opI32ShrS:      {"i32.shr_s", wasmer.OpcodeI32ShrS, immediateNone},
+	opI32ShrU:      {"i32.shr_u", wasmer.OpcodeI32ShrU, immediateNone},
+	opI32Rotl:      {"i32.rotl", wasmer.OpcodeI32Rotl, immediateNone},
+	opI32Rotr:      {"i32.rotr", wasmer.OpcodeI32Rotr, immediateNone},
+	opI64Clz:       {"i64.clz", wasmer.OpcodeI64Clz, immediateNone},
+	opI64Ctz:       {"i64.ctz", wasmer.OpcodeI64Ctz, immediateNone},
+	opI64Popcnt:    {"i64.popcnt", wasmer.OpcodeI64Popcnt, immediateNone},
+	opI64Add:       {"i64.add", wasmer.OpcodeI64Add, immediateNone},
+	opI64Sub:       {"i64.sub", wasmer.OpcodeI64Sub, immediateNone},
+	opI64Mul:       {"i64.mul", wasmer.OpcodeI64Mul, immediateNone},
+	opI64DivS:      {"i64.div_s", wasmer.OpcodeI64DivS, immediateNone},
+	opI64DivU:      {"i64.div_u", wasmer.OpcodeI64DivU, immediateNone},
+	opI64RemS:      {"i64.rem_s", wasmer.OpcodeI64RemS, immediateNone},
+	opI64RemU:      {"i64.rem_u", wasmer.OpcodeI64RemU, immediateNone},
+	opI64And:       {"i64.and", wasmer.OpcodeI64And, immediateNone},
+	opI64Or:        {"i64.or", wasmer.OpcodeI64Or, immediateNone},
+	opI64Xor:       {"i64.xor", wasmer.OpcodeI64Xor, immediateNone},
+	opI64Shl:       {"i64.shl", wasmer.OpcodeI64Shl, immediateNone},
+	opI64ShrS:      {"i64.shr_s", wasmer.OpcodeI64ShrS, immediateNone},
+	opI64ShrU:      {"i64.shr_u", wasmer.OpcodeI64ShrU, immediateNone},
+	opI64Rotl:      {"i64.rotl", wasmer.OpcodeI64Rotl, immediateNone},
+	opI64Rotr:      {"i64.rotr", wasmer.OpcodeI64Rotr, immediateNone},
+	opI32WrapI64:   {"i32.wrap_i64", wasmer.OpcodeI32WrapI64, immediateNone},
+	opI64ExtendS:   {"i64.extend_i32_s", wasmer.OpcodeI64ExtendI32S, immediateNone},
+	opI64ExtendU:   {"i64.extend_i32_u", wasmer.OpcodeI64ExtendI32U, immediateNone},
+	opI32Extend8S:  {"i32.extend8_s", wasmer.OpcodeI32Extend8S, immediateNone},
+	opI32Extend16S: {"i32.extend16_s", wasmer.OpcodeI32Extend16S, immediateNone},
+	opI64Extend8S:  {"i64.extend8_s", wasmer.OpcodeI64Extend8S, immediateNone},
+	opI64Extend16S: {"i64.extend16_s", wasmer.OpcodeI64Extend16S, immediateNone},
+	opI64Extend32S: {"i64.extend32_s", wasmer.OpcodeI64Extend32S, immediateNone},
+	opMemoryInit:   {"memory.init", wasmer.OpcodeMemoryInit, immediateDataMemory},
+	opDataDrop:     {"data.drop", wasmer.OpcodeDataDrop, immediateData},
+	opMemoryCopy:   {"memory.copy", wasmer.OpcodeMemoryCopy, immediateTwoMemories},
+	opMemoryFill:   {"memory.fill", wasmer.OpcodeMemoryFill, immediateMemoryIndex},
+}
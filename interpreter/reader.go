@@ -0,0 +1,126 @@
+package interpreter
+
+import (
+	"fmt"
+)
+
+// reader decodes the primitive values of the WASM binary format
+type reader struct {
+	data   []byte
+	offset int
+}
+
+func newReader(data []byte) *reader {
+	return &reader{data: data}
+}
+
+func (r *reader) hasMore() bool {
+	return r.offset < len(r.data)
+}
+
+func (r *reader) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s at offset %d", ErrInvalidModule, fmt.Sprintf(format, args...), r.offset)
+}
+
+func (r *reader) readByte() (byte, error) {
+	if r.offset >= len(r.data) {
+		return 0, r.errorf("unexpected end")
+	}
+
+	b := r.data[r.offset]
+	r.offset++
+	return b, nil
+}
+
+func (r *reader) readBytes(length uint32) ([]byte, error) {
+	if uint64(r.offset)+uint64(length) > uint64(len(r.data)) {
+		return nil, r.errorf("unexpected end")
+	}
+
+	bytes := r.data[r.offset : r.offset+int(length)]
+	r.offset += int(length)
+	return bytes, nil
+}
+
+func (r *reader) readU32() (uint32, error) {
+	value, err := r.readUnsigned(32)
+	return uint32(value), err
+}
+
+func (r *reader) readS32() (int32, error) {
+	value, err := r.readSigned(32)
+	return int32(value), err
+}
+
+func (r *reader) readS33() (int64, error) {
+	return r.readSigned(33)
+}
+
+func (r *reader) readS64() (int64, error) {
+	return r.readSigned(64)
+}
+
+func (r *reader) readUnsigned(bits uint) (uint64, error) {
+	var result uint64
+	var shift uint
+	for {
+		b, err := r.readByte()
+		if err != nil {
+			return 0, err
+		}
+		if shift+7 > bits+6 || (shift+7 > bits && b&0x7f >= 1<<(bits-shift)) {
+			return 0, r.errorf("integer too large")
+		}
+
+		result |= uint64(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return result, nil
+		}
+		shift += 7
+	}
+}
+
+func (r *reader) readSigned(bits uint) (int64, error) {
+	var result int64
+	var shift uint
+	var b byte
+	for {
+		var err error
+		b, err = r.readByte()
+		if err != nil {
+			return 0, err
+		}
+		if shift+7 > bits+6 {
+			return 0, r.errorf("integer too large")
+		}
+
+		result |= int64(b&0x7f) << shift
+		shift += 7
+		if b&0x80 == 0 {
+			break
+		}
+	}
+
+	if shift < 64 && b&0x40 != 0 {
+		result |= -1 << shift
+	}
+	if bits < 64 && (result < -(1<<(bits-1)) || result >= 1<<(bits-1)) {
+		return 0, r.errorf("integer too large")
+	}
+
+	return result, nil
+}
+
+func (r *reader) readName() (string, error) {
+	length, err := r.readU32()
+	if err != nil {
+		return "", err
+	}
+
+	bytes, err := r.readBytes(length)
+	if err != nil {
+		return "", err
+	}
+
+	return string(bytes), nil
+}
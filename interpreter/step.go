@@ -0,0 +1,35 @@
+package interpreter
+
+// Step describes the instruction about to be executed, as received by a StepHandler
+type Step struct {
+	// FunctionIndex is the index of the executing function, the imported functions coming first
+	FunctionIndex uint32
+
+	// FunctionName is the name under which the executing function is exported, if it is exported
+	FunctionName string
+
+	// PC is the position of the instruction in the function
+	PC int
+
+	// Offset is the offset of the instruction in the module
+	Offset int
+
+	// Opcode is the name of the instruction, in the WebAssembly text format
+	Opcode string
+
+	// Locals holds a copy of the parameters and the locals of the executing function
+	Locals []uint64
+
+	// Stack holds a copy of the values pushed by the executing function, the top of the stack coming last
+	Stack []uint64
+
+	// CallDepth is the number of functions on the call stack, the called export counting as 1
+	CallDepth int
+
+	// PointsUsed are the points used before the instruction is charged
+	PointsUsed uint64
+}
+
+// StepHandler receives each instruction before its execution; returning an error stops the execution,
+// the instance returning that error to its caller
+type StepHandler func(step *Step) error
@@ -37,6 +37,7 @@ type BlockchainHookStub struct {
 	SaveCompiledCodeCalled        func(codeHash []byte, code []byte)
 	GetCodeCalled                 func(account vmcommon.UserAccountHandler) []byte
 	GetESDTTokenCalled            func(address []byte, tokenID []byte, nonce uint64) (*esdt.ESDigitalToken, error)
+	ClearCompiledCodesCalled      func()
 	GetSnapshotCalled             func() int
 	RevertToSnapshotCalled        func(snapshot int) error
 }
@@ -247,6 +248,9 @@ func (b *BlockchainHookStub) GetCompiledCode(codeHash []byte) (bool, []byte) {
 
 // ClearCompiledCodes mocked method
 func (b *BlockchainHookStub) ClearCompiledCodes() {
+	if b.ClearCompiledCodesCalled != nil {
+		b.ClearCompiledCodesCalled()
+	}
 }
 
 // GetSnapshot mocked method
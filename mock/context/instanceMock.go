@@ -188,7 +188,12 @@ func GetMockInstance(host vmhost.VMHost) *InstanceMock {
 }
 
 // SetMemory mocked method
-func (instance *InstanceMock) SetMemory(_ []byte) bool {
+func (instance *InstanceMock) SetMemory(data []byte) bool {
+	if instance.Memory == nil || len(instance.Memory.Data()) != len(data) {
+		return false
+	}
+
+	copy(instance.Memory.Data(), data)
 	return true
 }
 
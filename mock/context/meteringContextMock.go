@@ -128,6 +128,24 @@ func (m *MeteringContextMock) GetGasProvided() uint64 {
 	return 0
 }
 
+// GetGasRefundedByBuiltins mocked method
+func (m *MeteringContextMock) GetGasRefundedByBuiltins() uint64 {
+	return 0
+}
+
+// SetGasInvariantChecking mocked method
+func (m *MeteringContextMock) SetGasInvariantChecking(_ bool) {
+}
+
+// GetGasInvariantViolation mocked method
+func (m *MeteringContextMock) GetGasInvariantViolation() *vmhost.GasInvariantViolation {
+	return nil
+}
+
+// SetGasTraceFrameMemoryPages mocked method
+func (m *MeteringContextMock) SetGasTraceFrameMemoryPages(_ uint32) {
+}
+
 // GetSCPrepareInitialCost mocked method
 func (m *MeteringContextMock) GetSCPrepareInitialCost() uint64 {
 	return 0
@@ -213,3 +231,20 @@ func (m *MeteringContextMock) SetGasTracing(_ bool) {
 func (m *MeteringContextMock) GetGasTrace() map[string]map[string][]uint64 {
 	return nil
 }
+
+// BeginGasTraceFrame mocked method
+func (m *MeteringContextMock) BeginGasTraceFrame(_ vmhost.GasTraceFrameKind, _ string) {
+}
+
+// EndGasTraceFrame mocked method
+func (m *MeteringContextMock) EndGasTraceFrame() {
+}
+
+// SetCallTreeGasTracing mocked method
+func (m *MeteringContextMock) SetCallTreeGasTracing(_ bool) {
+}
+
+// GetGasCallTree mocked method
+func (m *MeteringContextMock) GetGasCallTree() []*vmhost.GasTraceFrame {
+	return nil
+}
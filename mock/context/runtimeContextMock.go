@@ -243,6 +243,21 @@ func (r *RuntimeContextMock) GetInstanceExports() wasmer.ExportsMap {
 func (r *RuntimeContextMock) ClearWarmInstanceCache() {
 }
 
+// IsWarmInstanceCacheEnabled mocked method
+func (r *RuntimeContextMock) IsWarmInstanceCacheEnabled() bool {
+	return false
+}
+
+// GetWarmInstanceCacheStats mocked method
+func (r *RuntimeContextMock) GetWarmInstanceCacheStats() vmhost.WarmInstanceCacheStats {
+	return vmhost.WarmInstanceCacheStats{}
+}
+
+// PrewarmInstance mocked method
+func (r *RuntimeContextMock) PrewarmInstance(_ vmhost.PrewarmTarget) (bool, error) {
+	return false, r.Err
+}
+
 // CallFunction mocked method
 func (r *RuntimeContextMock) CallFunction(_ string) error {
 	return r.Err
@@ -370,6 +385,29 @@ func (r *RuntimeContextMock) ValidateInstances() error {
 	return nil
 }
 
+// SetCoverageTracker -
+func (r *RuntimeContextMock) SetCoverageTracker(_ vmhost.CoverageTracker) {
+}
+
+// TrackEICall -
+func (r *RuntimeContextMock) TrackEICall(_ string) {
+}
+
+// SetOpcodeProfiling -
+func (r *RuntimeContextMock) SetOpcodeProfiling(_ [][]byte) error {
+	return nil
+}
+
+// GetOpcodeProfiles -
+func (r *RuntimeContextMock) GetOpcodeProfiles() map[string]*vmhost.OpcodeProfile {
+	return nil
+}
+
+// GetMemoryUsage -
+func (r *RuntimeContextMock) GetMemoryUsage() *vmhost.MemoryUsage {
+	return vmhost.NewMemoryUsage()
+}
+
 // CleanInstance mocked method
 func (r *RuntimeContextMock) CleanInstance() {
 }
@@ -689,3 +689,41 @@ func (contextWrapper *RuntimeContextWrapper) EndExecution() {
 func (contextWrapper *RuntimeContextWrapper) ValidateInstances() error {
 	return nil
 }
+
+// SetCoverageTracker -
+func (contextWrapper *RuntimeContextWrapper) SetCoverageTracker(_ vmhost.CoverageTracker) {
+}
+
+// TrackEICall -
+func (contextWrapper *RuntimeContextWrapper) TrackEICall(_ string) {
+}
+
+// SetOpcodeProfiling delegates to the wrapped context
+func (contextWrapper *RuntimeContextWrapper) SetOpcodeProfiling(contractAddresses [][]byte) error {
+	return contextWrapper.runtimeContext.SetOpcodeProfiling(contractAddresses)
+}
+
+// GetOpcodeProfiles delegates to the wrapped context
+func (contextWrapper *RuntimeContextWrapper) GetOpcodeProfiles() map[string]*vmhost.OpcodeProfile {
+	return contextWrapper.runtimeContext.GetOpcodeProfiles()
+}
+
+// GetMemoryUsage delegates to the wrapped context
+func (contextWrapper *RuntimeContextWrapper) GetMemoryUsage() *vmhost.MemoryUsage {
+	return contextWrapper.runtimeContext.GetMemoryUsage()
+}
+
+// IsWarmInstanceCacheEnabled delegates to the wrapped context
+func (contextWrapper *RuntimeContextWrapper) IsWarmInstanceCacheEnabled() bool {
+	return contextWrapper.runtimeContext.IsWarmInstanceCacheEnabled()
+}
+
+// GetWarmInstanceCacheStats delegates to the wrapped context
+func (contextWrapper *RuntimeContextWrapper) GetWarmInstanceCacheStats() vmhost.WarmInstanceCacheStats {
+	return contextWrapper.runtimeContext.GetWarmInstanceCacheStats()
+}
+
+// PrewarmInstance delegates to the wrapped context
+func (contextWrapper *RuntimeContextWrapper) PrewarmInstance(target vmhost.PrewarmTarget) (bool, error) {
+	return contextWrapper.runtimeContext.PrewarmInstance(target)
+}
@@ -1,6 +1,9 @@
 package mock
 
 import (
+	"context"
+	"time"
+
 	"github.com/multiversx/mx-chain-core-go/data/vm"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 	"github.com/multiversx/mx-chain-vm-v1_4-go/config"
@@ -240,6 +243,82 @@ func (host *VMHostMock) Reset() {
 func (host *VMHostMock) SetGasTracing(enableGasTracing bool) {
 }
 
+// SetCoverageTracker -
+func (host *VMHostMock) SetCoverageTracker(_ vmhost.CoverageTracker) {
+}
+
+// SetCallTreeGasTracing -
+func (host *VMHostMock) SetCallTreeGasTracing(_ bool) {
+}
+
+// GetGasCallTree -
+func (host *VMHostMock) GetGasCallTree() []*vmhost.GasTraceFrame {
+	return nil
+}
+
+// EstimateGas -
+func (host *VMHostMock) EstimateGas(_ *vmcommon.ContractCallInput) (*vmhost.GasEstimate, error) {
+	return nil, nil
+}
+
+// GetGasReport -
+func (host *VMHostMock) GetGasReport() *vmhost.GasReport {
+	return nil
+}
+
+// SetGasInvariantChecking -
+func (host *VMHostMock) SetGasInvariantChecking(_ bool) {
+}
+
+// GetGasInvariantViolation -
+func (host *VMHostMock) GetGasInvariantViolation() *vmhost.GasInvariantViolation {
+	return nil
+}
+
+// GetMemoryUsage -
+func (host *VMHostMock) GetMemoryUsage() *vmhost.MemoryUsage {
+	return nil
+}
+
+// GetStorageUsage -
+func (host *VMHostMock) GetStorageUsage() *vmhost.StorageUsage {
+	return nil
+}
+
+// GetWarmInstanceCacheStats -
+func (host *VMHostMock) GetWarmInstanceCacheStats() vmhost.WarmInstanceCacheStats {
+	return vmhost.WarmInstanceCacheStats{}
+}
+
+// CompilationFingerprint -
+func (host *VMHostMock) CompilationFingerprint() []byte {
+	return nil
+}
+
+// PrewarmInstances -
+func (host *VMHostMock) PrewarmInstances(_ []vmhost.PrewarmTarget) (<-chan *vmhost.PrewarmReport, error) {
+	return nil, nil
+}
+
+// RunSmartContractQuery -
+func (host *VMHostMock) RunSmartContractQuery(_ *vmcommon.ContractCallInput, _ time.Duration) (*vmcommon.VMOutput, error) {
+	return nil, nil
+}
+
+// RunSmartContractCallWithContext -
+func (host *VMHostMock) RunSmartContractCallWithContext(_ context.Context, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	return nil, nil
+}
+
+// RunSmartContractCreateWithContext -
+func (host *VMHostMock) RunSmartContractCreateWithContext(_ context.Context, _ *vmcommon.ContractCreateInput) (*vmcommon.VMOutput, error) {
+	return nil, nil
+}
+
+// SetCallDebugger -
+func (host *VMHostMock) SetCallDebugger(_ vmhost.CallDebugger) {
+}
+
 // GetGasTrace -
 func (host *VMHostMock) GetGasTrace() map[string]map[string][]uint64 {
 	return make(map[string]map[string][]uint64)
@@ -1,6 +1,9 @@
 package mock
 
 import (
+	"context"
+	"time"
+
 	"github.com/multiversx/mx-chain-core-go/data/vm"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 	"github.com/multiversx/mx-chain-vm-v1_4-go/config"
@@ -335,6 +338,82 @@ func (vhs *VMHostStub) Reset() {
 func (vhs *VMHostStub) SetGasTracing(enableGasTracing bool) {
 }
 
+// SetCoverageTracker -
+func (vhs *VMHostStub) SetCoverageTracker(_ vmhost.CoverageTracker) {
+}
+
+// SetCallTreeGasTracing -
+func (vhs *VMHostStub) SetCallTreeGasTracing(_ bool) {
+}
+
+// GetGasCallTree -
+func (vhs *VMHostStub) GetGasCallTree() []*vmhost.GasTraceFrame {
+	return nil
+}
+
+// EstimateGas -
+func (vhs *VMHostStub) EstimateGas(_ *vmcommon.ContractCallInput) (*vmhost.GasEstimate, error) {
+	return nil, nil
+}
+
+// GetGasReport -
+func (vhs *VMHostStub) GetGasReport() *vmhost.GasReport {
+	return nil
+}
+
+// SetGasInvariantChecking -
+func (vhs *VMHostStub) SetGasInvariantChecking(_ bool) {
+}
+
+// GetGasInvariantViolation -
+func (vhs *VMHostStub) GetGasInvariantViolation() *vmhost.GasInvariantViolation {
+	return nil
+}
+
+// GetMemoryUsage -
+func (vhs *VMHostStub) GetMemoryUsage() *vmhost.MemoryUsage {
+	return nil
+}
+
+// GetStorageUsage -
+func (vhs *VMHostStub) GetStorageUsage() *vmhost.StorageUsage {
+	return nil
+}
+
+// GetWarmInstanceCacheStats -
+func (vhs *VMHostStub) GetWarmInstanceCacheStats() vmhost.WarmInstanceCacheStats {
+	return vmhost.WarmInstanceCacheStats{}
+}
+
+// CompilationFingerprint -
+func (vhs *VMHostStub) CompilationFingerprint() []byte {
+	return nil
+}
+
+// PrewarmInstances -
+func (vhs *VMHostStub) PrewarmInstances(_ []vmhost.PrewarmTarget) (<-chan *vmhost.PrewarmReport, error) {
+	return nil, nil
+}
+
+// RunSmartContractQuery -
+func (vhs *VMHostStub) RunSmartContractQuery(_ *vmcommon.ContractCallInput, _ time.Duration) (*vmcommon.VMOutput, error) {
+	return nil, nil
+}
+
+// RunSmartContractCallWithContext -
+func (vhs *VMHostStub) RunSmartContractCallWithContext(_ context.Context, input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	return vhs.RunSmartContractCall(input)
+}
+
+// RunSmartContractCreateWithContext -
+func (vhs *VMHostStub) RunSmartContractCreateWithContext(_ context.Context, input *vmcommon.ContractCreateInput) (*vmcommon.VMOutput, error) {
+	return vhs.RunSmartContractCreate(input)
+}
+
+// SetCallDebugger -
+func (vhs *VMHostStub) SetCallDebugger(_ vmhost.CallDebugger) {
+}
+
 // GetGasTrace -
 func (vhs *VMHostStub) GetGasTrace() map[string]map[string][]uint64 {
 	return make(map[string]map[string][]uint64)
@@ -0,0 +1,47 @@
+package mock
+
+import (
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/vmhooksmeta"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/wasmer"
+)
+
+var _ vmhost.WasmEngine = (*WasmEngineStub)(nil)
+
+// WasmEngineStub is used in tests to plug an engine into the host; the instances
+// are created by the wrapped InstanceBuilder
+type WasmEngineStub struct {
+	InstanceBuilder      vmhost.InstanceBuilder
+	SetImportsCalled     func(imports *vmhooksmeta.EIFunctions) error
+	SetOpcodeCostsCalled func(opcodeCosts *[wasmer.OpcodeCount]uint32)
+}
+
+// NewInstanceWithOptions -
+func (stub *WasmEngineStub) NewInstanceWithOptions(contractCode []byte, options wasmer.CompilationOptions) (wasmer.InstanceHandler, error) {
+	return stub.InstanceBuilder.NewInstanceWithOptions(contractCode, options)
+}
+
+// NewInstanceFromCompiledCodeWithOptions -
+func (stub *WasmEngineStub) NewInstanceFromCompiledCodeWithOptions(compiledCode []byte, options wasmer.CompilationOptions) (wasmer.InstanceHandler, error) {
+	return stub.InstanceBuilder.NewInstanceFromCompiledCodeWithOptions(compiledCode, options)
+}
+
+// SetImports -
+func (stub *WasmEngineStub) SetImports(imports *vmhooksmeta.EIFunctions) error {
+	if stub.SetImportsCalled != nil {
+		return stub.SetImportsCalled(imports)
+	}
+	return nil
+}
+
+// SetOpcodeCosts -
+func (stub *WasmEngineStub) SetOpcodeCosts(opcodeCosts *[wasmer.OpcodeCount]uint32) {
+	if stub.SetOpcodeCostsCalled != nil {
+		stub.SetOpcodeCostsCalled(opcodeCosts)
+	}
+}
+
+// IsInterfaceNil -
+func (stub *WasmEngineStub) IsInterfaceNil() bool {
+	return stub == nil
+}
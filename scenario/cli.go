@@ -0,0 +1,126 @@
+package scenario
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	scenclibase "github.com/multiversx/mx-chain-scenario-go/clibase"
+	scenexec "github.com/multiversx/mx-chain-scenario-go/scenario/executor"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	scenjparse "github.com/multiversx/mx-chain-scenario-go/scenario/json/parse"
+	cli "github.com/urfave/cli/v2"
+)
+
+// CLIRunnerHook is an optional extension of scenclibase.CLIRunConfig, replacing the executor that
+// scenclibase.RunScenariosAtPath hands to the scenario controller with a runner wrapping it
+type CLIRunnerHook interface {
+	// NewRunner returns the runner of the scenarios, created around the executor of the run
+	NewRunner(executor *scenexec.ScenarioExecutor) (scenio.ScenarioRunner, error)
+
+	// AfterRun is called once the scenarios ran, after the result was printed, and returns the
+	// error of the run as reported by the CLI
+	AfterRun(err error) error
+}
+
+// ScenariosCLI is a temporary fork of scenclibase.ScenariosCLI (mx-chain-scenario-go v1.4.4), whose
+// run command always goes through scenclibase.RunScenariosAtPath; the fork runs the scenarios through
+// the runner hook of the configs also implementing CLIRunnerHook, and falls back to scenclibase for the
+// other configs. It is to be dropped once scenclibase takes the hook.
+func ScenariosCLI(version string, vmFlags scenclibase.CLIRunConfig) {
+	app := cli.NewApp()
+	app.Version = version
+	app.Commands = []*cli.Command{
+		{
+			Name:    "version",
+			Aliases: []string{"v"},
+			Usage:   "print the tool version",
+			Action: func(cCtx *cli.Context) error {
+				fmt.Println(app.Version)
+				return nil
+			},
+		},
+		{
+			Name:  "run",
+			Usage: "run the scenarios at the given path",
+			Flags: vmFlags.GetFlags(),
+			Action: func(cCtx *cli.Context) error {
+				if cCtx.Args().Len() != 1 {
+					return errors.New("one path argument required to run scenarios")
+				}
+
+				options := vmFlags.ParseFlags(cCtx)
+				runnerHook, ok := vmFlags.(CLIRunnerHook)
+				if !ok {
+					return scenclibase.RunScenariosAtPath(cCtx.Args().First(), options)
+				}
+
+				return RunScenariosAtPath(cCtx.Args().First(), options, runnerHook)
+			},
+		},
+		{
+			Name:  "fmt",
+			Usage: "format all scenario files in a folder ( .scen.json / .step.json / .steps.json )",
+			Action: func(cCtx *cli.Context) error {
+				if cCtx.Args().Len() != 1 {
+					return errors.New("one path argument required to format scenarios")
+				}
+
+				return scenio.FormatAllInFolder(cCtx.Args().First())
+			},
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// RunScenariosAtPath is a temporary fork of scenclibase.RunScenariosAtPath, which creates its executor
+// and controller internally, leaving no way to wrap the executor; the fork runs the scenarios through
+// the runner created by the hook and reports an execution stopped by a ScenarioStepFilter as STOPPED
+func RunScenariosAtPath(path string, options scenclibase.CLIRunOptions, runnerHook CLIRunnerHook) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	runner, err := runnerHook.NewRunner(scenexec.NewScenarioExecutor(options.VMBuilder))
+	if err != nil {
+		return err
+	}
+
+	controller := &scenio.ScenarioController{
+		Executor: runner,
+		Parser: scenjparse.NewParser(
+			scenio.NewDefaultFileResolver(),
+			options.VMBuilder.GetVMType()),
+	}
+
+	switch {
+	case fi.IsDir():
+		err = controller.RunAllJSONScenariosInDirectory(
+			path,
+			"",
+			".scen.json",
+			[]string{},
+			options.RunOptions)
+	case strings.HasSuffix(path, ".scen.json"):
+		err = controller.RunSingleJSONScenario(path, options.RunOptions)
+	default:
+		err = errors.New("only directories and scenario files accepted as path")
+	}
+
+	switch {
+	case err == nil:
+		fmt.Println("SUCCESS")
+	case errors.Is(err, ErrScenarioStopped):
+		fmt.Printf("STOPPED: %s\n", err.Error())
+	default:
+		fmt.Printf("ERROR: %s\n", err.Error())
+	}
+
+	return runnerHook.AfterRun(err)
+}
@@ -0,0 +1,89 @@
+package scenario
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"sort"
+
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+)
+
+// CoverageReport lists, for each contract code executed by the scenarios, which endpoints
+// and which EI functions were exercised, and which were not.
+type CoverageReport struct {
+	Contracts []*ContractCoverageReport `json:"contracts"`
+}
+
+// ContractCoverageReport holds the coverage of a single contract code.
+type ContractCoverageReport struct {
+	CodeHash           string       `json:"codeHash"`
+	Functions          []*CallCount `json:"functions"`
+	UncoveredFunctions []string     `json:"uncoveredFunctions"`
+	EIFunctions        []*CallCount `json:"eiFunctions"`
+	UnusedEIFunctions  []string     `json:"unusedEIFunctions"`
+}
+
+// CallCount holds the number of times a function was called.
+type CallCount struct {
+	Name  string `json:"name"`
+	Calls uint64 `json:"calls"`
+}
+
+// NewCoverageReport builds a CoverageReport from the coverage gathered by a vmhost.CoverageTracker.
+func NewCoverageReport(coverage map[string]*vmhost.ContractCoverage) *CoverageReport {
+	report := &CoverageReport{
+		Contracts: make([]*ContractCoverageReport, 0, len(coverage)),
+	}
+
+	for _, contractCoverage := range coverage {
+		report.Contracts = append(report.Contracts, &ContractCoverageReport{
+			CodeHash:           hex.EncodeToString(contractCoverage.CodeHash),
+			Functions:          sortedCallCounts(contractCoverage.FunctionCalls),
+			UncoveredFunctions: namesNotCalled(contractCoverage.ExportedFunctions, contractCoverage.FunctionCalls),
+			EIFunctions:        sortedCallCounts(contractCoverage.EICalls),
+			UnusedEIFunctions:  namesNotCalled(contractCoverage.ImportedEIFunctions, contractCoverage.EICalls),
+		})
+	}
+
+	sort.Slice(report.Contracts, func(i, j int) bool {
+		return report.Contracts[i].CodeHash < report.Contracts[j].CodeHash
+	})
+
+	return report
+}
+
+// WriteJSONFile saves the report as indented JSON at the given path.
+func (report *CoverageReport) WriteJSONFile(path string) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+func sortedCallCounts(calls map[string]uint64) []*CallCount {
+	result := make([]*CallCount, 0, len(calls))
+	for name, count := range calls {
+		result = append(result, &CallCount{Name: name, Calls: count})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+func namesNotCalled(names []string, calls map[string]uint64) []string {
+	result := make([]string, 0)
+	for _, name := range names {
+		if calls[name] == 0 {
+			result = append(result, name)
+		}
+	}
+
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,259 @@
+package scenario
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	fr "github.com/multiversx/mx-chain-scenario-go/scenario/expression/fileresolver"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+)
+
+var _ scenio.ScenarioRunner = (*ScenarioDebugger)(nil)
+var _ vmhost.CallDebugger = (*ScenarioDebugger)(nil)
+
+// ErrDebuggerQuit signals that the scenario execution was stopped from the debugger
+var ErrDebuggerQuit = errors.New("scenario execution stopped from the debugger")
+
+const debuggerHelp = `commands:
+  s, step-in    pause at the next step or nested call boundary
+  n, step-over  pause at the next boundary which is not nested inside the current call
+  c, continue   pause at the next scenario step
+  r, run        run until the end, without pausing
+  q, quit       stop the execution
+  h, help       print this message`
+
+type debugMode int
+
+const (
+	debugModeStepIn debugMode = iota
+	debugModeStepOver
+	debugModeContinue
+	debugModeRun
+)
+
+type debugCallFrame struct {
+	boundary vmhost.CallBoundary
+	input    *vmcommon.ContractCallInput
+}
+
+// ScenarioDebugger runs scenarios one step at a time, pausing before each step and, when
+// also installed as the CallDebugger of the VM, at each nested call boundary.
+type ScenarioDebugger struct {
+	runner        scenio.ScenarioRunner
+	commands      *bufio.Scanner
+	output        io.Writer
+	mode          debugMode
+	stepOverDepth int
+	callStack     []*debugCallFrame
+	quit          bool
+}
+
+// NewScenarioDebugger creates a ScenarioDebugger which executes the steps with the given runner,
+// reads commands from the given input and prints the execution state to the given output.
+func NewScenarioDebugger(runner scenio.ScenarioRunner, input io.Reader, output io.Writer) *ScenarioDebugger {
+	return &ScenarioDebugger{
+		runner:    runner,
+		commands:  bufio.NewScanner(input),
+		output:    output,
+		mode:      debugModeStepIn,
+		callStack: make([]*debugCallFrame, 0),
+	}
+}
+
+// Reset clears the state of the underlying runner.
+func (sd *ScenarioDebugger) Reset() {
+	sd.runner.Reset()
+	sd.callStack = make([]*debugCallFrame, 0)
+}
+
+// RunScenario executes the steps of the scenario one by one, pausing before each of them.
+func (sd *ScenarioDebugger) RunScenario(scenario *scenmodel.Scenario, fileResolver fr.FileResolver) error {
+	for stepIndex, step := range scenario.Steps {
+		if sd.mode != debugModeRun {
+			fmt.Fprintf(sd.output, "\n[step %d/%d] %s\n", stepIndex+1, len(scenario.Steps), describeStep(step))
+			sd.readCommand(0)
+		}
+		if sd.quit {
+			return ErrDebuggerQuit
+		}
+
+		sd.callStack = make([]*debugCallFrame, 0)
+		singleStepScenario := *scenario
+		singleStepScenario.Steps = []scenmodel.Step{step}
+		singleStepScenario.IsNewTest = scenario.IsNewTest && stepIndex == 0
+
+		err := sd.runner.RunScenario(&singleStepScenario, fileResolver)
+		if err != nil {
+			fmt.Fprintf(sd.output, "[step %d/%d] failed: %s\n", stepIndex+1, len(scenario.Steps), err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EnterCall pauses the execution when a nested call is entered, if the current command requires it.
+func (sd *ScenarioDebugger) EnterCall(host vmhost.VMHost, boundary vmhost.CallBoundary, input *vmcommon.ContractCallInput) {
+	sd.callStack = append(sd.callStack, &debugCallFrame{
+		boundary: boundary,
+		input:    input,
+	})
+
+	depth := len(sd.callStack)
+	if !sd.shouldPause(depth) {
+		return
+	}
+
+	fmt.Fprintf(sd.output, "\n> enter %s %s\n", boundary, describeCall(input))
+	fmt.Fprintf(sd.output, "gas provided: %d\n", input.GasProvided)
+	sd.printState(host)
+	sd.readCommand(depth)
+}
+
+// ExitCall pauses the execution when a nested call is left, if the current command requires it.
+func (sd *ScenarioDebugger) ExitCall(host vmhost.VMHost, boundary vmhost.CallBoundary, err error) {
+	depth := len(sd.callStack)
+	if depth > 0 && sd.shouldPause(depth) {
+		frame := sd.callStack[depth-1]
+		result := "ok"
+		if err != nil {
+			result = err.Error()
+		}
+
+		fmt.Fprintf(sd.output, "\n< exit %s %s: %s\n", boundary, describeCall(frame.input), result)
+		fmt.Fprintf(sd.output, "gas left in caller: %d\n", host.Metering().GasLeft())
+		sd.printState(host)
+		sd.readCommand(depth)
+	}
+
+	if depth > 0 {
+		sd.callStack = sd.callStack[:depth-1]
+	}
+}
+
+func (sd *ScenarioDebugger) shouldPause(depth int) bool {
+	switch sd.mode {
+	case debugModeStepIn:
+		return true
+	case debugModeStepOver:
+		return depth <= sd.stepOverDepth
+	default:
+		return false
+	}
+}
+
+func (sd *ScenarioDebugger) readCommand(depth int) {
+	for {
+		fmt.Fprint(sd.output, "(debug) ")
+		if !sd.commands.Scan() {
+			sd.mode = debugModeRun
+			return
+		}
+
+		switch strings.TrimSpace(sd.commands.Text()) {
+		case "s", "step-in":
+			sd.mode = debugModeStepIn
+			return
+		case "n", "step-over":
+			sd.mode = debugModeStepOver
+			sd.stepOverDepth = depth
+			return
+		case "c", "continue":
+			sd.mode = debugModeContinue
+			return
+		case "r", "run":
+			sd.mode = debugModeRun
+			return
+		case "q", "quit":
+			sd.mode = debugModeRun
+			sd.quit = true
+			return
+		default:
+			fmt.Fprintln(sd.output, debuggerHelp)
+		}
+	}
+}
+
+func (sd *ScenarioDebugger) printState(host vmhost.VMHost) {
+	fmt.Fprintln(sd.output, "call stack:")
+	for i := len(sd.callStack) - 1; i >= 0; i-- {
+		frame := sd.callStack[i]
+		fmt.Fprintf(sd.output, "  #%d %s %s\n", i, frame.boundary, describeCall(frame.input))
+	}
+
+	fmt.Fprintln(sd.output, "return data:")
+	for i, data := range host.Output().ReturnData() {
+		fmt.Fprintf(sd.output, "  [%d] 0x%s\n", i, hex.EncodeToString(data))
+	}
+
+	fmt.Fprintln(sd.output, "pending storage updates:")
+	outputAccounts := host.Output().GetOutputAccounts()
+	addresses := make([]string, 0, len(outputAccounts))
+	for address := range outputAccounts {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	for _, address := range addresses {
+		storageUpdates := outputAccounts[address].StorageUpdates
+		keys := make([]string, 0, len(storageUpdates))
+		for key := range storageUpdates {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			fmt.Fprintf(sd.output, "  0x%s: 0x%s = 0x%s\n",
+				hex.EncodeToString([]byte(address)),
+				hex.EncodeToString([]byte(key)),
+				hex.EncodeToString(storageUpdates[key].Data))
+		}
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (sd *ScenarioDebugger) IsInterfaceNil() bool {
+	return sd == nil
+}
+
+func describeCall(input *vmcommon.ContractCallInput) string {
+	return fmt.Sprintf("0x%s::%s", hex.EncodeToString(input.RecipientAddr), input.Function)
+}
+
+func describeStep(step scenmodel.Step) string {
+	description := step.StepTypeName()
+	identifier, comment := stepIdentifierAndComment(step)
+	if len(identifier) > 0 {
+		description += fmt.Sprintf(" %q", identifier)
+	}
+	if len(comment) > 0 {
+		description += " - " + comment
+	}
+
+	return description
+}
+
+func stepIdentifierAndComment(step scenmodel.Step) (string, string) {
+	switch typedStep := step.(type) {
+	case *scenmodel.TxStep:
+		return typedStep.TxIdent, typedStep.Comment
+	case *scenmodel.SetStateStep:
+		return typedStep.SetStateIdent, typedStep.Comment
+	case *scenmodel.CheckStateStep:
+		return typedStep.CheckStateIdent, typedStep.Comment
+	case *scenmodel.ExternalStepsStep:
+		return typedStep.Path, typedStep.Comment
+	case *scenmodel.DumpStateStep:
+		return "", typedStep.Comment
+	default:
+		return "", ""
+	}
+}
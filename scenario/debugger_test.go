@@ -0,0 +1,117 @@
+package scenario
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	fr "github.com/multiversx/mx-chain-scenario-go/scenario/expression/fileresolver"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	contextmock "github.com/multiversx/mx-chain-vm-v1_4-go/mock/context"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/stretchr/testify/require"
+)
+
+type scenarioRunnerStub struct {
+	runScenarioCalled func(scenario *scenmodel.Scenario) error
+	executedSteps     []scenmodel.Step
+}
+
+func (stub *scenarioRunnerStub) Reset() {
+}
+
+func (stub *scenarioRunnerStub) RunScenario(scenario *scenmodel.Scenario, _ fr.FileResolver) error {
+	stub.executedSteps = append(stub.executedSteps, scenario.Steps...)
+	if stub.runScenarioCalled != nil {
+		return stub.runScenarioCalled(scenario)
+	}
+	return nil
+}
+
+func newDebuggerTestHost() *contextmock.VMHostMock {
+	return &contextmock.VMHostMock{
+		OutputContext: &contextmock.OutputContextMock{
+			ReturnDataMock: [][]byte{[]byte("data")},
+			OutputAccounts: map[string]*vmcommon.OutputAccount{
+				"address": {
+					StorageUpdates: map[string]*vmcommon.StorageUpdate{
+						"key": {Offset: []byte("key"), Data: []byte("value")},
+					},
+				},
+			},
+		},
+		MeteringContext: &contextmock.MeteringContextMock{GasLeftMock: 1000},
+	}
+}
+
+func newDebuggerTestScenario(numSteps int) *scenmodel.Scenario {
+	scenario := &scenmodel.Scenario{IsNewTest: true}
+	for i := 0; i < numSteps; i++ {
+		scenario.Steps = append(scenario.Steps, &scenmodel.TxStep{
+			TxIdent: "tx",
+			Tx:      &scenmodel.Transaction{Type: scenmodel.ScCall},
+		})
+	}
+	return scenario
+}
+
+func TestScenarioDebugger_PausesBeforeEachStep(t *testing.T) {
+	runner := &scenarioRunnerStub{}
+	output := &bytes.Buffer{}
+	debugger := NewScenarioDebugger(runner, strings.NewReader("c\nc\nc\n"), output)
+
+	err := debugger.RunScenario(newDebuggerTestScenario(3), nil)
+	require.Nil(t, err)
+	require.Len(t, runner.executedSteps, 3)
+	require.Equal(t, 3, strings.Count(output.String(), "(debug) "))
+	require.Contains(t, output.String(), `[step 3/3] scCall "tx"`)
+}
+
+func TestScenarioDebugger_StepOverSkipsNestedCalls(t *testing.T) {
+	host := newDebuggerTestHost()
+	outerInput := &vmcommon.ContractCallInput{Function: "outer"}
+	innerInput := &vmcommon.ContractCallInput{Function: "inner"}
+
+	runner := &scenarioRunnerStub{}
+	output := &bytes.Buffer{}
+	// step-in at the scenario step, step-over at the outer call, continue at its exit
+	debugger := NewScenarioDebugger(runner, strings.NewReader("s\nn\nc\n"), output)
+	runner.runScenarioCalled = func(_ *scenmodel.Scenario) error {
+		debugger.EnterCall(host, vmhost.DestContextCallBoundary, outerInput)
+		debugger.EnterCall(host, vmhost.AsyncCallBoundary, innerInput)
+		debugger.ExitCall(host, vmhost.AsyncCallBoundary, nil)
+		debugger.ExitCall(host, vmhost.DestContextCallBoundary, nil)
+		return nil
+	}
+
+	err := debugger.RunScenario(newDebuggerTestScenario(1), nil)
+	require.Nil(t, err)
+	require.Equal(t, 3, strings.Count(output.String(), "(debug) "))
+	require.Contains(t, output.String(), "> enter executeOnDestContext 0x::outer")
+	require.Contains(t, output.String(), "< exit executeOnDestContext 0x::outer: ok")
+	require.NotContains(t, output.String(), "inner")
+	require.Contains(t, output.String(), "0x61646472657373: 0x6b6579 = 0x76616c7565")
+	require.Contains(t, output.String(), "gas left in caller: 1000")
+	require.Len(t, debugger.callStack, 0)
+}
+
+func TestScenarioDebugger_Quit(t *testing.T) {
+	runner := &scenarioRunnerStub{}
+	debugger := NewScenarioDebugger(runner, strings.NewReader("c\nq\n"), &bytes.Buffer{})
+
+	err := debugger.RunScenario(newDebuggerTestScenario(3), nil)
+	require.Equal(t, ErrDebuggerQuit, err)
+	require.Len(t, runner.executedSteps, 1)
+}
+
+func TestScenarioDebugger_EndOfInputRunsToCompletion(t *testing.T) {
+	runner := &scenarioRunnerStub{}
+	output := &bytes.Buffer{}
+	debugger := NewScenarioDebugger(runner, strings.NewReader(""), output)
+
+	err := debugger.RunScenario(newDebuggerTestScenario(3), nil)
+	require.Nil(t, err)
+	require.Len(t, runner.executedSteps, 3)
+	require.Equal(t, 1, strings.Count(output.String(), "(debug) "))
+}
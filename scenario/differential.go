@@ -0,0 +1,296 @@
+package scenario
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	scenexec "github.com/multiversx/mx-chain-scenario-go/scenario/executor"
+	fr "github.com/multiversx/mx-chain-scenario-go/scenario/expression/fileresolver"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// ErrNilVMBuilder signals that no VM builder was provided for differential testing
+var ErrNilVMBuilder = errors.New("nil VM builder")
+
+// ErrNilReferenceVMBuilder signals that no reference VM builder was provided for differential testing
+var ErrNilReferenceVMBuilder = errors.New("nil reference VM builder")
+
+// ErrNilFileResolver signals that an external steps step was run without a file resolver to locate its file
+var ErrNilFileResolver = errors.New("nil file resolver")
+
+// FieldDivergence is a single field of the step output which differs between the two VMs
+type FieldDivergence struct {
+	Field     string
+	Value     string
+	Reference string
+}
+
+// DivergenceError is returned by the DifferentialRunner at the first step where the two VMs diverge
+type DivergenceError struct {
+	StepPath    string
+	StepType    string
+	Divergences []*FieldDivergence
+}
+
+// Error returns the diverging step and, for each diverging field, the values produced by both VMs
+func (de *DivergenceError) Error() string {
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "VMs diverge at %s step %s", de.StepType, de.StepPath)
+	for _, divergence := range de.Divergences {
+		fmt.Fprintf(sb, "\n  %s:\n    this VM:      %s\n    reference VM: %s",
+			divergence.Field,
+			divergence.Value,
+			divergence.Reference)
+	}
+	return sb.String()
+}
+
+// DifferentialRunner runs the same scenarios against this VM and a reference VM, comparing the
+// outputs of every transaction and stopping at the first step where the two diverge
+type DifferentialRunner struct {
+	executor          *scenexec.ScenarioExecutor
+	referenceExecutor *scenexec.ScenarioExecutor
+	fileResolver      fr.FileResolver
+	stepPath          []string
+}
+
+var _ scenio.ScenarioRunner = (*DifferentialRunner)(nil)
+
+// NewDifferentialRunner creates a DifferentialRunner comparing the VM built by vmBuilder against the
+// one built by referenceVMBuilder
+func NewDifferentialRunner(vmBuilder scenexec.VMBuilder, referenceVMBuilder scenexec.VMBuilder) (*DifferentialRunner, error) {
+	if vmBuilder == nil {
+		return nil, ErrNilVMBuilder
+	}
+	if referenceVMBuilder == nil {
+		return nil, ErrNilReferenceVMBuilder
+	}
+
+	return &DifferentialRunner{
+		executor:          scenexec.NewScenarioExecutor(vmBuilder),
+		referenceExecutor: scenexec.NewScenarioExecutor(referenceVMBuilder),
+		stepPath:          make([]string, 0),
+	}, nil
+}
+
+// Reset clears the worlds of both VMs
+func (dr *DifferentialRunner) Reset() {
+	dr.executor.Reset()
+	dr.referenceExecutor.Reset()
+}
+
+// RunScenario executes the steps of the scenario on both VMs; the expected results of the
+// transactions are ignored, since the reference VM is the expectation
+func (dr *DifferentialRunner) RunScenario(scenario *scenmodel.Scenario, fileResolver fr.FileResolver) error {
+	err := dr.executor.InitVM(scenario.GasSchedule)
+	if err != nil {
+		return err
+	}
+	err = dr.referenceExecutor.InitVM(scenario.GasSchedule)
+	if err != nil {
+		return err
+	}
+
+	dr.fileResolver = fileResolver
+	return dr.RunSteps(scenario.Steps)
+}
+
+// RunSteps executes the given steps on both VMs, such as a list of transactions built in code
+func (dr *DifferentialRunner) RunSteps(steps []scenmodel.Step) error {
+	for stepIndex, step := range steps {
+		dr.stepPath = append(dr.stepPath, stepPathElement(stepIndex, step))
+		err := dr.runStep(step)
+		dr.stepPath = dr.stepPath[:len(dr.stepPath)-1]
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (dr *DifferentialRunner) runStep(generalStep scenmodel.Step) error {
+	switch step := generalStep.(type) {
+	case *scenmodel.ExternalStepsStep:
+		return dr.runExternalStep(step)
+	case *scenmodel.TxStep:
+		return dr.runTxStep(step)
+	case *scenmodel.DumpStateStep:
+		return dr.executor.ExecuteStep(step)
+	default:
+		err := dr.executor.ExecuteStep(step)
+		referenceErr := dr.referenceExecutor.ExecuteStep(step)
+		if err != nil && referenceErr != nil {
+			return err
+		}
+		return dr.checkDivergences(step, compareErrors(err, referenceErr))
+	}
+}
+
+func (dr *DifferentialRunner) runExternalStep(step *scenmodel.ExternalStepsStep) error {
+	if dr.fileResolver == nil {
+		return ErrNilFileResolver
+	}
+
+	fileResolverBackup := dr.fileResolver
+	externalStepsRunner := scenio.NewScenarioController(dr, dr.fileResolver.Clone(), dr.executor.GetVMType())
+
+	err := externalStepsRunner.RunSingleJSONScenario(
+		dr.fileResolver.ResolveAbsolutePath(step.Path),
+		scenio.DefaultRunScenarioOptions())
+	dr.fileResolver = fileResolverBackup
+
+	return err
+}
+
+func (dr *DifferentialRunner) runTxStep(step *scenmodel.TxStep) error {
+	unchecked := *step
+	unchecked.ExpectedResult = nil
+
+	vmOutput, err := dr.executor.ExecuteTxStep(&unchecked)
+	referenceVMOutput, referenceErr := dr.referenceExecutor.ExecuteTxStep(&unchecked)
+
+	divergences := compareErrors(err, referenceErr)
+	if err == nil && referenceErr == nil {
+		divergences = compareVMOutputs(step.Tx.GasLimit.Value, vmOutput, referenceVMOutput)
+	}
+
+	return dr.checkDivergences(step, divergences)
+}
+
+func (dr *DifferentialRunner) checkDivergences(step scenmodel.Step, divergences []*FieldDivergence) error {
+	if len(divergences) == 0 {
+		return nil
+	}
+
+	return &DivergenceError{
+		StepPath:    strings.Join(dr.stepPath, " / "),
+		StepType:    step.StepTypeName(),
+		Divergences: divergences,
+	}
+}
+
+func stepPathElement(stepIndex int, step scenmodel.Step) string {
+	identifier, _ := stepIdentifierAndComment(step)
+	if len(identifier) == 0 {
+		return fmt.Sprintf("#%d", stepIndex+1)
+	}
+	return fmt.Sprintf("#%d %q", stepIndex+1, identifier)
+}
+
+func compareErrors(err error, referenceErr error) []*FieldDivergence {
+	divergences := make([]*FieldDivergence, 0)
+	compareField(&divergences, "error", errorString(err), errorString(referenceErr))
+	return divergences
+}
+
+func compareVMOutputs(gasLimit uint64, vmOutput *vmcommon.VMOutput, referenceVMOutput *vmcommon.VMOutput) []*FieldDivergence {
+	divergences := make([]*FieldDivergence, 0)
+	compareField(&divergences, "return code", vmOutput.ReturnCode.String(), referenceVMOutput.ReturnCode.String())
+	compareField(&divergences, "return message", vmOutput.ReturnMessage, referenceVMOutput.ReturnMessage)
+	compareField(&divergences, "return data", formatBytesList(vmOutput.ReturnData), formatBytesList(referenceVMOutput.ReturnData))
+	compareField(&divergences, "storage updates", formatStorageUpdates(vmOutput), formatStorageUpdates(referenceVMOutput))
+	compareField(&divergences, "logs", formatLogs(vmOutput.Logs), formatLogs(referenceVMOutput.Logs))
+	compareField(&divergences, "output transfers", formatOutputTransfers(vmOutput), formatOutputTransfers(referenceVMOutput))
+	compareField(&divergences, "gas used", formatGasUsed(gasLimit, vmOutput), formatGasUsed(gasLimit, referenceVMOutput))
+	return divergences
+}
+
+func compareField(divergences *[]*FieldDivergence, field string, value string, reference string) {
+	if value == reference {
+		return
+	}
+
+	*divergences = append(*divergences, &FieldDivergence{
+		Field:     field,
+		Value:     value,
+		Reference: reference,
+	})
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+	return err.Error()
+}
+
+func formatBytes(value []byte) string {
+	return "0x" + hex.EncodeToString(value)
+}
+
+func formatBytesList(values [][]byte) string {
+	formatted := make([]string, 0, len(values))
+	for _, value := range values {
+		formatted = append(formatted, formatBytes(value))
+	}
+	return "[" + strings.Join(formatted, ", ") + "]"
+}
+
+func sortedOutputAccounts(vmOutput *vmcommon.VMOutput) []*vmcommon.OutputAccount {
+	accounts := make([]*vmcommon.OutputAccount, 0, len(vmOutput.OutputAccounts))
+	for _, account := range vmOutput.OutputAccounts {
+		accounts = append(accounts, account)
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return string(accounts[i].Address) < string(accounts[j].Address)
+	})
+	return accounts
+}
+
+func formatStorageUpdates(vmOutput *vmcommon.VMOutput) string {
+	formatted := make([]string, 0)
+	for _, account := range sortedOutputAccounts(vmOutput) {
+		updates := make([]string, 0, len(account.StorageUpdates))
+		for _, update := range account.StorageUpdates {
+			updates = append(updates, fmt.Sprintf("%s: %s = %s",
+				formatBytes(account.Address),
+				formatBytes(update.Offset),
+				formatBytes(update.Data)))
+		}
+		sort.Strings(updates)
+		formatted = append(formatted, updates...)
+	}
+	return "[" + strings.Join(formatted, "; ") + "]"
+}
+
+func formatLogs(logs []*vmcommon.LogEntry) string {
+	formatted := make([]string, 0, len(logs))
+	for _, logEntry := range logs {
+		formatted = append(formatted, fmt.Sprintf("%s from %s topics %s data %s",
+			string(logEntry.Identifier),
+			formatBytes(logEntry.Address),
+			formatBytesList(logEntry.Topics),
+			formatBytesList(logEntry.Data)))
+	}
+	return "[" + strings.Join(formatted, "; ") + "]"
+}
+
+func formatOutputTransfers(vmOutput *vmcommon.VMOutput) string {
+	formatted := make([]string, 0)
+	for _, account := range sortedOutputAccounts(vmOutput) {
+		for _, transfer := range account.OutputTransfers {
+			formatted = append(formatted, fmt.Sprintf("%s -> %s value %s data %s gas limit %d locked %d call type %d",
+				formatBytes(transfer.SenderAddress),
+				formatBytes(account.Address),
+				transfer.Value.String(),
+				formatBytes(transfer.Data),
+				transfer.GasLimit,
+				transfer.GasLocked,
+				transfer.CallType))
+		}
+	}
+	return "[" + strings.Join(formatted, "; ") + "]"
+}
+
+func formatGasUsed(gasLimit uint64, vmOutput *vmcommon.VMOutput) string {
+	if vmOutput.GasRemaining > gasLimit {
+		return fmt.Sprintf("gas remaining %d exceeds gas limit %d", vmOutput.GasRemaining, gasLimit)
+	}
+	return fmt.Sprintf("%d", gasLimit-vmOutput.GasRemaining)
+}
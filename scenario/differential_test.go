@@ -0,0 +1,146 @@
+package scenario
+
+import (
+	"math/big"
+	"testing"
+
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/config"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/interpreter"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/testcommon"
+	"github.com/stretchr/testify/require"
+)
+
+// dummyGasVMHostBuilder builds this VM with the dummy gas schedule, regardless of the scenario
+type dummyGasVMHostBuilder struct {
+	*ScenarioVMHostBuilder
+}
+
+func (builder *dummyGasVMHostBuilder) GasScheduleMapFromScenarios(_ scenmodel.GasSchedule) (worldmock.GasScheduleMap, error) {
+	return config.MakeGasMapForTests(), nil
+}
+
+// newInterpreterVMHostBuilder builds this VM with the interpreter, so that the tests run without Wasmer
+func newInterpreterVMHostBuilder() *ScenarioVMHostBuilder {
+	vmBuilder := NewScenarioVMHostBuilder()
+	vmBuilder.WasmEngine = interpreter.NewEngine()
+	return vmBuilder
+}
+
+func newAdderDifferentialSteps() []scenmodel.Step {
+	owner := []byte("owner___________________________")
+	adder := testcommon.MakeTestSCAddress("adder")
+
+	return []scenmodel.Step{
+		&scenmodel.SetStateStep{
+			SetStateIdent: "init",
+			Accounts: []*scenmodel.Account{
+				{
+					Address:         scenmodel.JSONBytesFromString{Value: owner},
+					Nonce:           scenmodel.JSONUint64{Value: 0, Original: "0"},
+					Balance:         scenmodel.JSONBigInt{Value: big.NewInt(0), Original: "0"},
+					DeveloperReward: scenmodel.JSONBigInt{Value: big.NewInt(0), Original: "0"},
+				},
+				{
+					Address:         scenmodel.JSONBytesFromString{Value: adder},
+					Nonce:           scenmodel.JSONUint64{Value: 0, Original: "0"},
+					Balance:         scenmodel.JSONBigInt{Value: big.NewInt(0), Original: "0"},
+					DeveloperReward: scenmodel.JSONBigInt{Value: big.NewInt(0), Original: "0"},
+					Code:            scenmodel.JSONBytesFromString{Value: testcommon.GetSCCode("../test/adder/output/adder.wasm")},
+					Owner:           scenmodel.JSONBytesFromString{Value: owner},
+				},
+			},
+		},
+		&scenmodel.TxStep{
+			TxIdent: "add",
+			Tx: &scenmodel.Transaction{
+				Type:      scenmodel.ScCall,
+				From:      scenmodel.JSONBytesFromString{Value: owner},
+				To:        scenmodel.JSONBytesFromString{Value: adder},
+				EGLDValue: scenmodel.JSONBigInt{Value: big.NewInt(0)},
+				Function:  "add",
+				Arguments: []scenmodel.JSONBytesFromTree{{Value: []byte{7}}},
+				GasLimit:  scenmodel.JSONUint64{Value: 50_000_000},
+			},
+		},
+	}
+}
+
+func TestNewDifferentialRunner_NilBuilders(t *testing.T) {
+	_, err := NewDifferentialRunner(nil, NewScenarioVMHostBuilder())
+	require.Equal(t, ErrNilVMBuilder, err)
+
+	_, err = NewDifferentialRunner(NewScenarioVMHostBuilder(), nil)
+	require.Equal(t, ErrNilReferenceVMBuilder, err)
+}
+
+func TestDifferentialRunner_ExternalStepWithoutFileResolver(t *testing.T) {
+	runner, err := NewDifferentialRunner(NewScenarioVMHostBuilder(), NewScenarioVMHostBuilder())
+	require.Nil(t, err)
+
+	err = runner.RunScenario(&scenmodel.Scenario{
+		Steps: []scenmodel.Step{&scenmodel.ExternalStepsStep{Path: "steps.json"}},
+	}, nil)
+	require.Equal(t, ErrNilFileResolver, err)
+}
+
+func TestDifferentialRunner_SameVM(t *testing.T) {
+	runner, err := NewDifferentialRunner(newInterpreterVMHostBuilder(), newInterpreterVMHostBuilder())
+	require.Nil(t, err)
+
+	err = runner.RunScenario(&scenmodel.Scenario{Steps: newAdderDifferentialSteps()}, nil)
+	require.Nil(t, err)
+}
+
+func TestDifferentialRunner_GasDivergence(t *testing.T) {
+	reference := &dummyGasVMHostBuilder{ScenarioVMHostBuilder: newInterpreterVMHostBuilder()}
+	runner, err := NewDifferentialRunner(newInterpreterVMHostBuilder(), reference)
+	require.Nil(t, err)
+
+	err = runner.RunScenario(&scenmodel.Scenario{Steps: newAdderDifferentialSteps()}, nil)
+	divergenceErr, ok := err.(*DivergenceError)
+	require.True(t, ok)
+	require.Equal(t, `#2 "add"`, divergenceErr.StepPath)
+	require.Len(t, divergenceErr.Divergences, 1)
+	require.Equal(t, "gas used", divergenceErr.Divergences[0].Field)
+}
+
+func TestCompareVMOutputs(t *testing.T) {
+	vmOutput := &vmcommon.VMOutput{
+		ReturnCode:   vmcommon.Ok,
+		ReturnData:   [][]byte{{1}},
+		GasRemaining: 40,
+		OutputAccounts: map[string]*vmcommon.OutputAccount{
+			"a": {
+				Address: []byte("a"),
+				StorageUpdates: map[string]*vmcommon.StorageUpdate{
+					"k": {Offset: []byte("k"), Data: []byte("v")},
+				},
+			},
+		},
+	}
+	referenceVMOutput := &vmcommon.VMOutput{
+		ReturnCode:   vmcommon.Ok,
+		ReturnData:   [][]byte{{1}},
+		GasRemaining: 40,
+		OutputAccounts: map[string]*vmcommon.OutputAccount{
+			"a": {
+				Address: []byte("a"),
+				StorageUpdates: map[string]*vmcommon.StorageUpdate{
+					"k": {Offset: []byte("k"), Data: []byte("v")},
+				},
+			},
+		},
+	}
+	require.Len(t, compareVMOutputs(100, vmOutput, referenceVMOutput), 0)
+
+	referenceVMOutput.ReturnData = [][]byte{{2}}
+	referenceVMOutput.OutputAccounts["a"].StorageUpdates["k"].Data = []byte("w")
+	divergences := compareVMOutputs(100, vmOutput, referenceVMOutput)
+	require.Equal(t, []*FieldDivergence{
+		{Field: "return data", Value: "[0x01]", Reference: "[0x02]"},
+		{Field: "storage updates", Value: "[0x61: 0x6b = 0x76]", Reference: "[0x61: 0x6b = 0x77]"},
+	}, divergences)
+}
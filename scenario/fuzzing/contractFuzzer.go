@@ -0,0 +1,309 @@
+// Package fuzzing contains a harness for fuzzing contract endpoints on a MockWorld-backed VM,
+// compatible with the native Go fuzzing engine
+package fuzzing
+
+import (
+	"fmt"
+	"math/big"
+	"runtime/debug"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	scenexec "github.com/multiversx/mx-chain-scenario-go/scenario/executor"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	vmscenario "github.com/multiversx/mx-chain-vm-v1_4-go/scenario"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/contexts"
+)
+
+const (
+	defaultNumCallers        = 3
+	defaultMaxCallsPerInput  = 4
+	defaultMaxArguments      = 4
+	defaultMaxArgumentLength = 64
+	defaultMaxESDTPayments   = 3
+	defaultMaxValue          = uint64(1_000_000_000_000_000_000)
+	defaultGasLimit          = uint64(100_000_000)
+)
+
+// Invariant checks the world after a fuzzed call; a non-nil error is reported as a violation
+type Invariant func(call *Call, vmOutput *vmcommon.VMOutput, world *worldmock.MockWorld) error
+
+// ArgsContractFuzzer holds the arguments needed to create a ContractFuzzer; the zero values of
+// the optional fields are replaced with defaults
+type ArgsContractFuzzer struct {
+	Code          []byte
+	InitArguments [][]byte
+	Endpoints     []string
+	ESDTTokens    [][]byte
+	Invariants    []Invariant
+
+	NumCallers        int
+	MaxCallsPerInput  int
+	MaxArguments      int
+	MaxArgumentLength int
+	MaxESDTPayments   int
+	MaxEGLDValue      uint64
+	MaxESDTValue      uint64
+	GasLimit          uint64
+
+	// IgnoreExecutionFailed stops reporting the calls which end with vmcommon.ExecutionFailed
+	IgnoreExecutionFailed bool
+}
+
+// ContractFuzzer executes fuzzed calls against a contract deployed in a MockWorld
+type ContractFuzzer struct {
+	executor        *scenexec.ScenarioExecutor
+	host            vmhost.VMHost
+	initialAccounts worldmock.AccountMap
+	contractAddress []byte
+	callers         [][]byte
+
+	endpoints             []string
+	esdtTokens            [][]byte
+	invariants            []Invariant
+	maxCallsPerInput      int
+	maxArguments          int
+	maxArgumentLength     int
+	maxESDTPayments       int
+	maxEGLDValue          uint64
+	maxESDTValue          uint64
+	gasLimit              uint64
+	ignoreExecutionFailed bool
+	txCounter             uint64
+}
+
+// NewContractFuzzer creates a new VM, deploys the contract and funds the callers
+func NewContractFuzzer(args ArgsContractFuzzer) (*ContractFuzzer, error) {
+	if len(args.Code) == 0 {
+		return nil, ErrNilContractCode
+	}
+	if len(args.Endpoints) == 0 {
+		return nil, ErrNoEndpoints
+	}
+
+	cf := &ContractFuzzer{
+		endpoints:             args.Endpoints,
+		esdtTokens:            args.ESDTTokens,
+		invariants:            args.Invariants,
+		maxCallsPerInput:      valueOrDefault(args.MaxCallsPerInput, defaultMaxCallsPerInput),
+		maxArguments:          valueOrDefault(args.MaxArguments, defaultMaxArguments),
+		maxArgumentLength:     valueOrDefault(args.MaxArgumentLength, defaultMaxArgumentLength),
+		maxESDTPayments:       valueOrDefault(args.MaxESDTPayments, defaultMaxESDTPayments),
+		maxEGLDValue:          valueOrDefault(args.MaxEGLDValue, defaultMaxValue),
+		maxESDTValue:          valueOrDefault(args.MaxESDTValue, defaultMaxValue),
+		gasLimit:              valueOrDefault(args.GasLimit, defaultGasLimit),
+		ignoreExecutionFailed: args.IgnoreExecutionFailed,
+	}
+
+	err := cf.initWorld(args.Code, args.InitArguments, valueOrDefault(args.NumCallers, defaultNumCallers))
+	if err != nil {
+		return nil, err
+	}
+
+	return cf, nil
+}
+
+func (cf *ContractFuzzer) initWorld(code []byte, initArguments [][]byte, numCallers int) error {
+	cf.executor = scenexec.NewScenarioExecutor(vmscenario.NewScenarioVMHostBuilder())
+	err := cf.executor.InitVM(scenmodel.GasScheduleDefault)
+	if err != nil {
+		return err
+	}
+
+	host, ok := cf.executor.GetVM().(vmhost.VMHost)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+	cf.host = host
+
+	world := cf.executor.World
+	balance := big.NewInt(0).SetUint64(cf.maxEGLDValue)
+	balance.Mul(balance, big.NewInt(int64(cf.maxCallsPerInput)))
+	cf.callers = make([][]byte, 0, numCallers)
+	for i := 0; i < numCallers; i++ {
+		caller := makeCallerAddress(i)
+		account := world.AcctMap.CreateAccount(caller, world)
+		account.Balance = big.NewInt(0).Set(balance)
+		for _, token := range cf.esdtTokens {
+			tokenBalance := big.NewInt(0).SetUint64(cf.maxESDTValue)
+			tokenBalance.Mul(tokenBalance, big.NewInt(int64(cf.maxCallsPerInput*cf.maxESDTPayments)))
+			err = account.SetTokenBalance(token, 0, tokenBalance)
+			if err != nil {
+				return err
+			}
+		}
+		cf.callers = append(cf.callers, caller)
+	}
+
+	vmOutput, err := cf.executor.ExecuteTxStep(&scenmodel.TxStep{
+		TxIdent: "deploy",
+		Tx: &scenmodel.Transaction{
+			Type:      scenmodel.ScDeploy,
+			From:      scenmodel.JSONBytesFromString{Value: cf.callers[0]},
+			EGLDValue: scenmodel.JSONBigInt{Value: big.NewInt(0)},
+			Code:      scenmodel.JSONBytesFromString{Value: code},
+			CodeMetadata: scenmodel.JSONBytesFromString{
+				Unspecified: true,
+			},
+			Arguments: toJSONArguments(initArguments),
+			GasLimit:  scenmodel.JSONUint64{Value: cf.gasLimit},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if vmOutput.ReturnCode != vmcommon.Ok {
+		return fmt.Errorf("%w: %s %s", ErrDeploymentFailed, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	}
+
+	cf.contractAddress = world.LastCreatedContractAddress
+	cf.initialAccounts = world.AcctMap.Clone()
+
+	return nil
+}
+
+// ContractAddress returns the address of the fuzzed contract
+func (cf *ContractFuzzer) ContractAddress() []byte {
+	return cf.contractAddress
+}
+
+// Callers returns the addresses used as callers of the fuzzed calls
+func (cf *ContractFuzzer) Callers() [][]byte {
+	return cf.callers
+}
+
+// AddSeeds registers the given call sequences as seeds of the fuzz test
+func (cf *ContractFuzzer) AddSeeds(f *testing.F, callSequences ...[]*Call) {
+	for _, calls := range callSequences {
+		f.Add(cf.EncodeCalls(calls...))
+	}
+}
+
+// Fuzz runs the fuzz target; every failure is reported as an error of the test
+func (cf *ContractFuzzer) Fuzz(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, failure := range cf.Run(data) {
+			t.Error(failure.String())
+		}
+	})
+}
+
+// Run executes the calls decoded from the fuzzer input, starting from the initial world state
+func (cf *ContractFuzzer) Run(data []byte) []*Failure {
+	cf.resetWorld()
+
+	failures := make([]*Failure, 0)
+	for _, call := range cf.DecodeCalls(data) {
+		_, callFailures := cf.executeCall(call)
+		failures = append(failures, callFailures...)
+	}
+
+	return failures
+}
+
+func (cf *ContractFuzzer) resetWorld() {
+	cf.executor.World.AcctMap = cf.initialAccounts.Clone()
+}
+
+func (cf *ContractFuzzer) executeCall(call *Call) (vmOutput *vmcommon.VMOutput, failures []*Failure) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			vmOutput = nil
+			failures = []*Failure{{
+				Kind:    GoPanic,
+				Call:    call,
+				Message: fmt.Sprintf("%v\n%s", r, string(debug.Stack())),
+			}}
+		}
+	}()
+
+	cf.txCounter++
+	vmOutput, err := cf.executor.ExecuteTxStep(&scenmodel.TxStep{
+		TxIdent: fmt.Sprintf("fuzz-%d", cf.txCounter),
+		Tx:      cf.makeTransaction(call),
+	})
+	if err == vmhost.ErrExecutionPanicked {
+		return nil, []*Failure{{Kind: VMPanic, Call: call, Message: err.Error()}}
+	}
+	if err != nil {
+		return nil, []*Failure{{Kind: Crash, Call: call, Message: err.Error()}}
+	}
+
+	failures = make([]*Failure, 0)
+	if vmOutput.ReturnCode == vmcommon.ExecutionFailed && !cf.ignoreExecutionFailed {
+		failures = append(failures, &Failure{
+			Kind:    ExecutionFailed,
+			Call:    call,
+			Message: vmOutput.ReturnMessage,
+		})
+	}
+
+	for _, invariant := range cf.invariants {
+		err = invariant(call, vmOutput, cf.executor.World)
+		if err != nil {
+			failures = append(failures, &Failure{
+				Kind:    InvariantViolation,
+				Call:    call,
+				Message: err.Error(),
+			})
+		}
+	}
+
+	return vmOutput, failures
+}
+
+func (cf *ContractFuzzer) makeTransaction(call *Call) *scenmodel.Transaction {
+	esdtValue := make([]*scenmodel.ESDTTxData, 0, len(call.ESDTPayments))
+	for _, payment := range call.ESDTPayments {
+		esdtValue = append(esdtValue, &scenmodel.ESDTTxData{
+			TokenIdentifier: scenmodel.JSONBytesFromString{Value: payment.TokenIdentifier},
+			Value:           scenmodel.JSONBigInt{Value: big.NewInt(0).SetUint64(payment.Value)},
+		})
+	}
+
+	return &scenmodel.Transaction{
+		Type:      scenmodel.ScCall,
+		From:      scenmodel.JSONBytesFromString{Value: call.Caller},
+		To:        scenmodel.JSONBytesFromString{Value: cf.contractAddress},
+		EGLDValue: scenmodel.JSONBigInt{Value: big.NewInt(0).SetUint64(call.EGLDValue)},
+		ESDTValue: esdtValue,
+		Function:  call.Endpoint,
+		Arguments: toJSONArguments(call.Arguments),
+		GasLimit:  scenmodel.JSONUint64{Value: cf.gasLimit},
+	}
+}
+
+// setCoverageTracker replaces the coverage tracker of the VM; used for corpus minimization
+func (cf *ContractFuzzer) setCoverageTracker(tracker vmhost.CoverageTracker) {
+	if check.IfNil(tracker) {
+		tracker = contexts.NewDisabledCoverageTracker()
+	}
+	cf.host.SetCoverageTracker(tracker)
+}
+
+func toJSONArguments(arguments [][]byte) []scenmodel.JSONBytesFromTree {
+	result := make([]scenmodel.JSONBytesFromTree, 0, len(arguments))
+	for _, argument := range arguments {
+		result = append(result, scenmodel.JSONBytesFromTree{Value: argument})
+	}
+	return result
+}
+
+func makeCallerAddress(index int) []byte {
+	address := []byte(fmt.Sprintf("fuzz_caller_%d", index))
+	for len(address) < 32 {
+		address = append(address, '_')
+	}
+	return address
+}
+
+func valueOrDefault[T int | uint64](value T, defaultValue T) T {
+	if value == 0 {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,163 @@
+package fuzzing
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/testcommon"
+	"github.com/stretchr/testify/require"
+)
+
+const adderPath = "../../test/adder/output/adder.wasm"
+
+func newAdderFuzzer(tb testing.TB, invariants ...Invariant) *ContractFuzzer {
+	fuzzer, err := NewContractFuzzer(ArgsContractFuzzer{
+		Code:          testcommon.GetSCCode(adderPath),
+		InitArguments: [][]byte{{5}},
+		Endpoints:     []string{"add", "getSum"},
+		ESDTTokens:    [][]byte{[]byte("FUZZ-123456")},
+		Invariants:    invariants,
+	})
+	require.Nil(tb, err)
+	return fuzzer
+}
+
+func TestNewContractFuzzer_InvalidArguments(t *testing.T) {
+	_, err := NewContractFuzzer(ArgsContractFuzzer{Endpoints: []string{"add"}})
+	require.Equal(t, ErrNilContractCode, err)
+
+	_, err = NewContractFuzzer(ArgsContractFuzzer{Code: []byte("code")})
+	require.Equal(t, ErrNoEndpoints, err)
+}
+
+func TestContractFuzzer_EncodeDecodeCalls(t *testing.T) {
+	fuzzer := &ContractFuzzer{
+		endpoints:         []string{"add", "getSum"},
+		callers:           [][]byte{[]byte("alice"), []byte("bob")},
+		esdtTokens:        [][]byte{[]byte("TOKEN-1"), []byte("TOKEN-2")},
+		maxCallsPerInput:  3,
+		maxArguments:      2,
+		maxArgumentLength: 8,
+		maxESDTPayments:   2,
+		maxEGLDValue:      1000,
+		maxESDTValue:      1000,
+	}
+
+	calls := []*Call{
+		{
+			Endpoint:     "add",
+			Caller:       []byte("bob"),
+			EGLDValue:    10,
+			ESDTPayments: []*ESDTPayment{},
+			Arguments:    [][]byte{{1, 2}, {}},
+		},
+		{
+			Endpoint:  "getSum",
+			Caller:    []byte("alice"),
+			EGLDValue: 0,
+			ESDTPayments: []*ESDTPayment{
+				{TokenIdentifier: []byte("TOKEN-2"), Value: 7},
+				{TokenIdentifier: []byte("TOKEN-1"), Value: 1000},
+			},
+			Arguments: [][]byte{},
+		},
+	}
+
+	decoded := fuzzer.DecodeCalls(fuzzer.EncodeCalls(calls...))
+	require.Equal(t, calls, decoded)
+
+	decoded = fuzzer.DecodeCalls(nil)
+	require.Len(t, decoded, 1)
+	require.Equal(t, "add", decoded[0].Endpoint)
+}
+
+func TestContractFuzzer_InvariantViolation(t *testing.T) {
+	errSumTooLarge := errors.New("sum too large")
+	fuzzer := newAdderFuzzer(t, func(call *Call, vmOutput *vmcommon.VMOutput, _ *worldmock.MockWorld) error {
+		if call.Endpoint == "add" && vmOutput.ReturnCode == vmcommon.Ok && len(call.Arguments) > 0 {
+			return errSumTooLarge
+		}
+		return nil
+	})
+
+	addCall := &Call{
+		Endpoint:  "add",
+		Caller:    fuzzer.Callers()[0],
+		Arguments: [][]byte{big.NewInt(3).Bytes()},
+	}
+	failures := fuzzer.Run(fuzzer.EncodeCalls(addCall))
+	require.Len(t, failures, 1)
+	require.Equal(t, InvariantViolation, failures[0].Kind)
+	require.Equal(t, errSumTooLarge.Error(), failures[0].Message)
+
+	getSumCall := &Call{Endpoint: "getSum", Caller: fuzzer.Callers()[1]}
+	failures = fuzzer.Run(fuzzer.EncodeCalls(getSumCall))
+	require.Len(t, failures, 0)
+}
+
+func TestContractFuzzer_MinimizeCorpus(t *testing.T) {
+	fuzzer := newAdderFuzzer(t)
+
+	getSum := fuzzer.EncodeCalls(&Call{Endpoint: "getSum", Caller: fuzzer.Callers()[0]})
+	getSumTwice := fuzzer.EncodeCalls(
+		&Call{Endpoint: "getSum", Caller: fuzzer.Callers()[0]},
+		&Call{Endpoint: "getSum", Caller: fuzzer.Callers()[1]},
+	)
+
+	minimized := fuzzer.MinimizeCorpus([][]byte{getSumTwice, getSum})
+	require.Equal(t, [][]byte{getSum}, minimized)
+}
+
+func TestCorpusDir(t *testing.T) {
+	dir := t.TempDir()
+	entries := [][]byte{{0, 1, 2}, []byte("\"quoted\"\n")}
+
+	err := WriteCorpusDir(dir, entries)
+	require.Nil(t, err)
+
+	read, err := ReadCorpusDir(dir)
+	require.Nil(t, err)
+	require.ElementsMatch(t, entries, read)
+}
+
+func FuzzAdder(f *testing.F) {
+	fuzzer := newAdderFuzzer(f)
+	fuzzer.AddSeeds(f,
+		[]*Call{{Endpoint: "add", Caller: fuzzer.Callers()[0], Arguments: [][]byte{{7}}}},
+		[]*Call{{Endpoint: "getSum", Caller: fuzzer.Callers()[1]}},
+	)
+	fuzzer.Fuzz(f)
+}
+
+func TestContractFuzzer_DecodeCallsWithUnboundedValues(t *testing.T) {
+	fuzzer := &ContractFuzzer{
+		endpoints:         []string{"add"},
+		callers:           [][]byte{[]byte("alice")},
+		esdtTokens:        [][]byte{[]byte("TOKEN-1")},
+		maxCallsPerInput:  1,
+		maxArguments:      0,
+		maxArgumentLength: 0,
+		maxESDTPayments:   1,
+		maxEGLDValue:      math.MaxUint64,
+		maxESDTValue:      math.MaxUint64,
+	}
+
+	calls := []*Call{
+		{
+			Endpoint:  "add",
+			Caller:    []byte("alice"),
+			EGLDValue: math.MaxUint64,
+			ESDTPayments: []*ESDTPayment{
+				{TokenIdentifier: []byte("TOKEN-1"), Value: math.MaxUint64},
+			},
+			Arguments: [][]byte{},
+		},
+	}
+
+	decoded := fuzzer.DecodeCalls(fuzzer.EncodeCalls(calls...))
+	require.Equal(t, calls, decoded)
+}
@@ -0,0 +1,173 @@
+package fuzzing
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/contexts"
+)
+
+const corpusFileHeader = "go test fuzz v1"
+
+// MinimizeCorpus returns a subset of the given fuzzer inputs which exercises the same features:
+// the endpoints called, the return codes they produced, the EI functions they used and the
+// kinds of failures found; shorter inputs are preferred
+func (cf *ContractFuzzer) MinimizeCorpus(entries [][]byte) [][]byte {
+	sortedEntries := make([][]byte, len(entries))
+	copy(sortedEntries, entries)
+	sort.SliceStable(sortedEntries, func(i, j int) bool {
+		return len(sortedEntries[i]) < len(sortedEntries[j])
+	})
+
+	entryFeatures := make([]map[string]struct{}, len(sortedEntries))
+	for i, entry := range sortedEntries {
+		entryFeatures[i] = cf.collectFeatures(entry)
+	}
+
+	covered := make(map[string]struct{})
+	selected := make([]bool, len(sortedEntries))
+	for {
+		bestIndex := -1
+		bestGain := 0
+		for i, features := range entryFeatures {
+			if selected[i] {
+				continue
+			}
+			gain := countUncovered(features, covered)
+			if gain > bestGain {
+				bestIndex = i
+				bestGain = gain
+			}
+		}
+		if bestIndex < 0 {
+			break
+		}
+
+		selected[bestIndex] = true
+		for feature := range entryFeatures[bestIndex] {
+			covered[feature] = struct{}{}
+		}
+	}
+
+	minimized := make([][]byte, 0)
+	for i, entry := range sortedEntries {
+		if selected[i] {
+			minimized = append(minimized, entry)
+		}
+	}
+
+	return minimized
+}
+
+func (cf *ContractFuzzer) collectFeatures(data []byte) map[string]struct{} {
+	tracker := contexts.NewEnabledCoverageTracker()
+	cf.setCoverageTracker(tracker)
+	defer cf.setCoverageTracker(nil)
+
+	features := make(map[string]struct{})
+	cf.resetWorld()
+	for _, call := range cf.DecodeCalls(data) {
+		vmOutput, failures := cf.executeCall(call)
+		if vmOutput != nil {
+			features[call.Endpoint+"|"+vmOutput.ReturnCode.String()] = struct{}{}
+		}
+		for _, failure := range failures {
+			features[call.Endpoint+"|"+failure.Kind.String()] = struct{}{}
+		}
+	}
+
+	for _, coverage := range tracker.GetCoverage() {
+		for eiFunction := range coverage.EICalls {
+			features["ei|"+eiFunction] = struct{}{}
+		}
+	}
+
+	return features
+}
+
+func countUncovered(features map[string]struct{}, covered map[string]struct{}) int {
+	count := 0
+	for feature := range features {
+		_, ok := covered[feature]
+		if !ok {
+			count++
+		}
+	}
+	return count
+}
+
+// ReadCorpusDir reads the fuzzer inputs from a directory in the format of the native Go fuzzing
+// engine, such as testdata/fuzz/FuzzXxx
+func ReadCorpusDir(dir string) ([][]byte, error) {
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([][]byte, 0, len(dirEntries))
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(dir, dirEntry.Name())
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		entry, err := parseCorpusFile(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// WriteCorpusDir writes the fuzzer inputs to a directory in the format of the native Go fuzzing
+// engine, naming each file after the hash of its content
+func WriteCorpusDir(dir string, entries [][]byte) error {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		hash := sha256.Sum256(entry)
+		path := filepath.Join(dir, hex.EncodeToString(hash[:8]))
+		content := fmt.Sprintf("%s\n[]byte(%q)\n", corpusFileHeader, entry)
+		err = os.WriteFile(path, []byte(content), 0644)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func parseCorpusFile(content string) ([]byte, error) {
+	lines := strings.Split(strings.TrimSpace(content), "\n")
+	if len(lines) != 2 || lines[0] != corpusFileHeader {
+		return nil, ErrInvalidCorpusFile
+	}
+
+	value := strings.TrimSpace(lines[1])
+	if !strings.HasPrefix(value, "[]byte(") || !strings.HasSuffix(value, ")") {
+		return nil, ErrInvalidCorpusFile
+	}
+
+	unquoted, err := strconv.Unquote(value[len("[]byte(") : len(value)-1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCorpusFile, err.Error())
+	}
+
+	return []byte(unquoted), nil
+}
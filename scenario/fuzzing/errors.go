@@ -0,0 +1,18 @@
+package fuzzing
+
+import "errors"
+
+// ErrNilContractCode signals that no contract code was provided to the fuzzer
+var ErrNilContractCode = errors.New("nil contract code")
+
+// ErrNoEndpoints signals that no endpoints were provided to the fuzzer
+var ErrNoEndpoints = errors.New("no endpoints to fuzz")
+
+// ErrWrongTypeAssertion signals that the VM built for fuzzing is not a VMHost
+var ErrWrongTypeAssertion = errors.New("wrong type assertion")
+
+// ErrDeploymentFailed signals that the fuzzed contract could not be deployed
+var ErrDeploymentFailed = errors.New("contract deployment failed")
+
+// ErrInvalidCorpusFile signals that a corpus file is not a single []byte value in the Go fuzzing format
+var ErrInvalidCorpusFile = errors.New("invalid corpus file")
@@ -0,0 +1,77 @@
+package fuzzing
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// FailureKind classifies the problems found by the fuzzer
+type FailureKind uint8
+
+const (
+	// VMPanic is a panic recovered by the VM during contract execution
+	VMPanic FailureKind = iota
+
+	// GoPanic is a panic which escaped the VM
+	GoPanic
+
+	// Crash is an error returned by the VM instead of a VMOutput
+	Crash
+
+	// ExecutionFailed is a call which ended with vmcommon.ExecutionFailed
+	ExecutionFailed
+
+	// InvariantViolation is an error returned by one of the invariants
+	InvariantViolation
+)
+
+// String returns the name of the failure kind
+func (kind FailureKind) String() string {
+	switch kind {
+	case VMPanic:
+		return "vm panic"
+	case GoPanic:
+		return "go panic"
+	case Crash:
+		return "crash"
+	case ExecutionFailed:
+		return "execution failed"
+	case InvariantViolation:
+		return "invariant violation"
+	default:
+		return fmt.Sprintf("unknown failure kind %d", kind)
+	}
+}
+
+// Failure is a problem found while executing a fuzzed call
+type Failure struct {
+	Kind    FailureKind
+	Call    *Call
+	Message string
+}
+
+// String returns a human-readable description of the failure and of the call which caused it
+func (failure *Failure) String() string {
+	return fmt.Sprintf("%s in %s: %s", failure.Kind, failure.Call.String(), failure.Message)
+}
+
+// String returns a human-readable description of the call
+func (call *Call) String() string {
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "%s(", call.Endpoint)
+	for i, argument := range call.Arguments {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(sb, "0x%s", hex.EncodeToString(argument))
+	}
+	fmt.Fprintf(sb, ") from %s", string(call.Caller))
+	if call.EGLDValue > 0 {
+		fmt.Fprintf(sb, " with %d EGLD", call.EGLDValue)
+	}
+	for _, payment := range call.ESDTPayments {
+		fmt.Fprintf(sb, " with %d %s", payment.Value, string(payment.TokenIdentifier))
+	}
+	return sb.String()
+}
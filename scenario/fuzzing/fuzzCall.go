@@ -0,0 +1,176 @@
+package fuzzing
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+)
+
+const (
+	egldPaymentFlag = 1 << iota
+	esdtPaymentFlag
+)
+
+// ESDTPayment is a fungible ESDT transfer attached to a fuzzed call
+type ESDTPayment struct {
+	TokenIdentifier []byte
+	Value           uint64
+}
+
+// Call is a single contract call decoded from the fuzzer input
+type Call struct {
+	Endpoint     string
+	Caller       []byte
+	EGLDValue    uint64
+	ESDTPayments []*ESDTPayment
+	Arguments    [][]byte
+}
+
+// fuzzInputReader reads the fuzzer input byte by byte, yielding zeroes once the input is exhausted
+type fuzzInputReader struct {
+	data     []byte
+	position int
+}
+
+func (reader *fuzzInputReader) exhausted() bool {
+	return reader.position >= len(reader.data)
+}
+
+func (reader *fuzzInputReader) readByte() byte {
+	if reader.exhausted() {
+		return 0
+	}
+
+	value := reader.data[reader.position]
+	reader.position++
+	return value
+}
+
+func (reader *fuzzInputReader) readBytes(length int) []byte {
+	result := make([]byte, length)
+	for i := range result {
+		result[i] = reader.readByte()
+	}
+	return result
+}
+
+func (reader *fuzzInputReader) readUint64() uint64 {
+	return binary.BigEndian.Uint64(reader.readBytes(8))
+}
+
+// DecodeCalls deterministically converts the fuzzer input into a sequence of calls; any input,
+// including an empty one, yields at least one call
+func (cf *ContractFuzzer) DecodeCalls(data []byte) []*Call {
+	reader := &fuzzInputReader{data: data}
+
+	calls := make([]*Call, 0, 1)
+	for len(calls) < cf.maxCallsPerInput {
+		calls = append(calls, cf.decodeCall(reader))
+		if reader.exhausted() {
+			break
+		}
+	}
+
+	return calls
+}
+
+func (cf *ContractFuzzer) decodeCall(reader *fuzzInputReader) *Call {
+	call := &Call{
+		Endpoint:     cf.endpoints[int(reader.readByte())%len(cf.endpoints)],
+		Caller:       cf.callers[int(reader.readByte())%len(cf.callers)],
+		ESDTPayments: make([]*ESDTPayment, 0),
+		Arguments:    make([][]byte, 0),
+	}
+
+	paymentFlags := reader.readByte()
+	if paymentFlags&egldPaymentFlag != 0 {
+		call.EGLDValue = boundValue(reader.readUint64(), cf.maxEGLDValue)
+	}
+	if paymentFlags&esdtPaymentFlag != 0 && len(cf.esdtTokens) > 0 {
+		numPayments := 1 + int(reader.readByte())%cf.maxESDTPayments
+		for i := 0; i < numPayments; i++ {
+			call.ESDTPayments = append(call.ESDTPayments, &ESDTPayment{
+				TokenIdentifier: cf.esdtTokens[int(reader.readByte())%len(cf.esdtTokens)],
+				Value:           boundValue(reader.readUint64(), cf.maxESDTValue),
+			})
+		}
+	}
+
+	numArguments := int(reader.readByte()) % (cf.maxArguments + 1)
+	for i := 0; i < numArguments; i++ {
+		argumentLength := int(reader.readByte()) % (cf.maxArgumentLength + 1)
+		call.Arguments = append(call.Arguments, reader.readBytes(argumentLength))
+	}
+
+	return call
+}
+
+// EncodeCalls converts the given calls into a fuzzer input, to be used as a seed; it is the
+// inverse of DecodeCalls for calls which respect the configured limits
+func (cf *ContractFuzzer) EncodeCalls(calls ...*Call) []byte {
+	buffer := &bytes.Buffer{}
+	for _, call := range calls {
+		buffer.WriteByte(byte(indexOfString(cf.endpoints, call.Endpoint)))
+		buffer.WriteByte(byte(indexOfBytes(cf.callers, call.Caller)))
+
+		paymentFlags := byte(0)
+		if call.EGLDValue > 0 {
+			paymentFlags |= egldPaymentFlag
+		}
+		if len(call.ESDTPayments) > 0 {
+			paymentFlags |= esdtPaymentFlag
+		}
+		buffer.WriteByte(paymentFlags)
+
+		if call.EGLDValue > 0 {
+			writeUint64(buffer, call.EGLDValue)
+		}
+		if len(call.ESDTPayments) > 0 {
+			buffer.WriteByte(byte(len(call.ESDTPayments) - 1))
+			for _, payment := range call.ESDTPayments {
+				buffer.WriteByte(byte(indexOfBytes(cf.esdtTokens, payment.TokenIdentifier)))
+				writeUint64(buffer, payment.Value)
+			}
+		}
+
+		buffer.WriteByte(byte(len(call.Arguments)))
+		for _, argument := range call.Arguments {
+			buffer.WriteByte(byte(len(argument)))
+			buffer.Write(argument)
+		}
+	}
+
+	return buffer.Bytes()
+}
+
+// boundValue maps the value into [0, maxValue], leaving it as it is when any uint64 is accepted
+func boundValue(value uint64, maxValue uint64) uint64 {
+	if maxValue == math.MaxUint64 {
+		return value
+	}
+	return value % (maxValue + 1)
+}
+
+func writeUint64(buffer *bytes.Buffer, value uint64) {
+	encoded := make([]byte, 8)
+	binary.BigEndian.PutUint64(encoded, value)
+	buffer.Write(encoded)
+}
+
+func indexOfString(values []string, value string) int {
+	for i, candidate := range values {
+		if candidate == value {
+			return i
+		}
+	}
+	return 0
+}
+
+func indexOfBytes(values [][]byte, value []byte) int {
+	for i, candidate := range values {
+		if bytes.Equal(candidate, value) {
+			return i
+		}
+	}
+	return 0
+}
@@ -0,0 +1,159 @@
+package scenario
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	fr "github.com/multiversx/mx-chain-scenario-go/scenario/expression/fileresolver"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+)
+
+// ErrScenarioStopped signals that the scenario execution was stopped at the requested step
+var ErrScenarioStopped = errors.New("scenario execution stopped")
+
+// ErrNilScenarioRunner signals that a nil scenario runner was provided
+var ErrNilScenarioRunner = errors.New("nil scenario runner")
+
+// ErrNilMockWorld signals that a nil MockWorld was provided
+var ErrNilMockWorld = errors.New("nil mock world")
+
+const stepTagPrefix = "#"
+
+// ArgsScenarioStepFilter holds the arguments needed to create a ScenarioStepFilter
+type ArgsScenarioStepFilter struct {
+	Runner scenio.ScenarioRunner
+	World  *worldmock.MockWorld
+
+	// StepIDPattern, if set, selects the steps whose id (or path, for externalSteps) matches it
+	StepIDPattern *regexp.Regexp
+
+	// Tag, if set, selects the steps whose comment contains the tag, written as #tag
+	Tag string
+
+	// FromStep skips the steps before the given 1-based position, whose effects are expected to
+	// be restored by ResumeState
+	FromStep int
+
+	// StopAfterStep stops the execution after the step at the given 1-based position
+	StopAfterStep int
+
+	// StateDumpPath, if set, receives the world state at the stop point, usable as ResumeState
+	StateDumpPath string
+
+	// ResumeState is a scenario, normally a state dump, executed before the first scenario
+	ResumeState *scenmodel.Scenario
+}
+
+// ScenarioStepFilter wraps a ScenarioRunner, executing only the selected steps of each scenario;
+// setState steps are always executed, since the steps after them depend on the state they set
+type ScenarioStepFilter struct {
+	runner        scenio.ScenarioRunner
+	world         *worldmock.MockWorld
+	stepIDPattern *regexp.Regexp
+	tag           string
+	fromStep      int
+	stopAfterStep int
+	stateDumpPath string
+	resumeState   *scenmodel.Scenario
+}
+
+var _ scenio.ScenarioRunner = (*ScenarioStepFilter)(nil)
+
+// NewScenarioStepFilter creates a new ScenarioStepFilter
+func NewScenarioStepFilter(args ArgsScenarioStepFilter) (*ScenarioStepFilter, error) {
+	if args.Runner == nil {
+		return nil, ErrNilScenarioRunner
+	}
+	if args.World == nil && len(args.StateDumpPath) > 0 {
+		return nil, ErrNilMockWorld
+	}
+
+	return &ScenarioStepFilter{
+		runner:        args.Runner,
+		world:         args.World,
+		stepIDPattern: args.StepIDPattern,
+		tag:           strings.TrimPrefix(args.Tag, stepTagPrefix),
+		fromStep:      args.FromStep,
+		stopAfterStep: args.StopAfterStep,
+		stateDumpPath: args.StateDumpPath,
+		resumeState:   args.ResumeState,
+	}, nil
+}
+
+// Reset resets the wrapped runner
+func (sf *ScenarioStepFilter) Reset() {
+	sf.runner.Reset()
+}
+
+// RunScenario runs the selected steps of the scenario with the wrapped runner, returning
+// ErrScenarioStopped once the stop step ran, even if it is the last step of the scenario
+func (sf *ScenarioStepFilter) RunScenario(scenario *scenmodel.Scenario, fileResolver fr.FileResolver) error {
+	filtered := *scenario
+	filtered.Steps = make([]scenmodel.Step, 0, len(scenario.Steps))
+	if sf.resumeState != nil {
+		filtered.Steps = append(filtered.Steps, sf.resumeState.Steps...)
+		sf.resumeState = nil
+	}
+
+	stopped := false
+	for stepIndex, step := range scenario.Steps {
+		stepPosition := stepIndex + 1
+		if stepPosition < sf.fromStep {
+			continue
+		}
+		if sf.isSelected(step) {
+			filtered.Steps = append(filtered.Steps, step)
+		}
+		if stepPosition == sf.stopAfterStep {
+			stopped = true
+			break
+		}
+	}
+
+	err := sf.runner.RunScenario(&filtered, fileResolver)
+	if err != nil {
+		return err
+	}
+	if !stopped {
+		return nil
+	}
+
+	if len(sf.stateDumpPath) > 0 {
+		comment := fmt.Sprintf("world state after step %d, resume from step %d", sf.stopAfterStep, sf.stopAfterStep+1)
+		err = WriteWorldStateFile(sf.world, scenario.GasSchedule, comment, sf.stateDumpPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	return fmt.Errorf("%w after step %d", ErrScenarioStopped, sf.stopAfterStep)
+}
+
+func (sf *ScenarioStepFilter) isSelected(step scenmodel.Step) bool {
+	if _, isSetState := step.(*scenmodel.SetStateStep); isSetState {
+		return true
+	}
+
+	identifier, comment := stepIdentifierAndComment(step)
+	if sf.stepIDPattern != nil && !sf.stepIDPattern.MatchString(identifier) {
+		return false
+	}
+	if len(sf.tag) > 0 && !hasStepTag(comment, sf.tag) {
+		return false
+	}
+
+	return true
+}
+
+func hasStepTag(comment string, tag string) bool {
+	for _, word := range strings.Fields(comment) {
+		if word == stepTagPrefix+tag {
+			return true
+		}
+	}
+	return false
+}
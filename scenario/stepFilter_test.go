@@ -0,0 +1,137 @@
+package scenario
+
+import (
+	"errors"
+	"math/big"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	scenexec "github.com/multiversx/mx-chain-scenario-go/scenario/executor"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/testcommon"
+	"github.com/stretchr/testify/require"
+)
+
+func newStepFilterTestScenario() *scenmodel.Scenario {
+	return &scenmodel.Scenario{
+		Steps: []scenmodel.Step{
+			&scenmodel.SetStateStep{SetStateIdent: "init"},
+			&scenmodel.TxStep{TxIdent: "deposit-1", Comment: "#deposit", Tx: &scenmodel.Transaction{Type: scenmodel.ScCall}},
+			&scenmodel.TxStep{TxIdent: "withdraw-1", Comment: "#withdraw", Tx: &scenmodel.Transaction{Type: scenmodel.ScCall}},
+			&scenmodel.CheckStateStep{CheckStateIdent: "check-1"},
+			&scenmodel.TxStep{TxIdent: "deposit-2", Comment: "second #deposit", Tx: &scenmodel.Transaction{Type: scenmodel.ScCall}},
+		},
+	}
+}
+
+func executedStepIDs(runner *scenarioRunnerStub) []string {
+	ids := make([]string, 0, len(runner.executedSteps))
+	for _, step := range runner.executedSteps {
+		id, _ := stepIdentifierAndComment(step)
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestNewScenarioStepFilter_InvalidArguments(t *testing.T) {
+	_, err := NewScenarioStepFilter(ArgsScenarioStepFilter{})
+	require.Equal(t, ErrNilScenarioRunner, err)
+
+	_, err = NewScenarioStepFilter(ArgsScenarioStepFilter{
+		Runner:        &scenarioRunnerStub{},
+		StateDumpPath: "state.scen.json",
+	})
+	require.Equal(t, ErrNilMockWorld, err)
+}
+
+func TestScenarioStepFilter_StepIDPattern(t *testing.T) {
+	runner := &scenarioRunnerStub{}
+	filter, _ := NewScenarioStepFilter(ArgsScenarioStepFilter{
+		Runner:        runner,
+		StepIDPattern: regexp.MustCompile("^deposit"),
+	})
+
+	err := filter.RunScenario(newStepFilterTestScenario(), nil)
+	require.Nil(t, err)
+	require.Equal(t, []string{"init", "deposit-1", "deposit-2"}, executedStepIDs(runner))
+}
+
+func TestScenarioStepFilter_Tag(t *testing.T) {
+	runner := &scenarioRunnerStub{}
+	filter, _ := NewScenarioStepFilter(ArgsScenarioStepFilter{
+		Runner: runner,
+		Tag:    "#withdraw",
+	})
+
+	err := filter.RunScenario(newStepFilterTestScenario(), nil)
+	require.Nil(t, err)
+	require.Equal(t, []string{"init", "withdraw-1"}, executedStepIDs(runner))
+}
+
+func TestScenarioStepFilter_StopAfterStepDumpsState(t *testing.T) {
+	world := worldmock.NewMockWorld()
+	address := testcommon.MakeTestSCAddress("contract")
+	account := world.AcctMap.CreateSmartContractAccount(nil, address, []byte("code"), world)
+	account.Balance = big.NewInt(42)
+	account.Storage["key"] = []byte("value")
+
+	runner := &scenarioRunnerStub{}
+	statePath := filepath.Join(t.TempDir(), "state.scen.json")
+	filter, _ := NewScenarioStepFilter(ArgsScenarioStepFilter{
+		Runner:        runner,
+		World:         world,
+		StopAfterStep: 3,
+		StateDumpPath: statePath,
+	})
+
+	err := filter.RunScenario(newStepFilterTestScenario(), nil)
+	require.True(t, errors.Is(err, ErrScenarioStopped))
+	require.Equal(t, []string{"init", "deposit-1", "withdraw-1"}, executedStepIDs(runner))
+
+	resumeState, err := scenio.ParseScenariosScenarioDefaultParser(statePath)
+	require.Nil(t, err)
+
+	executor := scenexec.NewScenarioExecutor(NewScenarioVMHostBuilder())
+	err = executor.ExecuteSetStateStep(resumeState.Steps[0].(*scenmodel.SetStateStep))
+	require.Nil(t, err)
+
+	restored := executor.World.AcctMap.GetAccount(address)
+	require.NotNil(t, restored)
+	require.Equal(t, big.NewInt(42), restored.Balance)
+	require.Equal(t, []byte("code"), restored.Code)
+	require.Equal(t, []byte("value"), restored.Storage["key"])
+}
+
+func TestScenarioStepFilter_StopAfterLastStepDumpsState(t *testing.T) {
+	runner := &scenarioRunnerStub{}
+	statePath := filepath.Join(t.TempDir(), "state.scen.json")
+	filter, _ := NewScenarioStepFilter(ArgsScenarioStepFilter{
+		Runner:        runner,
+		World:         worldmock.NewMockWorld(),
+		StopAfterStep: 5,
+		StateDumpPath: statePath,
+	})
+
+	err := filter.RunScenario(newStepFilterTestScenario(), nil)
+	require.True(t, errors.Is(err, ErrScenarioStopped))
+	require.Len(t, runner.executedSteps, 5)
+	require.FileExists(t, statePath)
+}
+
+func TestScenarioStepFilter_Resume(t *testing.T) {
+	runner := &scenarioRunnerStub{}
+	filter, _ := NewScenarioStepFilter(ArgsScenarioStepFilter{
+		Runner:   runner,
+		FromStep: 4,
+		ResumeState: &scenmodel.Scenario{
+			Steps: []scenmodel.Step{&scenmodel.SetStateStep{SetStateIdent: "world-state"}},
+		},
+	})
+
+	err := filter.RunScenario(newStepFilterTestScenario(), nil)
+	require.Nil(t, err)
+	require.Equal(t, []string{"world-state", "check-1", "deposit-2"}, executedStepIDs(runner))
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/mock"
 
 	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
 	scenexec "github.com/multiversx/mx-chain-scenario-go/scenario/executor"
 	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
 	"github.com/multiversx/mx-chain-scenario-go/worldmock"
@@ -24,6 +25,19 @@ var DefaultVMType = []byte{5, 0}
 // VMTestExecutor parses, interprets and executes both .test.json tests and .scen.json scenarios with VM.
 type ScenarioVMHostBuilder struct {
 	VMType []byte
+
+	// CoverageTracker, if set, collects the endpoints and EI functions reached by the scenarios
+	CoverageTracker vmhost.CoverageTracker
+
+	// CallDebugger, if set, is notified at each nested call boundary
+	CallDebugger vmhost.CallDebugger
+
+	// CompiledCodeStore, if set, keeps the compiled contracts across the scenario runs, since
+	// the compiled codes saved in the mock world are lost with it
+	CompiledCodeStore vmhost.CompiledCodeStore
+
+	// WasmEngine, if set, replaces Wasmer in executing the scenario contracts
+	WasmEngine vmhost.WasmEngine
 }
 
 // NewScenarioVMHostBuilder creates a default ScenarioVMHostBuilder.
@@ -67,7 +81,7 @@ func (svb *ScenarioVMHostBuilder) NewVM(
 	blockGasLimit := uint64(10000000)
 	esdtTransferParser, _ := parsers.NewESDTTransferParser(worldmock.WorldMarshalizer)
 
-	return hostCore.NewVMHost(
+	host, err := hostCore.NewVMHost(
 		world,
 		&vmhost.VMHostParameters{
 			VMType:                   svb.VMType,
@@ -80,8 +94,21 @@ func (svb *ScenarioVMHostBuilder) NewVM(
 			EnableEpochsHandler:      world.EnableEpochsHandler,
 			WasmerSIGSEGVPassthrough: false,
 			Hasher:                   worldmock.DefaultHasher,
+			CompiledCodeStore:        svb.CompiledCodeStore,
+			WasmEngine:               svb.WasmEngine,
 		})
+	if err != nil {
+		return nil, err
+	}
+
+	if !check.IfNil(svb.CoverageTracker) {
+		host.SetCoverageTracker(svb.CoverageTracker)
+	}
+	if !check.IfNil(svb.CallDebugger) {
+		host.SetCallDebugger(svb.CallDebugger)
+	}
 
+	return host, nil
 }
 
 // DefaultScenarioExecutor provides a scenario executor with VM 1.5, default configuration
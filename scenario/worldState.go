@@ -0,0 +1,161 @@
+package scenario
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+
+	oj "github.com/multiversx/mx-chain-scenario-go/orderedjson"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+)
+
+// WorldStateToScenario converts the full state of the MockWorld into a scenario with a single
+// setState step which recreates it; unlike the dumpState step, the contract code and the
+// protected storage are included, so the result can be used as a resume point
+func WorldStateToScenario(world *worldmock.MockWorld, gasSchedule scenmodel.GasSchedule, comment string) *scenmodel.Scenario {
+	addresses := make([]string, 0, len(world.AcctMap))
+	for address := range world.AcctMap {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	accounts := make([]*scenmodel.Account, 0, len(addresses))
+	for _, address := range addresses {
+		accounts = append(accounts, accountToScenarioFormat(world.AcctMap[address]))
+	}
+
+	newAddressMocks := make([]*scenmodel.NewAddressMock, 0, len(world.NewAddressMocks))
+	for _, newAddressMock := range world.NewAddressMocks {
+		newAddressMocks = append(newAddressMocks, &scenmodel.NewAddressMock{
+			CreatorAddress: bytesFromString(newAddressMock.CreatorAddress),
+			CreatorNonce:   uint64Value(newAddressMock.CreatorNonce),
+			NewAddress:     bytesFromString(newAddressMock.NewAddress),
+		})
+	}
+
+	blockHashes := scenmodel.JSONValueList{}
+	for _, blockHash := range world.Blockhashes {
+		blockHashes.Values = append(blockHashes.Values, bytesFromString(blockHash))
+	}
+
+	return &scenmodel.Scenario{
+		Comment:     comment,
+		CheckGas:    true,
+		GasSchedule: gasSchedule,
+		Steps: []scenmodel.Step{
+			&scenmodel.SetStateStep{
+				SetStateIdent:     "world-state",
+				Accounts:          accounts,
+				NewAddressMocks:   newAddressMocks,
+				PreviousBlockInfo: blockInfoToScenarioFormat(world.PreviousBlockInfo),
+				CurrentBlockInfo:  blockInfoToScenarioFormat(world.CurrentBlockInfo),
+				BlockHashes:       blockHashes,
+			},
+		},
+	}
+}
+
+// WriteWorldStateFile writes the state of the MockWorld as a scenario file, see WorldStateToScenario
+func WriteWorldStateFile(world *worldmock.MockWorld, gasSchedule scenmodel.GasSchedule, comment string, path string) error {
+	return scenio.WriteScenariosScenario(WorldStateToScenario(world, gasSchedule, comment), path)
+}
+
+func accountToScenarioFormat(account *worldmock.Account) *scenmodel.Account {
+	storageKeys := make([]string, 0, len(account.Storage))
+	for key, value := range account.Storage {
+		if len(value) > 0 {
+			storageKeys = append(storageKeys, key)
+		}
+	}
+	sort.Strings(storageKeys)
+
+	storage := make([]*scenmodel.StorageKeyValuePair, 0, len(storageKeys))
+	for _, key := range storageKeys {
+		storage = append(storage, &scenmodel.StorageKeyValuePair{
+			Key:   bytesFromString([]byte(key)),
+			Value: bytesFromTree(account.Storage[key]),
+		})
+	}
+
+	scenAccount := &scenmodel.Account{
+		Address:         bytesFromString(account.Address),
+		Shard:           uint64Value(uint64(account.ShardID)),
+		Nonce:           uint64Value(account.Nonce),
+		Balance:         bigIntValue(account.Balance),
+		Storage:         storage,
+		DeveloperReward: bigIntValue(account.DeveloperReward),
+		AsyncCallData:   account.AsyncCallData,
+	}
+	if len(account.Username) > 0 {
+		scenAccount.Username = bytesFromString(account.Username)
+	}
+	if len(account.Code) > 0 {
+		scenAccount.Code = bytesFromString(account.Code)
+		scenAccount.CodeMetadata = bytesFromString(account.CodeMetadata)
+	}
+	if len(account.OwnerAddress) > 0 {
+		scenAccount.Owner = bytesFromString(account.OwnerAddress)
+	}
+
+	return scenAccount
+}
+
+func blockInfoToScenarioFormat(blockInfo *worldmock.BlockInfo) *scenmodel.BlockInfo {
+	if blockInfo == nil {
+		return nil
+	}
+
+	scenBlockInfo := &scenmodel.BlockInfo{
+		BlockTimestamp: uint64Value(blockInfo.BlockTimestamp),
+		BlockNonce:     uint64Value(blockInfo.BlockNonce),
+		BlockRound:     uint64Value(blockInfo.BlockRound),
+		BlockEpoch:     uint64Value(uint64(blockInfo.BlockEpoch)),
+	}
+	if blockInfo.RandomSeed != nil {
+		randomSeed := bytesFromTree(blockInfo.RandomSeed[:])
+		scenBlockInfo.BlockRandomSeed = &randomSeed
+	}
+
+	return scenBlockInfo
+}
+
+func hexExpression(value []byte) string {
+	if len(value) == 0 {
+		return ""
+	}
+	return "0x" + hex.EncodeToString(value)
+}
+
+func bytesFromString(value []byte) scenmodel.JSONBytesFromString {
+	return scenmodel.JSONBytesFromString{
+		Value:    value,
+		Original: hexExpression(value),
+	}
+}
+
+func bytesFromTree(value []byte) scenmodel.JSONBytesFromTree {
+	return scenmodel.JSONBytesFromTree{
+		Value:    value,
+		Original: &oj.OJsonString{Value: hexExpression(value)},
+	}
+}
+
+func uint64Value(value uint64) scenmodel.JSONUint64 {
+	return scenmodel.JSONUint64{
+		Value:    value,
+		Original: fmt.Sprintf("%d", value),
+	}
+}
+
+func bigIntValue(value *big.Int) scenmodel.JSONBigInt {
+	if value == nil {
+		value = big.NewInt(0)
+	}
+	return scenmodel.JSONBigInt{
+		Value:    value,
+		Original: value.String(),
+	}
+}
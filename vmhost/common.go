@@ -134,6 +134,70 @@ type VMHostParameters struct {
 	EnableEpochsHandler                 EnableEpochsHandler
 	Hasher                              HashComputer
 	TimeOutForSCExecutionInMilliseconds uint32
+
+	// StrictGasScheduleValidation rejects the gas schedules failing config.ValidateGasSchedule,
+	// which are otherwise only logged
+	StrictGasScheduleValidation bool
+
+	// WarmInstanceCache configures the cache of warm instances; nil keeps the default cache,
+	// enabled, holding 100 instances, with LRU eviction and no memory budget
+	WarmInstanceCache *WarmInstanceCacheConfig
+
+	// CompiledCodeStore persists the compiled contracts besides the blockchain hook; nil disables it
+	CompiledCodeStore CompiledCodeStore
+
+	// WasmEngine executes the contracts; nil selects Wasmer, linked through cgo
+	WasmEngine WasmEngine
+}
+
+// WarmInstanceEvictionPolicy selects the warm instance evicted when the warm instance cache is full
+type WarmInstanceEvictionPolicy string
+
+const (
+	// LRUEviction evicts the least recently used warm instance
+	LRUEviction WarmInstanceEvictionPolicy = "LRU"
+
+	// LFUEviction evicts the least frequently used warm instance, the least recently used one among equals
+	LFUEviction WarmInstanceEvictionPolicy = "LFU"
+
+	// SizeAwareEviction evicts the largest warm instance, the least recently used one among equals
+	SizeAwareEviction WarmInstanceEvictionPolicy = "SizeAware"
+)
+
+// DefaultWarmInstanceCacheSize is the number of warm instances kept when the size is not configured
+const DefaultWarmInstanceCacheSize = 100
+
+// WarmInstanceCacheConfig configures the cache of warm instances. A zero Size or EvictionPolicy selects
+// the default, while a zero MemoryBudget means that the cache is limited by the number of instances only.
+// The memory of an instance is estimated as the size of its code plus the size of its linear memory.
+// RestoreWarmInstances snapshots the memory and the globals of each instance saved as warm, restoring them
+// on each reuse, in case the engine leaks them through Reset; the snapshots are counted in the size of the
+// warm instances, and a warm instance whose memory grew is discarded, as its memory cannot shrink back.
+// VerifyWarmInstances compares each reused warm instance with a fresh instance compiled from the bytecode,
+// discarding the warm instance if their memories or globals differ; it is meant for diagnosis, as it
+// compiles the contract on each reuse.
+type WarmInstanceCacheConfig struct {
+	Enabled              bool
+	Size                 int
+	MemoryBudget         uint64
+	EvictionPolicy       WarmInstanceEvictionPolicy
+	RestoreWarmInstances bool
+	VerifyWarmInstances  bool
+}
+
+// WarmInstanceCacheStats holds the counters of the warm instance cache since the host was created,
+// together with its current contents. Hits counts the instances reused from the cache, while the
+// instances created from precompiled code or from bytecode are the cold ones. StateMismatches counts
+// the warm instances discarded by the verification against fresh instances.
+type WarmInstanceCacheStats struct {
+	Hits                     uint64
+	Misses                   uint64
+	Evictions                uint64
+	InstancesFromPrecompiled uint64
+	InstancesFromBytecode    uint64
+	NumWarmInstances         int
+	WarmInstancesSizeInBytes uint64
+	StateMismatches          uint64
 }
 
 // AsyncCallInfo contains the information required to handle the asynchronous call of another SmartContract
@@ -197,6 +261,167 @@ type AsyncContextInfo struct {
 	AsyncContextMap map[string]*AsyncContext
 }
 
+// CallBoundary identifies the kind of nested call entered or left by the host
+type CallBoundary uint8
+
+const (
+	// DestContextCallBoundary marks a synchronous call executed on the context of the destination
+	DestContextCallBoundary CallBoundary = iota
+
+	// SameContextCallBoundary marks a synchronous call executed on the context of the caller
+	SameContextCallBoundary
+
+	// AsyncCallBoundary marks the destination call of an async call executed in the same shard
+	AsyncCallBoundary
+
+	// AsyncCallbackBoundary marks the callback of an async call executed in the same shard
+	AsyncCallbackBoundary
+)
+
+// String returns a human-readable name for the call boundary
+func (boundary CallBoundary) String() string {
+	switch boundary {
+	case DestContextCallBoundary:
+		return "executeOnDestContext"
+	case SameContextCallBoundary:
+		return "executeOnSameContext"
+	case AsyncCallBoundary:
+		return "asyncCall"
+	case AsyncCallbackBoundary:
+		return "callBack"
+	default:
+		return "unknown"
+	}
+}
+
+// ContractCoverage holds the functions of a contract code which were called during execution,
+// alongside the functions exported by the code and the EI functions it imports
+type ContractCoverage struct {
+	CodeHash            []byte
+	ExportedFunctions   []string
+	ImportedEIFunctions []string
+	FunctionCalls       map[string]uint64
+	EICalls             map[string]uint64
+}
+
+// GasTraceFrameKind identifies what a frame of the gas call tree stands for
+type GasTraceFrameKind uint8
+
+const (
+	// ContractCallFrame is the execution of a contract function
+	ContractCallFrame GasTraceFrameKind = iota
+
+	// EIFunctionFrame is the invocation of an EI function by a contract
+	EIFunctionFrame
+
+	// BuiltinFunctionFrame is the execution of a builtin function on behalf of a contract
+	BuiltinFunctionFrame
+
+	// AsyncCallbackFrame is the execution of the callback of an async call
+	AsyncCallbackFrame
+)
+
+// String returns a human-readable name for the gas trace frame kind
+func (kind GasTraceFrameKind) String() string {
+	switch kind {
+	case ContractCallFrame:
+		return "call"
+	case EIFunctionFrame:
+		return "ei"
+	case BuiltinFunctionFrame:
+		return "builtin"
+	case AsyncCallbackFrame:
+		return "callback"
+	default:
+		return "unknown"
+	}
+}
+
+// GasTraceFrame is a node of the gas call tree; InclusiveGas covers the frame and all its
+// children, while SelfGas excludes the gas attributed to the children
+type GasTraceFrame struct {
+	Kind         GasTraceFrameKind
+	SCAddress    []byte
+	Name         string
+	GasBefore    uint64
+	GasAfter     uint64
+	SelfGas      uint64
+	InclusiveGas uint64
+	Children     []*GasTraceFrame
+
+	// PeakMemoryPages is the linear memory of the contract at the end of the call, for the
+	// contract call frames only
+	PeakMemoryPages uint32
+}
+
+// CallMemoryUsage is the linear memory used by a contract call, in pages of WASMPageSize bytes;
+// the memory of an instance never shrinks, so the pages at the end of the call are the peak. The
+// initial pages are those declared by the module, even for a warm instance keeping the memory grown
+// by its previous executions, so the grown pages are those charged to the call.
+type CallMemoryUsage struct {
+	SCAddress    []byte
+	CodeHash     []byte
+	Function     string
+	InitialPages uint32
+	PeakPages    uint32
+}
+
+// GrownPages returns the number of pages the call added to the memory of the instance
+func (usage *CallMemoryUsage) GrownPages() uint32 {
+	if usage.PeakPages < usage.InitialPages {
+		return 0
+	}
+
+	return usage.PeakPages - usage.InitialPages
+}
+
+// MemoryUsage holds the linear memory used during an execution, per contract call, in the
+// order the calls finished, and per instance, as the peak pages by code hash
+type MemoryUsage struct {
+	Calls               []*CallMemoryUsage
+	PeakPagesByCodeHash map[string]uint32
+}
+
+// NewMemoryUsage creates an empty MemoryUsage
+func NewMemoryUsage() *MemoryUsage {
+	return &MemoryUsage{
+		Calls:               make([]*CallMemoryUsage, 0),
+		PeakPagesByCodeHash: make(map[string]uint32),
+	}
+}
+
+// AddCall records the memory used by a contract call
+func (usage *MemoryUsage) AddCall(call *CallMemoryUsage) {
+	usage.Calls = append(usage.Calls, call)
+
+	codeHash := string(call.CodeHash)
+	if call.PeakPages > usage.PeakPagesByCodeHash[codeHash] {
+		usage.PeakPagesByCodeHash[codeHash] = call.PeakPages
+	}
+}
+
+// PeakPages returns the largest memory reached by an instance during the execution
+func (usage *MemoryUsage) PeakPages() uint32 {
+	peakPages := uint32(0)
+	for _, pages := range usage.PeakPagesByCodeHash {
+		if pages > peakPages {
+			peakPages = pages
+		}
+	}
+
+	return peakPages
+}
+
+// GasEstimate holds the minimal gas for which a call succeeds, broken down into the cost of
+// preparing the contract, the gas used by the execution and the gas locked for async callbacks
+type GasEstimate struct {
+	GasProvided           uint64
+	PrepareCost           uint64
+	ExecutionGas          uint64
+	GasLockedForCallbacks uint64
+	VMOutput              *vmcommon.VMOutput
+}
+
 // GetDestination returns the destination of an async call
 func (ac *AsyncGeneratedCall) GetDestination() []byte {
 	return ac.Destination
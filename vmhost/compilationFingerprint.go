@@ -0,0 +1,32 @@
+package vmhost
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/multiversx/mx-chain-vm-v1_4-go/wasmer"
+)
+
+// ComputeCompilationFingerprint identifies the conditions a contract is compiled under: the opcode costs
+// compiled into the metering and the format of the code produced by the linked Wasmer library
+func ComputeCompilationFingerprint(opcodeCosts *[wasmer.OpcodeCount]uint32) []byte {
+	data := make([]byte, 4+4*wasmer.OpcodeCount)
+	binary.BigEndian.PutUint32(data, wasmer.CompiledCodeFormatVersion)
+	for i, cost := range opcodeCosts {
+		binary.BigEndian.PutUint32(data[4+4*i:], cost)
+	}
+
+	fingerprint := sha256.Sum256(data)
+	return fingerprint[:]
+}
+
+// CompiledCodeKey is the key of the compiled code saved through the blockchain hook: the code hash
+// followed by the fingerprint it was compiled under, so that a compiled code is only found under the
+// opcode costs it was produced with, while the saved payload stays the bare compiled code. The entries
+// saved by the versions keying them on the code hash alone are not looked up anymore, the contracts
+// being compiled again once, and remain valid for those versions in case of a rollback.
+func CompiledCodeKey(codeHash []byte, fingerprint []byte) []byte {
+	key := make([]byte, 0, len(codeHash)+len(fingerprint))
+	key = append(key, codeHash...)
+	return append(key, fingerprint...)
+}
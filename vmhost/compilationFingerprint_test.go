@@ -0,0 +1,36 @@
+package vmhost
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-v1_4-go/wasmer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeCompilationFingerprint(t *testing.T) {
+	var opcodeCosts [wasmer.OpcodeCount]uint32
+	for i := range opcodeCosts {
+		opcodeCosts[i] = uint32(i)
+	}
+	sameOpcodeCosts := opcodeCosts
+	otherOpcodeCosts := opcodeCosts
+	otherOpcodeCosts[wasmer.OpcodeI32Add]++
+
+	fingerprint := ComputeCompilationFingerprint(&opcodeCosts)
+	require.Len(t, fingerprint, 32)
+	require.Equal(t, fingerprint, ComputeCompilationFingerprint(&sameOpcodeCosts))
+	require.NotEqual(t, fingerprint, ComputeCompilationFingerprint(&otherOpcodeCosts))
+}
+
+func TestCompiledCodeKey(t *testing.T) {
+	var opcodeCosts [wasmer.OpcodeCount]uint32
+	fingerprint := ComputeCompilationFingerprint(&opcodeCosts)
+	opcodeCosts[wasmer.OpcodeI32Add] = 1
+	otherFingerprint := ComputeCompilationFingerprint(&opcodeCosts)
+
+	codeHash := []byte("codeHash")
+	key := CompiledCodeKey(codeHash, fingerprint)
+	require.Equal(t, append([]byte("codeHash"), fingerprint...), key)
+	require.Equal(t, []byte("codeHash"), codeHash)
+	require.NotEqual(t, key, CompiledCodeKey(codeHash, otherFingerprint))
+}
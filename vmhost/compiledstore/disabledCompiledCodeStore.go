@@ -0,0 +1,28 @@
+package compiledstore
+
+import "github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+
+var _ vmhost.CompiledCodeStore = (*disabledCompiledCodeStore)(nil)
+
+type disabledCompiledCodeStore struct {
+}
+
+// NewDisabledCompiledCodeStore creates a compiled code store which keeps nothing
+func NewDisabledCompiledCodeStore() *disabledCompiledCodeStore {
+	return &disabledCompiledCodeStore{}
+}
+
+// GetCompiledCode finds nothing
+func (store *disabledCompiledCodeStore) GetCompiledCode(_ []byte, _ []byte) ([]byte, bool) {
+	return nil, false
+}
+
+// SaveCompiledCode does nothing
+func (store *disabledCompiledCodeStore) SaveCompiledCode(_ []byte, _ []byte, _ []byte) error {
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (store *disabledCompiledCodeStore) IsInterfaceNil() bool {
+	return store == nil
+}
@@ -0,0 +1,271 @@
+package compiledstore
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+)
+
+var log = logger.GetOrCreate("vm/compiledstore")
+
+var _ vmhost.CompiledCodeStore = (*fileCompiledCodeStore)(nil)
+
+const checksumLength = sha256.Size
+const entryFileSuffix = ".compiled"
+
+// entryFileName matches the entry files, named by the hex encoded code hash and fingerprint
+var entryFileName = regexp.MustCompile(`^[0-9a-f]+_[0-9a-f]+\` + entryFileSuffix + `$`)
+
+// ArgsFileCompiledCodeStore holds the arguments needed to create a fileCompiledCodeStore
+type ArgsFileCompiledCodeStore struct {
+	Directory    string
+	MaxEntrySize uint64
+	MaxTotalSize uint64
+}
+
+type storeEntry struct {
+	size    uint64
+	lastUse time.Time
+}
+
+// fileCompiledCodeStore keeps each compiled code in a file of the directory, preceded by a checksum
+// which also covers the code hash and the fingerprint; the entries failing the check are removed.
+// When the total size would exceed the limit, the least recently used entries are removed first.
+type fileCompiledCodeStore struct {
+	mut          sync.Mutex
+	directory    string
+	maxEntrySize uint64
+	maxTotalSize uint64
+	entries      map[string]*storeEntry
+	totalSize    uint64
+}
+
+// NewFileCompiledCodeStore creates a new fileCompiledCodeStore, indexing the entries already in the directory
+func NewFileCompiledCodeStore(args ArgsFileCompiledCodeStore) (*fileCompiledCodeStore, error) {
+	if len(args.Directory) == 0 {
+		return nil, fmt.Errorf("%w: empty directory", vmhost.ErrInvalidCompiledCodeStoreConfig)
+	}
+	if args.MaxEntrySize == 0 || args.MaxEntrySize > args.MaxTotalSize {
+		return nil, fmt.Errorf("%w: max entry size %d, max total size %d",
+			vmhost.ErrInvalidCompiledCodeStoreConfig, args.MaxEntrySize, args.MaxTotalSize)
+	}
+
+	err := os.MkdirAll(args.Directory, os.ModePerm)
+	if err != nil {
+		return nil, err
+	}
+
+	store := &fileCompiledCodeStore{
+		directory:    args.Directory,
+		maxEntrySize: args.MaxEntrySize,
+		maxTotalSize: args.MaxTotalSize,
+		entries:      make(map[string]*storeEntry),
+	}
+
+	err = store.loadEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (store *fileCompiledCodeStore) loadEntries() error {
+	dirEntries, err := os.ReadDir(store.directory)
+	if err != nil {
+		return err
+	}
+
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() {
+			continue
+		}
+		if filepath.Ext(dirEntry.Name()) == ".tmp" {
+			// left by an interrupted write
+			_ = os.Remove(filepath.Join(store.directory, dirEntry.Name()))
+			continue
+		}
+		if !entryFileName.MatchString(dirEntry.Name()) {
+			continue
+		}
+
+		info, err := dirEntry.Info()
+		if err != nil {
+			return err
+		}
+
+		store.entries[dirEntry.Name()] = &storeEntry{
+			size:    uint64(info.Size()),
+			lastUse: info.ModTime(),
+		}
+		store.totalSize += uint64(info.Size())
+	}
+
+	store.evictUntilFits(0)
+	return nil
+}
+
+// GetCompiledCode returns the compiled code of the code hash, if stored under the same fingerprint and intact
+func (store *fileCompiledCodeStore) GetCompiledCode(codeHash []byte, fingerprint []byte) ([]byte, bool) {
+	store.mut.Lock()
+	defer store.mut.Unlock()
+
+	fileName := makeEntryFileName(codeHash, fingerprint)
+	entry, ok := store.entries[fileName]
+	if !ok {
+		return nil, false
+	}
+
+	contents, err := os.ReadFile(filepath.Join(store.directory, fileName))
+	if err != nil {
+		log.Debug("read compiled code", "file", fileName, "error", err)
+		store.removeEntry(fileName)
+		return nil, false
+	}
+
+	compiledCode, err := verifyEntryContents(contents, codeHash, fingerprint)
+	if err != nil {
+		log.Warn("removing compiled code", "file", fileName, "error", err)
+		store.removeEntry(fileName)
+		return nil, false
+	}
+
+	entry.lastUse = time.Now()
+	_ = os.Chtimes(filepath.Join(store.directory, fileName), entry.lastUse, entry.lastUse)
+
+	return compiledCode, true
+}
+
+// SaveCompiledCode writes the compiled code of the code hash, removing the least recently used entries if needed
+func (store *fileCompiledCodeStore) SaveCompiledCode(codeHash []byte, fingerprint []byte, compiledCode []byte) error {
+	entrySize := uint64(checksumLength + len(compiledCode))
+	if entrySize > store.maxEntrySize {
+		return fmt.Errorf("%w: %d bytes", vmhost.ErrCompiledCodeTooLarge, entrySize)
+	}
+
+	store.mut.Lock()
+	defer store.mut.Unlock()
+
+	fileName := makeEntryFileName(codeHash, fingerprint)
+	_, exists := store.entries[fileName]
+	if exists {
+		store.removeEntry(fileName)
+	}
+	store.evictUntilFits(entrySize)
+
+	contents := make([]byte, 0, entrySize)
+	contents = append(contents, computeChecksum(codeHash, fingerprint, compiledCode)...)
+	contents = append(contents, compiledCode...)
+
+	err := store.writeEntryFile(fileName, contents)
+	if err != nil {
+		return err
+	}
+
+	store.entries[fileName] = &storeEntry{
+		size:    entrySize,
+		lastUse: time.Now(),
+	}
+	store.totalSize += entrySize
+
+	return nil
+}
+
+// writeEntryFile writes a temporary file first, so that an interrupted write never leaves a partial entry
+func (store *fileCompiledCodeStore) writeEntryFile(fileName string, contents []byte) error {
+	tempFile, err := os.CreateTemp(store.directory, fileName+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	_, err = tempFile.Write(contents)
+	errClose := tempFile.Close()
+	if err == nil {
+		err = errClose
+	}
+	if err != nil {
+		_ = os.Remove(tempFile.Name())
+		return err
+	}
+
+	return os.Rename(tempFile.Name(), filepath.Join(store.directory, fileName))
+}
+
+func (store *fileCompiledCodeStore) evictUntilFits(entrySize uint64) {
+	for store.totalSize+entrySize > store.maxTotalSize && len(store.entries) > 0 {
+		oldestFileName := ""
+		var oldestEntry *storeEntry
+		for fileName, entry := range store.entries {
+			if oldestEntry == nil || entry.lastUse.Before(oldestEntry.lastUse) {
+				oldestFileName = fileName
+				oldestEntry = entry
+			}
+		}
+
+		log.Trace("evicting compiled code", "file", oldestFileName)
+		store.removeEntry(oldestFileName)
+	}
+}
+
+func (store *fileCompiledCodeStore) removeEntry(fileName string) {
+	entry, ok := store.entries[fileName]
+	if !ok {
+		return
+	}
+
+	delete(store.entries, fileName)
+	store.totalSize -= entry.size
+
+	err := os.Remove(filepath.Join(store.directory, fileName))
+	if err != nil && !os.IsNotExist(err) {
+		log.Debug("remove compiled code", "file", fileName, "error", err)
+	}
+}
+
+// TotalSize returns the size in bytes of all the stored entries
+func (store *fileCompiledCodeStore) TotalSize() uint64 {
+	store.mut.Lock()
+	defer store.mut.Unlock()
+
+	return store.totalSize
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (store *fileCompiledCodeStore) IsInterfaceNil() bool {
+	return store == nil
+}
+
+func makeEntryFileName(codeHash []byte, fingerprint []byte) string {
+	return hex.EncodeToString(codeHash) + "_" + hex.EncodeToString(fingerprint) + entryFileSuffix
+}
+
+func computeChecksum(codeHash []byte, fingerprint []byte, compiledCode []byte) []byte {
+	hasher := sha256.New()
+	_, _ = hasher.Write(codeHash)
+	_, _ = hasher.Write(fingerprint)
+	_, _ = hasher.Write(compiledCode)
+	return hasher.Sum(nil)
+}
+
+func verifyEntryContents(contents []byte, codeHash []byte, fingerprint []byte) ([]byte, error) {
+	if len(contents) < checksumLength {
+		return nil, vmhost.ErrCorruptedCompiledCode
+	}
+
+	checksum := contents[:checksumLength]
+	compiledCode := contents[checksumLength:]
+	if !bytes.Equal(checksum, computeChecksum(codeHash, fingerprint, compiledCode)) {
+		return nil, vmhost.ErrCorruptedCompiledCode
+	}
+
+	return compiledCode, nil
+}
@@ -0,0 +1,129 @@
+package compiledstore
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/stretchr/testify/require"
+)
+
+var fingerprint = []byte{1, 2, 3, 4}
+var otherFingerprint = []byte{5, 6, 7, 8}
+
+func createTestStore(t *testing.T, directory string, maxTotalSize uint64) *fileCompiledCodeStore {
+	store, err := NewFileCompiledCodeStore(ArgsFileCompiledCodeStore{
+		Directory:    directory,
+		MaxEntrySize: 1024,
+		MaxTotalSize: maxTotalSize,
+	})
+	require.Nil(t, err)
+	require.False(t, check.IfNil(store))
+
+	return store
+}
+
+func TestNewFileCompiledCodeStore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty directory should error", func(t *testing.T) {
+		t.Parallel()
+
+		store, err := NewFileCompiledCodeStore(ArgsFileCompiledCodeStore{MaxEntrySize: 1, MaxTotalSize: 1})
+		require.True(t, errors.Is(err, vmhost.ErrInvalidCompiledCodeStoreConfig))
+		require.True(t, check.IfNil(store))
+	})
+	t.Run("invalid size limits should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewFileCompiledCodeStore(ArgsFileCompiledCodeStore{Directory: t.TempDir(), MaxTotalSize: 1})
+		require.True(t, errors.Is(err, vmhost.ErrInvalidCompiledCodeStoreConfig))
+
+		_, err = NewFileCompiledCodeStore(ArgsFileCompiledCodeStore{Directory: t.TempDir(), MaxEntrySize: 2, MaxTotalSize: 1})
+		require.True(t, errors.Is(err, vmhost.ErrInvalidCompiledCodeStoreConfig))
+	})
+}
+
+func TestFileCompiledCodeStore_SaveAndGet(t *testing.T) {
+	t.Parallel()
+
+	directory := t.TempDir()
+	store := createTestStore(t, directory, 4096)
+
+	_, found := store.GetCompiledCode([]byte("hash"), fingerprint)
+	require.False(t, found)
+
+	err := store.SaveCompiledCode([]byte("hash"), fingerprint, []byte("compiled"))
+	require.Nil(t, err)
+
+	compiledCode, found := store.GetCompiledCode([]byte("hash"), fingerprint)
+	require.True(t, found)
+	require.Equal(t, []byte("compiled"), compiledCode)
+
+	_, found = store.GetCompiledCode([]byte("hash"), otherFingerprint)
+	require.False(t, found)
+
+	// the entries persist across the store instances
+	reopenedStore := createTestStore(t, directory, 4096)
+	compiledCode, found = reopenedStore.GetCompiledCode([]byte("hash"), fingerprint)
+	require.True(t, found)
+	require.Equal(t, []byte("compiled"), compiledCode)
+	require.Equal(t, uint64(checksumLength+len("compiled")), reopenedStore.TotalSize())
+}
+
+func TestFileCompiledCodeStore_CorruptedEntryIsRemoved(t *testing.T) {
+	t.Parallel()
+
+	directory := t.TempDir()
+	store := createTestStore(t, directory, 4096)
+
+	err := store.SaveCompiledCode([]byte("hash"), fingerprint, []byte("compiled"))
+	require.Nil(t, err)
+
+	filePath := filepath.Join(directory, makeEntryFileName([]byte("hash"), fingerprint))
+	contents, err := os.ReadFile(filePath)
+	require.Nil(t, err)
+	contents[len(contents)-1] ^= 0xFF
+	err = os.WriteFile(filePath, contents, 0644)
+	require.Nil(t, err)
+
+	_, found := store.GetCompiledCode([]byte("hash"), fingerprint)
+	require.False(t, found)
+	require.Equal(t, uint64(0), store.TotalSize())
+
+	_, err = os.Stat(filePath)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestFileCompiledCodeStore_SizeLimits(t *testing.T) {
+	t.Parallel()
+
+	entrySize := uint64(checksumLength + 100)
+	store, err := NewFileCompiledCodeStore(ArgsFileCompiledCodeStore{
+		Directory:    t.TempDir(),
+		MaxEntrySize: entrySize,
+		MaxTotalSize: 2 * entrySize,
+	})
+	require.Nil(t, err)
+
+	err = store.SaveCompiledCode([]byte("large"), fingerprint, make([]byte, 101))
+	require.True(t, errors.Is(err, vmhost.ErrCompiledCodeTooLarge))
+
+	require.Nil(t, store.SaveCompiledCode([]byte("first"), fingerprint, make([]byte, 100)))
+	require.Nil(t, store.SaveCompiledCode([]byte("second"), fingerprint, make([]byte, 100)))
+	store.entries[makeEntryFileName([]byte("first"), fingerprint)].lastUse = store.entries[makeEntryFileName([]byte("second"), fingerprint)].lastUse.Add(1)
+
+	// "second" is the least recently used entry
+	require.Nil(t, store.SaveCompiledCode([]byte("third"), fingerprint, make([]byte, 100)))
+	require.Equal(t, 2*entrySize, store.TotalSize())
+
+	_, found := store.GetCompiledCode([]byte("second"), fingerprint)
+	require.False(t, found)
+	_, found = store.GetCompiledCode([]byte("first"), fingerprint)
+	require.True(t, found)
+	_, found = store.GetCompiledCode([]byte("third"), fingerprint)
+	require.True(t, found)
+}
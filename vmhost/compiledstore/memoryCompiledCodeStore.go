@@ -0,0 +1,56 @@
+package compiledstore
+
+import (
+	"sync"
+
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+)
+
+var _ vmhost.CompiledCodeStore = (*memoryCompiledCodeStore)(nil)
+
+// memoryCompiledCodeStore keeps the compiled codes in memory, for the lifetime of the process; it is
+// safe to share between hosts. The compiled codes returned are shared as well and must not be modified.
+type memoryCompiledCodeStore struct {
+	mut     sync.RWMutex
+	entries map[string][]byte
+}
+
+// NewMemoryCompiledCodeStore creates a new, empty memoryCompiledCodeStore
+func NewMemoryCompiledCodeStore() *memoryCompiledCodeStore {
+	return &memoryCompiledCodeStore{
+		entries: make(map[string][]byte),
+	}
+}
+
+// GetCompiledCode returns the compiled code saved for the code hash and fingerprint
+func (store *memoryCompiledCodeStore) GetCompiledCode(codeHash []byte, fingerprint []byte) ([]byte, bool) {
+	store.mut.RLock()
+	compiledCode, found := store.entries[makeEntryFileName(codeHash, fingerprint)]
+	store.mut.RUnlock()
+
+	return compiledCode, found
+}
+
+// SaveCompiledCode keeps a copy of the compiled code for the code hash and fingerprint
+func (store *memoryCompiledCodeStore) SaveCompiledCode(codeHash []byte, fingerprint []byte, compiledCode []byte) error {
+	entry := append([]byte(nil), compiledCode...)
+
+	store.mut.Lock()
+	store.entries[makeEntryFileName(codeHash, fingerprint)] = entry
+	store.mut.Unlock()
+
+	return nil
+}
+
+// Len returns the number of compiled codes kept
+func (store *memoryCompiledCodeStore) Len() int {
+	store.mut.RLock()
+	defer store.mut.RUnlock()
+
+	return len(store.entries)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (store *memoryCompiledCodeStore) IsInterfaceNil() bool {
+	return store == nil
+}
@@ -0,0 +1,29 @@
+package compiledstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCompiledCodeStore_SaveAndGet(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryCompiledCodeStore()
+
+	_, found := store.GetCompiledCode([]byte("hash"), fingerprint)
+	require.False(t, found)
+
+	compiledCode := []byte("compiled")
+	err := store.SaveCompiledCode([]byte("hash"), fingerprint, compiledCode)
+	require.Nil(t, err)
+	compiledCode[0] = 'C'
+
+	stored, found := store.GetCompiledCode([]byte("hash"), fingerprint)
+	require.True(t, found)
+	require.Equal(t, []byte("compiled"), stored)
+
+	_, found = store.GetCompiledCode([]byte("hash"), otherFingerprint)
+	require.False(t, found)
+	require.Equal(t, 1, store.Len())
+}
@@ -0,0 +1,174 @@
+package contexts
+
+import (
+	"github.com/multiversx/mx-chain-vm-v1_4-go/math"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+)
+
+// callTreeGasTracer records the gas used during execution as a tree of frames, while still
+// maintaining the flat gas trace of the embedded gasTracer
+type callTreeGasTracer struct {
+	*gasTracer
+	gasLeft func() uint64
+	roots   []*vmhost.GasTraceFrame
+	stack   []*vmhost.GasTraceFrame
+}
+
+// NewCallTreeGasTracer creates a new callTreeGasTracer, which reads the gas left at the frame
+// boundaries from the provided function
+func NewCallTreeGasTracer(gasLeft func() uint64) *callTreeGasTracer {
+	return &callTreeGasTracer{
+		gasTracer: NewEnabledGasTracer(),
+		gasLeft:   gasLeft,
+		roots:     make([]*vmhost.GasTraceFrame, 0),
+		stack:     make([]*vmhost.GasTraceFrame, 0),
+	}
+}
+
+// BeginTrace opens a frame for the EI function invoked by the contract, closing the frame of
+// the previously invoked EI function
+func (tracer *callTreeGasTracer) BeginTrace(scAddress string, functionName string) {
+	tracer.gasTracer.BeginTrace(scAddress, functionName)
+
+	tracer.finishEIFrame()
+	tracer.pushFrame(&vmhost.GasTraceFrame{
+		Kind:      vmhost.EIFunctionFrame,
+		SCAddress: []byte(scAddress),
+		Name:      functionName,
+		GasBefore: tracer.gasLeft(),
+	})
+}
+
+// AddToCurrentTrace adds the usedGas to the frame of the EI function currently traced
+func (tracer *callTreeGasTracer) AddToCurrentTrace(usedGas uint64) {
+	tracer.gasTracer.AddToCurrentTrace(usedGas)
+
+	frame := tracer.currentEIFrame()
+	if frame != nil {
+		frame.SelfGas += usedGas
+	}
+}
+
+// AddTracedGas records a complete EI function frame, for gas which was already used
+func (tracer *callTreeGasTracer) AddTracedGas(scAddress string, functionName string, usedGas uint64) {
+	tracer.gasTracer.AddTracedGas(scAddress, functionName, usedGas)
+
+	tracer.finishEIFrame()
+	gasAfter := tracer.gasLeft()
+	tracer.appendFrame(&vmhost.GasTraceFrame{
+		Kind:         vmhost.EIFunctionFrame,
+		SCAddress:    []byte(scAddress),
+		Name:         functionName,
+		GasBefore:    math.AddUint64(gasAfter, usedGas),
+		GasAfter:     gasAfter,
+		SelfGas:      usedGas,
+		InclusiveGas: usedGas,
+	})
+}
+
+// BeginFrame opens a frame for a contract call, builtin function or async callback, nested
+// under the frame currently open
+func (tracer *callTreeGasTracer) BeginFrame(kind vmhost.GasTraceFrameKind, scAddress string, name string) {
+	tracer.pushFrame(&vmhost.GasTraceFrame{
+		Kind:      kind,
+		SCAddress: []byte(scAddress),
+		Name:      name,
+		GasBefore: tracer.gasLeft(),
+	})
+}
+
+// EndFrame closes the frame opened by the last BeginFrame, together with the EI function
+// frames left open inside it
+func (tracer *callTreeGasTracer) EndFrame() {
+	for tracer.currentEIFrame() != nil {
+		tracer.finishEIFrame()
+	}
+
+	frame := tracer.popFrame()
+	if frame == nil {
+		return
+	}
+
+	frame.GasAfter = tracer.gasLeft()
+	frame.InclusiveGas = math.SubUint64(frame.GasBefore, frame.GasAfter)
+	frame.SelfGas = math.SubUint64(frame.InclusiveGas, childrenInclusiveGas(frame))
+}
+
+// SetFrameMemoryPages records the memory pages of the innermost frame which is not an EI function
+func (tracer *callTreeGasTracer) SetFrameMemoryPages(pages uint32) {
+	for i := len(tracer.stack) - 1; i >= 0; i-- {
+		if tracer.stack[i].Kind != vmhost.EIFunctionFrame {
+			tracer.stack[i].PeakMemoryPages = pages
+			return
+		}
+	}
+}
+
+// GetCallTree returns the root frames recorded so far
+func (tracer *callTreeGasTracer) GetCallTree() []*vmhost.GasTraceFrame {
+	return tracer.roots
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (tracer *callTreeGasTracer) IsInterfaceNil() bool {
+	return tracer == nil
+}
+
+func (tracer *callTreeGasTracer) currentEIFrame() *vmhost.GasTraceFrame {
+	if len(tracer.stack) == 0 {
+		return nil
+	}
+
+	frame := tracer.stack[len(tracer.stack)-1]
+	if frame.Kind != vmhost.EIFunctionFrame {
+		return nil
+	}
+
+	return frame
+}
+
+func (tracer *callTreeGasTracer) finishEIFrame() {
+	frame := tracer.currentEIFrame()
+	if frame == nil {
+		return
+	}
+
+	tracer.popFrame()
+	frame.InclusiveGas = math.AddUint64(frame.SelfGas, childrenInclusiveGas(frame))
+	frame.GasAfter = math.SubUint64(frame.GasBefore, frame.InclusiveGas)
+}
+
+func (tracer *callTreeGasTracer) pushFrame(frame *vmhost.GasTraceFrame) {
+	tracer.appendFrame(frame)
+	tracer.stack = append(tracer.stack, frame)
+}
+
+func (tracer *callTreeGasTracer) appendFrame(frame *vmhost.GasTraceFrame) {
+	if len(tracer.stack) == 0 {
+		tracer.roots = append(tracer.roots, frame)
+		return
+	}
+
+	parent := tracer.stack[len(tracer.stack)-1]
+	parent.Children = append(parent.Children, frame)
+}
+
+func (tracer *callTreeGasTracer) popFrame() *vmhost.GasTraceFrame {
+	if len(tracer.stack) == 0 {
+		return nil
+	}
+
+	frame := tracer.stack[len(tracer.stack)-1]
+	tracer.stack = tracer.stack[:len(tracer.stack)-1]
+
+	return frame
+}
+
+func childrenInclusiveGas(frame *vmhost.GasTraceFrame) uint64 {
+	total := uint64(0)
+	for _, child := range frame.Children {
+		total = math.AddUint64(total, child.InclusiveGas)
+	}
+
+	return total
+}
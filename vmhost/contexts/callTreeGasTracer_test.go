@@ -0,0 +1,104 @@
+package contexts
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallTreeGasTracer_NestedFrames(t *testing.T) {
+	gasLeft := uint64(1000)
+	tracer := NewCallTreeGasTracer(func() uint64 { return gasLeft })
+	require.False(t, tracer.IsInterfaceNil())
+
+	tracer.BeginFrame(vmhost.ContractCallFrame, "caller", "main")
+	tracer.BeginTrace("caller", "getArgument")
+	tracer.AddToCurrentTrace(10)
+	gasLeft -= 10
+
+	tracer.BeginTrace("caller", "executeOnDestContext")
+	tracer.AddToCurrentTrace(100)
+	gasLeft -= 100
+
+	tracer.BeginFrame(vmhost.ContractCallFrame, "callee", "inner")
+	tracer.BeginTrace("callee", "finish")
+	tracer.AddToCurrentTrace(5)
+	gasLeft -= 50
+	tracer.EndFrame()
+
+	gasLeft -= 200
+	gasLeft -= 40
+	tracer.AddTracedGas("caller", "transferValue", 40)
+	tracer.EndFrame()
+
+	roots := tracer.GetCallTree()
+	require.Len(t, roots, 1)
+
+	main := roots[0]
+	require.Equal(t, uint64(1000), main.GasBefore)
+	require.Equal(t, uint64(600), main.GasAfter)
+	require.Equal(t, uint64(400), main.InclusiveGas)
+	require.Equal(t, uint64(200), main.SelfGas)
+	require.Len(t, main.Children, 3)
+
+	getArgument := main.Children[0]
+	require.Equal(t, vmhost.EIFunctionFrame, getArgument.Kind)
+	require.Equal(t, uint64(10), getArgument.SelfGas)
+	require.Equal(t, uint64(10), getArgument.InclusiveGas)
+	require.Equal(t, uint64(1000), getArgument.GasBefore)
+	require.Equal(t, uint64(990), getArgument.GasAfter)
+
+	executeOnDestContext := main.Children[1]
+	require.Equal(t, uint64(100), executeOnDestContext.SelfGas)
+	require.Equal(t, uint64(150), executeOnDestContext.InclusiveGas)
+	require.Len(t, executeOnDestContext.Children, 1)
+
+	inner := executeOnDestContext.Children[0]
+	require.Equal(t, []byte("callee"), inner.SCAddress)
+	require.Equal(t, uint64(50), inner.InclusiveGas)
+	require.Equal(t, uint64(45), inner.SelfGas)
+	require.Len(t, inner.Children, 1)
+	require.Equal(t, uint64(5), inner.Children[0].InclusiveGas)
+
+	transferValue := main.Children[2]
+	require.Equal(t, uint64(40), transferValue.SelfGas)
+	require.Equal(t, uint64(640), transferValue.GasBefore)
+
+	gasTrace := tracer.GetGasTrace()
+	require.Equal(t, uint64(100), gasTrace["caller"]["executeOnDestContext"][0])
+	require.Equal(t, uint64(5), gasTrace["callee"]["finish"][0])
+}
+
+func TestCallTreeGasTracer_FrameMemoryPages(t *testing.T) {
+	tracer := NewCallTreeGasTracer(func() uint64 { return 1000 })
+
+	tracer.BeginFrame(vmhost.ContractCallFrame, "caller", "main")
+	tracer.BeginFrame(vmhost.ContractCallFrame, "callee", "inner")
+	tracer.BeginTrace("callee", "mBufferAppend")
+	tracer.SetFrameMemoryPages(4)
+	tracer.EndFrame()
+	tracer.SetFrameMemoryPages(2)
+	tracer.EndFrame()
+
+	main := tracer.GetCallTree()[0]
+	require.Equal(t, uint32(2), main.PeakMemoryPages)
+	require.Equal(t, uint32(4), main.Children[0].PeakMemoryPages)
+	require.Equal(t, uint32(0), main.Children[0].Children[0].PeakMemoryPages)
+}
+
+func TestCallTreeGasTracer_UnbalancedEndFrame(t *testing.T) {
+	tracer := NewCallTreeGasTracer(func() uint64 { return 0 })
+
+	tracer.EndFrame()
+	tracer.AddToCurrentTrace(10)
+	require.Len(t, tracer.GetCallTree(), 0)
+}
+
+func TestDisabledGasTracer_CallTree(t *testing.T) {
+	tracer := NewDisabledGasTracer()
+
+	tracer.BeginFrame(vmhost.ContractCallFrame, "caller", "main")
+	tracer.EndFrame()
+	require.Nil(t, tracer.GetCallTree())
+}
@@ -0,0 +1,148 @@
+package contexts
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+)
+
+// coverageTracker holds the contract functions and EI functions called, grouped by code hash
+type coverageTracker struct {
+	mutCoverage sync.RWMutex
+	coverage    map[string]*vmhost.ContractCoverage
+}
+
+// NewEnabledCoverageTracker creates a new coverageTracker
+func NewEnabledCoverageTracker() *coverageTracker {
+	return &coverageTracker{
+		coverage: make(map[string]*vmhost.ContractCoverage),
+	}
+}
+
+// NewDisabledCoverageTracker creates a new disabledCoverageTracker
+func NewDisabledCoverageTracker() *disabledCoverageTracker {
+	return &disabledCoverageTracker{}
+}
+
+// IsContractTracked returns true if the exported and imported functions of the code have already been recorded
+func (ct *coverageTracker) IsContractTracked(codeHash []byte) bool {
+	ct.mutCoverage.RLock()
+	defer ct.mutCoverage.RUnlock()
+
+	contractCoverage, ok := ct.coverage[string(codeHash)]
+	return ok && contractCoverage.ExportedFunctions != nil
+}
+
+// TrackContract records the functions exported by the code and the EI functions it imports
+func (ct *coverageTracker) TrackContract(codeHash []byte, exportedFunctions []string, importedEIFunctions []string) {
+	ct.mutCoverage.Lock()
+	defer ct.mutCoverage.Unlock()
+
+	contractCoverage := ct.getOrCreateContractCoverage(codeHash)
+	contractCoverage.ExportedFunctions = sortedCopy(exportedFunctions)
+	contractCoverage.ImportedEIFunctions = sortedCopy(importedEIFunctions)
+}
+
+// TrackFunctionCall counts a call of a function exported by the code
+func (ct *coverageTracker) TrackFunctionCall(codeHash []byte, functionName string) {
+	ct.mutCoverage.Lock()
+	defer ct.mutCoverage.Unlock()
+
+	ct.getOrCreateContractCoverage(codeHash).FunctionCalls[functionName]++
+}
+
+// TrackEICall counts a call of an EI function made by the code
+func (ct *coverageTracker) TrackEICall(codeHash []byte, eiFunctionName string) {
+	ct.mutCoverage.Lock()
+	defer ct.mutCoverage.Unlock()
+
+	ct.getOrCreateContractCoverage(codeHash).EICalls[eiFunctionName]++
+}
+
+// GetCoverage returns a copy of the coverage collected so far, keyed by code hash
+func (ct *coverageTracker) GetCoverage() map[string]*vmhost.ContractCoverage {
+	ct.mutCoverage.RLock()
+	defer ct.mutCoverage.RUnlock()
+
+	result := make(map[string]*vmhost.ContractCoverage, len(ct.coverage))
+	for codeHash, contractCoverage := range ct.coverage {
+		result[codeHash] = &vmhost.ContractCoverage{
+			CodeHash:            contractCoverage.CodeHash,
+			ExportedFunctions:   sortedCopy(contractCoverage.ExportedFunctions),
+			ImportedEIFunctions: sortedCopy(contractCoverage.ImportedEIFunctions),
+			FunctionCalls:       copyCallCounts(contractCoverage.FunctionCalls),
+			EICalls:             copyCallCounts(contractCoverage.EICalls),
+		}
+	}
+
+	return result
+}
+
+func (ct *coverageTracker) getOrCreateContractCoverage(codeHash []byte) *vmhost.ContractCoverage {
+	contractCoverage, ok := ct.coverage[string(codeHash)]
+	if !ok {
+		contractCoverage = &vmhost.ContractCoverage{
+			CodeHash:      codeHash,
+			FunctionCalls: make(map[string]uint64),
+			EICalls:       make(map[string]uint64),
+		}
+		ct.coverage[string(codeHash)] = contractCoverage
+	}
+
+	return contractCoverage
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ct *coverageTracker) IsInterfaceNil() bool {
+	return ct == nil
+}
+
+func sortedCopy(names []string) []string {
+	if names == nil {
+		return nil
+	}
+
+	result := make([]string, len(names))
+	copy(result, names)
+	sort.Strings(result)
+	return result
+}
+
+func copyCallCounts(callCounts map[string]uint64) map[string]uint64 {
+	result := make(map[string]uint64, len(callCounts))
+	for name, count := range callCounts {
+		result[name] = count
+	}
+	return result
+}
+
+type disabledCoverageTracker struct {
+}
+
+// IsContractTracked returns true, so that the contract details are never gathered
+func (dct *disabledCoverageTracker) IsContractTracked(_ []byte) bool {
+	return true
+}
+
+// TrackContract does nothing
+func (dct *disabledCoverageTracker) TrackContract(_ []byte, _ []string, _ []string) {
+}
+
+// TrackFunctionCall does nothing
+func (dct *disabledCoverageTracker) TrackFunctionCall(_ []byte, _ string) {
+}
+
+// TrackEICall does nothing
+func (dct *disabledCoverageTracker) TrackEICall(_ []byte, _ string) {
+}
+
+// GetCoverage returns nil
+func (dct *disabledCoverageTracker) GetCoverage() map[string]*vmhost.ContractCoverage {
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (dct *disabledCoverageTracker) IsInterfaceNil() bool {
+	return dct == nil
+}
@@ -0,0 +1,52 @@
+package contexts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoverageTracker(t *testing.T) {
+	coverageTracker := NewEnabledCoverageTracker()
+	require.False(t, coverageTracker.IsInterfaceNil())
+	require.Equal(t, 0, len(coverageTracker.GetCoverage()))
+
+	codeHash1 := []byte("codeHash1")
+	codeHash2 := []byte("codeHash2")
+
+	require.False(t, coverageTracker.IsContractTracked(codeHash1))
+	coverageTracker.TrackContract(codeHash1, []string{"init", "b", "a"}, []string{"int64finish", "getCaller"})
+	require.True(t, coverageTracker.IsContractTracked(codeHash1))
+	require.False(t, coverageTracker.IsContractTracked(codeHash2))
+
+	coverageTracker.TrackFunctionCall(codeHash1, "a")
+	coverageTracker.TrackFunctionCall(codeHash1, "a")
+	coverageTracker.TrackEICall(codeHash1, "getCaller")
+	coverageTracker.TrackEICall(codeHash2, "getCaller")
+	require.False(t, coverageTracker.IsContractTracked(codeHash2))
+
+	coverage := coverageTracker.GetCoverage()
+	require.Equal(t, 2, len(coverage))
+
+	contractCoverage := coverage[string(codeHash1)]
+	require.Equal(t, codeHash1, contractCoverage.CodeHash)
+	require.Equal(t, []string{"a", "b", "init"}, contractCoverage.ExportedFunctions)
+	require.Equal(t, []string{"getCaller", "int64finish"}, contractCoverage.ImportedEIFunctions)
+	require.Equal(t, map[string]uint64{"a": 2}, contractCoverage.FunctionCalls)
+	require.Equal(t, map[string]uint64{"getCaller": 1}, contractCoverage.EICalls)
+
+	contractCoverage.FunctionCalls["a"] = 100
+	require.Equal(t, uint64(2), coverageTracker.GetCoverage()[string(codeHash1)].FunctionCalls["a"])
+}
+
+func TestDisabledCoverageTracker(t *testing.T) {
+	coverageTracker := NewDisabledCoverageTracker()
+	require.False(t, coverageTracker.IsInterfaceNil())
+
+	codeHash := []byte("codeHash")
+	require.True(t, coverageTracker.IsContractTracked(codeHash))
+	coverageTracker.TrackContract(codeHash, []string{"a"}, []string{"getCaller"})
+	coverageTracker.TrackFunctionCall(codeHash, "a")
+	coverageTracker.TrackEICall(codeHash, "getCaller")
+	require.Nil(t, coverageTracker.GetCoverage())
+}
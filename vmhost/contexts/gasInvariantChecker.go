@@ -0,0 +1,165 @@
+package contexts
+
+import (
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+)
+
+type gasInvariantFrame struct {
+	address     []byte
+	function    string
+	callType    vm.CallType
+	gasProvided uint64
+	lastHook    string
+
+	// gasLeftAtCall is the gas left to the caller when this frame was pushed
+	gasLeftAtCall uint64
+	hasCaller     bool
+}
+
+// gasInvariantChecker verifies the conservation of gas at the boundaries of the frames of the
+// call stack and keeps the first violation found, with the frame and the EI hook it appeared after
+type gasInvariantChecker struct {
+	frames        []*gasInvariantFrame
+	gasLeftAtCall uint64
+	hasCaller     bool
+	violation     *vmhost.GasInvariantViolation
+}
+
+// disabledGasInvariantChecker does not check anything
+type disabledGasInvariantChecker struct {
+}
+
+// NewEnabledGasInvariantChecker creates a new gasInvariantChecker
+func NewEnabledGasInvariantChecker() *gasInvariantChecker {
+	return &gasInvariantChecker{
+		frames: make([]*gasInvariantFrame, 0),
+	}
+}
+
+// NewDisabledGasInvariantChecker creates a new disabledGasInvariantChecker
+func NewDisabledGasInvariantChecker() *disabledGasInvariantChecker {
+	return &disabledGasInvariantChecker{}
+}
+
+// BeginFrame pushes a new frame, which received the given gas
+func (checker *gasInvariantChecker) BeginFrame(address []byte, function string, callType vm.CallType, gasProvided uint64) {
+	checker.frames = append(checker.frames, &gasInvariantFrame{
+		address:       address,
+		function:      function,
+		callType:      callType,
+		gasProvided:   gasProvided,
+		gasLeftAtCall: checker.gasLeftAtCall,
+		hasCaller:     checker.hasCaller,
+	})
+	checker.hasCaller = false
+}
+
+// SuspendFrame records the gas left to the current frame, which is about to call another one
+func (checker *gasInvariantChecker) SuspendFrame(gasLeft uint64) {
+	checker.gasLeftAtCall = gasLeft
+	checker.hasCaller = true
+}
+
+// EndFrame pops the current frame, after verifying that it did not receive more gas than its
+// caller had left and, if its state is merged into the caller, that it did not spend more
+// gas than it received
+func (checker *gasInvariantChecker) EndFrame(boundary string, gasSpent uint64, merged bool) {
+	frame := checker.currentFrame()
+	if frame == nil {
+		return
+	}
+
+	// the callbacks also receive the gas locked for them, which is not left to the caller
+	if frame.hasCaller && frame.callType != vm.AsynchronousCallBack && frame.gasProvided > frame.gasLeftAtCall {
+		checker.setViolation(boundary, frame.gasLeftAtCall, frame.gasProvided)
+	}
+	if merged && gasSpent > frame.gasProvided {
+		checker.setViolation(boundary, frame.gasProvided, gasSpent)
+	}
+
+	checker.frames = checker.frames[:len(checker.frames)-1]
+}
+
+// SetLastHook records the EI hook which last used gas in the current frame
+func (checker *gasInvariantChecker) SetLastHook(hookName string) {
+	frame := checker.currentFrame()
+	if frame != nil {
+		frame.lastHook = hookName
+	}
+}
+
+// CheckOutputGas verifies that the gas accounted in the output of the current frame, used,
+// transferred and remaining, is the gas the frame received
+func (checker *gasInvariantChecker) CheckOutputGas(gasAccounted uint64) {
+	frame := checker.currentFrame()
+	if frame != nil && gasAccounted != frame.gasProvided {
+		checker.setViolation("UpdateGasStateOnSuccess", frame.gasProvided, gasAccounted)
+	}
+}
+
+// GetViolation returns the first violation found, if any
+func (checker *gasInvariantChecker) GetViolation() *vmhost.GasInvariantViolation {
+	return checker.violation
+}
+
+func (checker *gasInvariantChecker) currentFrame() *gasInvariantFrame {
+	if len(checker.frames) == 0 {
+		return nil
+	}
+
+	return checker.frames[len(checker.frames)-1]
+}
+
+func (checker *gasInvariantChecker) setViolation(boundary string, expected uint64, actual uint64) {
+	if checker.violation != nil {
+		return
+	}
+
+	frame := checker.currentFrame()
+	checker.violation = &vmhost.GasInvariantViolation{
+		Boundary: boundary,
+		Depth:    len(checker.frames) - 1,
+		Address:  frame.address,
+		Function: frame.function,
+		LastHook: frame.lastHook,
+		Expected: expected,
+		Actual:   actual,
+	}
+	logMetering.Error("gas invariant violated", "error", checker.violation.Error())
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (checker *gasInvariantChecker) IsInterfaceNil() bool {
+	return checker == nil
+}
+
+// BeginFrame does nothing
+func (dgic *disabledGasInvariantChecker) BeginFrame(_ []byte, _ string, _ vm.CallType, _ uint64) {
+}
+
+// SuspendFrame does nothing
+func (dgic *disabledGasInvariantChecker) SuspendFrame(_ uint64) {
+}
+
+// EndFrame does nothing
+func (dgic *disabledGasInvariantChecker) EndFrame(_ string, _ uint64, _ bool) {
+}
+
+// SetLastHook does nothing
+func (dgic *disabledGasInvariantChecker) SetLastHook(_ string) {
+}
+
+// CheckOutputGas does nothing
+func (dgic *disabledGasInvariantChecker) CheckOutputGas(_ uint64) {
+}
+
+// GetViolation returns nil
+func (dgic *disabledGasInvariantChecker) GetViolation() *vmhost.GasInvariantViolation {
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (dgic *disabledGasInvariantChecker) IsInterfaceNil() bool {
+	return dgic == nil
+}
@@ -0,0 +1,75 @@
+package contexts
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGasInvariantChecker_ConservedGas(t *testing.T) {
+	t.Parallel()
+
+	checker := NewEnabledGasInvariantChecker()
+	checker.BeginFrame([]byte("parent"), "parentFunction", vm.DirectCall, 10000)
+	checker.SuspendFrame(8000)
+	checker.BeginFrame([]byte("child"), "childFunction", vm.DirectCall, 8000)
+	checker.SetLastHook("childHook")
+	checker.CheckOutputGas(8000)
+	checker.EndFrame("PopMergeActiveState", 6000, true)
+	checker.CheckOutputGas(10000)
+
+	require.Nil(t, checker.GetViolation())
+}
+
+func TestGasInvariantChecker_ReportsFirstViolation(t *testing.T) {
+	t.Parallel()
+
+	checker := NewEnabledGasInvariantChecker()
+	checker.BeginFrame([]byte("parent"), "parentFunction", vm.DirectCall, 10000)
+	checker.SuspendFrame(8000)
+	checker.BeginFrame([]byte("child"), "childFunction", vm.DirectCall, 8000)
+	checker.SetLastHook("childHook")
+	checker.CheckOutputGas(7999)
+	checker.EndFrame("PopMergeActiveState", 9000, true)
+	checker.CheckOutputGas(9000)
+
+	violation := checker.GetViolation()
+	require.NotNil(t, violation)
+	require.Equal(t, "UpdateGasStateOnSuccess", violation.Boundary)
+	require.Equal(t, 1, violation.Depth)
+	require.Equal(t, []byte("child"), violation.Address)
+	require.Equal(t, "childFunction", violation.Function)
+	require.Equal(t, "childHook", violation.LastHook)
+	require.Equal(t, uint64(8000), violation.Expected)
+	require.Equal(t, uint64(7999), violation.Actual)
+}
+
+func TestGasInvariantChecker_CallbackReceivesLockedGas(t *testing.T) {
+	t.Parallel()
+
+	checker := NewEnabledGasInvariantChecker()
+	checker.BeginFrame([]byte("parent"), "parentFunction", vm.DirectCall, 10000)
+	checker.SuspendFrame(1000)
+	checker.BeginFrame([]byte("parent"), "callBack", vm.AsynchronousCallBack, 3000)
+	checker.EndFrame("PopMergeActiveState", 2000, true)
+	require.Nil(t, checker.GetViolation())
+
+	checker.SuspendFrame(1000)
+	checker.BeginFrame([]byte("child"), "childFunction", vm.DirectCall, 3000)
+	checker.EndFrame("PopSetActiveState", 0, false)
+	require.NotNil(t, checker.GetViolation())
+	require.Equal(t, uint64(1000), checker.GetViolation().Expected)
+}
+
+func TestDisabledGasInvariantChecker(t *testing.T) {
+	t.Parallel()
+
+	checker := NewDisabledGasInvariantChecker()
+	checker.BeginFrame([]byte("parent"), "parentFunction", vm.DirectCall, 10000)
+	checker.CheckOutputGas(1)
+	checker.EndFrame("PopMergeActiveState", 20000, true)
+
+	require.Nil(t, checker.GetViolation())
+	require.False(t, checker.IsInterfaceNil())
+}
@@ -0,0 +1,6 @@
+//go:build gasdebug
+
+package contexts
+
+// gasInvariantCheckingByDefault enables the gas invariant checker in the builds with the gasdebug tag
+const gasInvariantCheckingByDefault = true
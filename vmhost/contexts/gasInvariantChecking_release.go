@@ -0,0 +1,6 @@
+//go:build !gasdebug
+
+package contexts
+
+// gasInvariantCheckingByDefault disables the gas invariant checker, unless built with the gasdebug tag
+const gasInvariantCheckingByDefault = false
@@ -1,5 +1,7 @@
 package contexts
 
+import "github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+
 // GasTraceMap is the map that holds gas traces for all API functions, depending on SCAddress and functionName
 type gasTraceMap map[string]map[string][]uint64
 
@@ -81,6 +83,23 @@ func (gt *gasTracer) GetGasTrace() map[string]map[string][]uint64 {
 	return gt.gasTrace
 }
 
+// BeginFrame does nothing, the flat gas trace does not record call frames
+func (gt *gasTracer) BeginFrame(_ vmhost.GasTraceFrameKind, _ string, _ string) {
+}
+
+// EndFrame does nothing, the flat gas trace does not record call frames
+func (gt *gasTracer) EndFrame() {
+}
+
+// SetFrameMemoryPages does nothing, the flat gas trace does not record call frames
+func (gt *gasTracer) SetFrameMemoryPages(_ uint32) {
+}
+
+// GetCallTree returns nil, the flat gas trace does not record call frames
+func (gt *gasTracer) GetCallTree() []*vmhost.GasTraceFrame {
+	return nil
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (gt *gasTracer) IsInterfaceNil() bool {
 	return gt == nil
@@ -106,6 +125,23 @@ func (dgt *disabledGasTracer) GetGasTrace() map[string]map[string][]uint64 {
 	return nil
 }
 
+// BeginFrame does nothing
+func (dgt *disabledGasTracer) BeginFrame(_ vmhost.GasTraceFrameKind, _ string, _ string) {
+}
+
+// EndFrame does nothing
+func (dgt *disabledGasTracer) EndFrame() {
+}
+
+// SetFrameMemoryPages does nothing
+func (dgt *disabledGasTracer) SetFrameMemoryPages(_ uint32) {
+}
+
+// GetCallTree returns nil
+func (dgt *disabledGasTracer) GetCallTree() []*vmhost.GasTraceFrame {
+	return nil
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (dgt *disabledGasTracer) IsInterfaceNil() bool {
 	return dgt == nil
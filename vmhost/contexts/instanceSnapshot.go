@@ -0,0 +1,99 @@
+package contexts
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/wasmer"
+)
+
+// instanceSnapshot holds the linear memory and the globals of an instance right after its instantiation,
+// restored on the warm instance of the same code hash each time it is reused; the globals are captured
+// only from the instances implementing wasmer.GlobalsHandler, the others relying on Reset for them
+type instanceSnapshot struct {
+	memory  []byte
+	globals []uint64
+}
+
+func takeInstanceSnapshot(instance wasmer.InstanceHandler) *instanceSnapshot {
+	snapshot := &instanceSnapshot{}
+	if instance.HasMemory() && !check.IfNil(instance.GetMemory()) {
+		snapshot.memory = make([]byte, len(instance.GetMemory().Data()))
+		copy(snapshot.memory, instance.GetMemory().Data())
+	}
+
+	globalsHandler, ok := instance.(wasmer.GlobalsHandler)
+	if ok {
+		snapshot.globals = globalsHandler.GetGlobals()
+	}
+
+	return snapshot
+}
+
+func (snapshot *instanceSnapshot) sizeInBytes() uint64 {
+	return uint64(len(snapshot.memory)) + uint64(len(snapshot.globals))*8
+}
+
+// restore brings the memory and the globals of the instance back to the snapshot; it fails if the memory
+// grew since the snapshot, as it cannot shrink back to its size
+func (snapshot *instanceSnapshot) restore(instance wasmer.InstanceHandler) bool {
+	if snapshot.memory != nil {
+		if !instance.HasMemory() || check.IfNil(instance.GetMemory()) {
+			return false
+		}
+		if len(instance.GetMemory().Data()) != len(snapshot.memory) {
+			return false
+		}
+		if !instance.SetMemory(snapshot.memory) {
+			return false
+		}
+	}
+
+	if snapshot.globals != nil {
+		globalsHandler, ok := instance.(wasmer.GlobalsHandler)
+		if !ok || !globalsHandler.SetGlobals(snapshot.globals) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// compareInstanceStates returns an error describing the first difference between the memory and the
+// globals of the two instances, or nil if they are identical
+func compareInstanceStates(instance wasmer.InstanceHandler, reference wasmer.InstanceHandler) error {
+	state := takeInstanceSnapshot(instance)
+	referenceState := takeInstanceSnapshot(reference)
+
+	if len(state.memory) != len(referenceState.memory) {
+		return fmt.Errorf("memory size %d, expected %d", len(state.memory), len(referenceState.memory))
+	}
+	if !bytes.Equal(state.memory, referenceState.memory) {
+		return fmt.Errorf("memory differs at offset %d", firstDifference(state.memory, referenceState.memory))
+	}
+
+	if state.globals == nil || referenceState.globals == nil {
+		return nil
+	}
+	if len(state.globals) != len(referenceState.globals) {
+		return fmt.Errorf("%d globals, expected %d", len(state.globals), len(referenceState.globals))
+	}
+	for i := range state.globals {
+		if state.globals[i] != referenceState.globals[i] {
+			return fmt.Errorf("global %d is %d, expected %d", i, state.globals[i], referenceState.globals[i])
+		}
+	}
+
+	return nil
+}
+
+func firstDifference(a []byte, b []byte) int {
+	for i := range a {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+
+	return len(a)
+}
@@ -0,0 +1,40 @@
+package contexts
+
+import (
+	"testing"
+
+	mock "github.com/multiversx/mx-chain-vm-v1_4-go/mock/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareInstanceStates(t *testing.T) {
+	t.Parallel()
+
+	t.Run("identical states", func(t *testing.T) {
+		instance := &instanceWithGlobals{InstanceMock: mock.NewInstanceMock(nil), globals: []uint64{1, 2}}
+		reference := &instanceWithGlobals{InstanceMock: mock.NewInstanceMock(nil), globals: []uint64{1, 2}}
+		require.Nil(t, compareInstanceStates(instance, reference))
+	})
+	t.Run("different memory sizes", func(t *testing.T) {
+		instance := mock.NewInstanceMock(nil)
+		require.Nil(t, instance.GetMemory().Grow(1))
+		err := compareInstanceStates(instance, mock.NewInstanceMock(nil))
+		require.Equal(t, "memory size 196608, expected 131072", err.Error())
+	})
+	t.Run("different memory contents", func(t *testing.T) {
+		instance := mock.NewInstanceMock(nil)
+		instance.GetMemory().Data()[1024] = 1
+		err := compareInstanceStates(instance, mock.NewInstanceMock(nil))
+		require.Equal(t, "memory differs at offset 1024", err.Error())
+	})
+	t.Run("different globals", func(t *testing.T) {
+		instance := &instanceWithGlobals{InstanceMock: mock.NewInstanceMock(nil), globals: []uint64{1, 3}}
+		reference := &instanceWithGlobals{InstanceMock: mock.NewInstanceMock(nil), globals: []uint64{1, 2}}
+		err := compareInstanceStates(instance, reference)
+		require.Equal(t, "global 1 is 3, expected 2", err.Error())
+	})
+	t.Run("globals compared only when both instances expose them", func(t *testing.T) {
+		instance := &instanceWithGlobals{InstanceMock: mock.NewInstanceMock(nil), globals: []uint64{1, 3}}
+		require.Nil(t, compareInstanceStates(instance, mock.NewInstanceMock(nil)))
+	})
+}
@@ -3,6 +3,7 @@ package contexts
 import (
 	"bytes"
 	"fmt"
+	"sync/atomic"
 
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	logger "github.com/multiversx/mx-chain-logger-go"
@@ -39,23 +40,60 @@ type instanceTracker struct {
 	codeHashStack       [][]byte
 	codeSizeStack       []uint64
 
+	// the memory pages of the active instance when it was instantiated, as declared by its module
+	initialMemoryPages      uint32
+	initialMemoryPagesStack []uint32
+
 	instances map[string]wasmer.InstanceHandler
+
+	warmInstancesEnabled bool
+	restoreWarmInstances bool
+	verifyWarmInstances  bool
+	// the instances removed explicitly from the warm cache are not counted as evictions
+	removingWarmInstances bool
+	warmInstanceSizes     map[string]uint64
+	warmInstancePages     map[string]uint32
+	warmInstanceSnapshots map[string]*instanceSnapshot
+	counters              warmInstanceCacheCounters
+}
+
+// warmInstanceCacheCounters are updated atomically, as they are read outside the executions
+type warmInstanceCacheCounters struct {
+	hits                     uint64
+	misses                   uint64
+	evictions                uint64
+	instancesFromPrecompiled uint64
+	instancesFromBytecode    uint64
+	numWarmInstances         int64
+	warmInstancesSizeInBytes uint64
+	stateMismatches          uint64
 }
 
-// NewInstanceTracker creates a new instanceTracker instance
-func NewInstanceTracker() (*instanceTracker, error) {
+// NewInstanceTracker creates a new instanceTracker instance; a nil cache config selects the default warm instance cache
+func NewInstanceTracker(cacheConfig *vmhost.WarmInstanceCacheConfig) (*instanceTracker, error) {
+	resolvedConfig, err := resolveWarmInstanceCacheConfig(cacheConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	tracker := &instanceTracker{
-		instances:           make(map[string]wasmer.InstanceHandler),
-		instanceStack:       make([]wasmer.InstanceHandler, 0),
-		codeHashStack:       make([][]byte, 0),
-		codeSizeStack:       make([]uint64, 0),
-		numRunningInstances: 0,
+		instances:               make(map[string]wasmer.InstanceHandler),
+		instanceStack:           make([]wasmer.InstanceHandler, 0),
+		codeHashStack:           make([][]byte, 0),
+		codeSizeStack:           make([]uint64, 0),
+		initialMemoryPagesStack: make([]uint32, 0),
+		numRunningInstances:     0,
+		warmInstancesEnabled:    resolvedConfig.Enabled,
+		restoreWarmInstances:    resolvedConfig.Enabled && resolvedConfig.RestoreWarmInstances,
+		verifyWarmInstances:     resolvedConfig.Enabled && resolvedConfig.VerifyWarmInstances,
+		warmInstanceSizes:       make(map[string]uint64),
+		warmInstancePages:       make(map[string]uint32),
+		warmInstanceSnapshots:   make(map[string]*instanceSnapshot),
 	}
 
-	var err error
 	instanceEvictedCallback := tracker.makeInstanceEvictionCallback()
-	if WarmInstancesEnabled {
-		tracker.warmInstanceCache, err = lrucache.NewCacheWithEviction(warmCacheSize, instanceEvictedCallback)
+	if tracker.warmInstancesEnabled {
+		tracker.warmInstanceCache, err = createWarmInstanceCache(resolvedConfig, instanceEvictedCallback)
 	} else {
 		tracker.warmInstanceCache = nil
 	}
@@ -66,12 +104,50 @@ func NewInstanceTracker() (*instanceTracker, error) {
 	return tracker, nil
 }
 
+func resolveWarmInstanceCacheConfig(cacheConfig *vmhost.WarmInstanceCacheConfig) (vmhost.WarmInstanceCacheConfig, error) {
+	if cacheConfig == nil {
+		return vmhost.WarmInstanceCacheConfig{
+			Enabled:        WarmInstancesEnabled,
+			Size:           vmhost.DefaultWarmInstanceCacheSize,
+			EvictionPolicy: vmhost.LRUEviction,
+		}, nil
+	}
+
+	resolvedConfig := *cacheConfig
+	if resolvedConfig.Size < 0 {
+		return resolvedConfig, fmt.Errorf("%w: size %d", vmhost.ErrInvalidWarmInstanceCacheConfig, resolvedConfig.Size)
+	}
+	if resolvedConfig.Size == 0 {
+		resolvedConfig.Size = vmhost.DefaultWarmInstanceCacheSize
+	}
+
+	switch resolvedConfig.EvictionPolicy {
+	case "":
+		resolvedConfig.EvictionPolicy = vmhost.LRUEviction
+	case vmhost.LRUEviction, vmhost.LFUEviction, vmhost.SizeAwareEviction:
+	default:
+		return resolvedConfig, fmt.Errorf("%w: eviction policy %s", vmhost.ErrInvalidWarmInstanceCacheConfig, resolvedConfig.EvictionPolicy)
+	}
+
+	return resolvedConfig, nil
+}
+
+// createWarmInstanceCache keeps the plain LRU cache when neither another policy nor a memory budget is configured
+func createWarmInstanceCache(cacheConfig vmhost.WarmInstanceCacheConfig, onEvicted func(interface{}, interface{})) (Cacher, error) {
+	if cacheConfig.EvictionPolicy == vmhost.LRUEviction && cacheConfig.MemoryBudget == 0 {
+		return lrucache.NewCacheWithEviction(cacheConfig.Size, onEvicted)
+	}
+
+	return newPolicyCache(cacheConfig.EvictionPolicy, cacheConfig.Size, cacheConfig.MemoryBudget, onEvicted), nil
+}
+
 // InitState initializes the internal instanceTracker state
 func (tracker *instanceTracker) InitState() {
 	tracker.instance = nil
 	tracker.codeHash = make([]byte, 0)
 	tracker.instances = make(map[string]wasmer.InstanceHandler)
 	tracker.codeSize = 0
+	tracker.initialMemoryPages = 0
 }
 
 // PushState pushes the active instance and codeHash on the state stacks
@@ -79,6 +155,7 @@ func (tracker *instanceTracker) PushState() {
 	tracker.instanceStack = append(tracker.instanceStack, tracker.instance)
 	tracker.codeHashStack = append(tracker.codeHashStack, tracker.codeHash)
 	tracker.codeSizeStack = append(tracker.codeSizeStack, tracker.codeSize)
+	tracker.initialMemoryPagesStack = append(tracker.initialMemoryPagesStack, tracker.initialMemoryPages)
 	logTracker.Trace("pushing instance", "id", tracker.instance.ID(), "codeHash", tracker.codeHash)
 }
 
@@ -95,7 +172,7 @@ func (tracker *instanceTracker) PopSetActiveState() {
 
 	onStack := tracker.IsCodeHashOnTheStack(activeCodeHash)
 	activeInstanceIsTopOfStack := stackedPrevInstance == activeInstance
-	cold := !WarmInstancesEnabled
+	cold := !tracker.warmInstancesEnabled
 
 	if !activeInstanceIsTopOfStack && (onStack || cold) {
 		tracker.cleanPoppedInstance(activeInstance, activeCodeHash)
@@ -109,6 +186,9 @@ func (tracker *instanceTracker) PopSetActiveState() {
 
 	tracker.codeSize = tracker.codeSizeStack[instanceStackLen-1]
 	tracker.codeSizeStack = tracker.codeSizeStack[:instanceStackLen-1]
+
+	tracker.initialMemoryPages = tracker.initialMemoryPagesStack[instanceStackLen-1]
+	tracker.initialMemoryPagesStack = tracker.initialMemoryPagesStack[:instanceStackLen-1]
 }
 
 func (tracker *instanceTracker) cleanPoppedInstance(instance wasmer.InstanceHandler, codeHash []byte) {
@@ -130,6 +210,7 @@ func (tracker *instanceTracker) ClearStateStack() {
 	tracker.codeHashStack = make([][]byte, 0)
 	tracker.instanceStack = make([]wasmer.InstanceHandler, 0)
 	tracker.codeSizeStack = make([]uint64, 0)
+	tracker.initialMemoryPagesStack = make([]uint32, 0)
 }
 
 // StackSize returns the size of the instance stack
@@ -164,36 +245,89 @@ func (tracker *instanceTracker) CodeHash() []byte {
 
 // ClearWarmInstanceCache clears the internal warm instance cache
 func (tracker *instanceTracker) ClearWarmInstanceCache() {
-	if WarmInstancesEnabled {
+	if tracker.warmInstancesEnabled {
+		tracker.removingWarmInstances = true
 		tracker.warmInstanceCache.Clear()
+		tracker.removingWarmInstances = false
 	}
 }
 
+// IsWarmInstanceCacheEnabled returns true if the instances are kept warm after their execution
+func (tracker *instanceTracker) IsWarmInstanceCacheEnabled() bool {
+	return tracker.warmInstancesEnabled
+}
+
+func (tracker *instanceTracker) removeWarmInstance(codeHash []byte) {
+	tracker.removingWarmInstances = true
+	tracker.warmInstanceCache.Remove(codeHash)
+	tracker.removingWarmInstances = false
+}
+
 // UseWarmInstance attempts to retrieve a warm instance for the given codeHash
-// and to set it as active; returns false if not possible
+// and to set it as active; returns false if not possible. The warm instance is
+// reset, then brought back to the snapshot taken after its instantiation, if
+// the warm instances are restored.
 func (tracker *instanceTracker) UseWarmInstance(codeHash []byte, newCode bool) bool {
 	instance, ok := tracker.GetWarmInstance(codeHash)
 	if !ok {
+		atomic.AddUint64(&tracker.counters.misses, 1)
 		return false
 	}
 
 	ok = instance.Reset()
 	if !ok {
-		tracker.warmInstanceCache.Remove(codeHash)
+		atomic.AddUint64(&tracker.counters.misses, 1)
+		tracker.removeWarmInstance(codeHash)
+		return false
+	}
+
+	if tracker.restoreWarmInstances && !tracker.restoreWarmInstanceSnapshot(codeHash, instance) {
+		logTracker.Trace("warm instance snapshot cannot be restored", "id", instance.ID(), "codeHash", codeHash)
+		atomic.AddUint64(&tracker.counters.misses, 1)
+		tracker.removeWarmInstance(codeHash)
 		return false
 	}
 
 	if newCode {
 		// A warm instance was found, but newCode == true, meaning this is an
 		// upgrade; the old warm instance must be cleaned
+		atomic.AddUint64(&tracker.counters.misses, 1)
 		tracker.ForceCleanInstance(false)
 		return false
 	}
 
+	atomic.AddUint64(&tracker.counters.hits, 1)
 	tracker.SetNewInstance(instance, Warm)
+	tracker.initialMemoryPages = tracker.warmInstancePages[string(codeHash)]
 	return true
 }
 
+func (tracker *instanceTracker) restoreWarmInstanceSnapshot(codeHash []byte, instance wasmer.InstanceHandler) bool {
+	snapshot, ok := tracker.warmInstanceSnapshots[string(codeHash)]
+	return ok && snapshot.restore(instance)
+}
+
+// RejectWarmInstance discards the active warm instance, whose state differs from the one of a fresh
+// instance of its code; it is counted as a miss instead of a hit. The codeHash stays active.
+func (tracker *instanceTracker) RejectWarmInstance() {
+	if check.IfNil(tracker.instance) || tracker.cacheLevel != Warm {
+		return
+	}
+
+	atomic.AddUint64(&tracker.counters.hits, ^uint64(0))
+	atomic.AddUint64(&tracker.counters.misses, 1)
+	atomic.AddUint64(&tracker.counters.stateMismatches, 1)
+	logTracker.Trace("reject warm instance", "id", tracker.instance.ID(), "codeHash", tracker.codeHash)
+
+	tracker.removeWarmInstance(tracker.codeHash)
+	tracker.instance = nil
+}
+
+// IsWarmInstanceVerificationEnabled returns true if the reused warm instances must be compared with fresh instances
+func (tracker *instanceTracker) IsWarmInstanceVerificationEnabled() bool {
+	return tracker.verifyWarmInstances
+}
+
 // ForceCleanInstance cleans the active instance and evicts it from the
 // internal warm instance cache if possible
 func (tracker *instanceTracker) ForceCleanInstance(bypassWarmAndStackChecks bool) {
@@ -217,17 +351,19 @@ func (tracker *instanceTracker) ForceCleanInstance(bypassWarmAndStackChecks bool
 	}
 
 	onStack := tracker.IsCodeHashOnTheStack(tracker.codeHash)
-	coldOnlyEnabled := !WarmInstancesEnabled
+	coldOnlyEnabled := !tracker.warmInstancesEnabled
 	if onStack || coldOnlyEnabled {
 		if tracker.instance.Clean() {
 			tracker.updateNumRunningInstances(-1)
 		}
 	} else {
-		tracker.warmInstanceCache.Remove(tracker.codeHash)
+		tracker.removeWarmInstance(tracker.codeHash)
 	}
 }
 
-// SaveAsWarmInstance saves the active instance into the internal warm instance cache
+// SaveAsWarmInstance saves the active instance into the internal warm instance cache; the active
+// instance must not have been executed yet, as its state is taken as the snapshot of its codeHash,
+// counted in its size, when the warm instances are restored
 func (tracker *instanceTracker) SaveAsWarmInstance() {
 	lenCacheBeforeSaving := tracker.warmInstanceCache.Len()
 
@@ -241,17 +377,27 @@ func (tracker *instanceTracker) SaveAsWarmInstance() {
 		logTracker.Trace("warm instance already in cache, evicting",
 			"id", tracker.instance.ID(),
 			"codeHash", tracker.codeHash)
-		tracker.warmInstanceCache.Remove(tracker.codeHash)
+		tracker.removeWarmInstance(tracker.codeHash)
 	}
 
 	logTracker.Trace("warm instance not found, saving",
 		"id", tracker.instance.ID(),
 		"codeHash", tracker.codeHash,
 	)
+	sizeInBytes := tracker.activeInstanceSizeInBytes()
+	if tracker.restoreWarmInstances {
+		snapshot := takeInstanceSnapshot(tracker.instance)
+		tracker.warmInstanceSnapshots[string(tracker.codeHash)] = snapshot
+		sizeInBytes += snapshot.sizeInBytes()
+	}
+	tracker.warmInstanceSizes[string(tracker.codeHash)] = sizeInBytes
+	tracker.warmInstancePages[string(tracker.codeHash)] = tracker.initialMemoryPages
+	atomic.AddInt64(&tracker.counters.numWarmInstances, 1)
+	atomic.AddUint64(&tracker.counters.warmInstancesSizeInBytes, sizeInBytes)
 	tracker.warmInstanceCache.Put(
 		tracker.codeHash,
 		tracker.instance,
-		1,
+		int(sizeInBytes),
 	)
 
 	lenCacheAfterSaving := tracker.warmInstanceCache.Len()
@@ -266,6 +412,17 @@ func (tracker *instanceTracker) SaveAsWarmInstance() {
 	)
 }
 
+// activeInstanceSizeInBytes estimates the memory held by the active instance as the size of its code
+// plus the size of its linear memory
+func (tracker *instanceTracker) activeInstanceSizeInBytes() uint64 {
+	sizeInBytes := tracker.codeSize
+	if tracker.instance.HasMemory() {
+		sizeInBytes += uint64(tracker.instance.GetMemory().Length())
+	}
+
+	return sizeInBytes
+}
+
 // SetCodeHash sets the active codeHash; it must correspond with the active instance
 func (tracker *instanceTracker) SetCodeHash(codeHash []byte) {
 	tracker.codeHash = codeHash
@@ -281,12 +438,25 @@ func (tracker *instanceTracker) GetCodeSize() uint64 {
 	return tracker.codeSize
 }
 
+// InitialMemoryPages returns the memory pages of the active instance when it was instantiated, which
+// are fixed by its code, unlike its current memory, kept grown by the warm instances
+func (tracker *instanceTracker) InitialMemoryPages() uint32 {
+	return tracker.initialMemoryPages
+}
+
 // SetNewInstance sets the given instance as active and tracks its creation
 func (tracker *instanceTracker) SetNewInstance(instance wasmer.InstanceHandler, cacheLevel instanceCacheLevel) {
 	tracker.ReplaceInstance(instance)
 	tracker.cacheLevel = cacheLevel
 	if cacheLevel != Warm {
 		tracker.updateNumRunningInstances(+1)
+		tracker.initialMemoryPages = memoryPages(instance)
+	}
+	switch cacheLevel {
+	case Precompiled:
+		atomic.AddUint64(&tracker.counters.instancesFromPrecompiled, 1)
+	case Bytecode:
+		atomic.AddUint64(&tracker.counters.instancesFromBytecode, 1)
 	}
 	tracker.instances[instance.ID()] = instance
 }
@@ -335,7 +505,7 @@ func (tracker *instanceTracker) LogCounts() {
 // NumRunningInstances returns the number of currently running instances (cold and warm)
 func (tracker *instanceTracker) NumRunningInstances() (int, int) {
 	numWarmInstances := 0
-	if WarmInstancesEnabled {
+	if tracker.warmInstancesEnabled {
 		numWarmInstances = tracker.warmInstanceCache.Len()
 	}
 
@@ -391,8 +561,33 @@ func (tracker *instanceTracker) CheckInstances() error {
 	return nil
 }
 
+// GetWarmInstanceCacheStats returns the counters of the warm instance cache
+func (tracker *instanceTracker) GetWarmInstanceCacheStats() vmhost.WarmInstanceCacheStats {
+	return vmhost.WarmInstanceCacheStats{
+		Hits:                     atomic.LoadUint64(&tracker.counters.hits),
+		Misses:                   atomic.LoadUint64(&tracker.counters.misses),
+		Evictions:                atomic.LoadUint64(&tracker.counters.evictions),
+		InstancesFromPrecompiled: atomic.LoadUint64(&tracker.counters.instancesFromPrecompiled),
+		InstancesFromBytecode:    atomic.LoadUint64(&tracker.counters.instancesFromBytecode),
+		NumWarmInstances:         int(atomic.LoadInt64(&tracker.counters.numWarmInstances)),
+		WarmInstancesSizeInBytes: atomic.LoadUint64(&tracker.counters.warmInstancesSizeInBytes),
+		StateMismatches:          atomic.LoadUint64(&tracker.counters.stateMismatches),
+	}
+}
+
 func (tracker *instanceTracker) makeInstanceEvictionCallback() func(interface{}, interface{}) {
-	return func(_ interface{}, value interface{}) {
+	return func(key interface{}, value interface{}) {
+		codeHash, _ := key.(string)
+		sizeInBytes := tracker.warmInstanceSizes[codeHash]
+		delete(tracker.warmInstanceSizes, codeHash)
+		delete(tracker.warmInstancePages, codeHash)
+		delete(tracker.warmInstanceSnapshots, codeHash)
+		atomic.AddInt64(&tracker.counters.numWarmInstances, -1)
+		atomic.AddUint64(&tracker.counters.warmInstancesSizeInBytes, ^(sizeInBytes - 1))
+		if !tracker.removingWarmInstances {
+			atomic.AddUint64(&tracker.counters.evictions, 1)
+		}
+
 		instance, ok := value.(wasmer.InstanceHandler)
 		if !ok {
 			return
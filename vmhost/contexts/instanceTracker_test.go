@@ -1,16 +1,18 @@
 package contexts
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
 	mock "github.com/multiversx/mx-chain-vm-v1_4-go/mock/context"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
 	"github.com/multiversx/mx-chain-vm-v1_4-go/wasmer"
 	"github.com/stretchr/testify/require"
 )
 
 func TestInstanceTracker_TrackInstance(t *testing.T) {
-	iTracker, err := NewInstanceTracker()
+	iTracker, err := NewInstanceTracker(nil)
 	require.Nil(t, err)
 
 	newInstance := &wasmer.Instance{
@@ -33,7 +35,7 @@ func TestInstanceTracker_TrackInstance(t *testing.T) {
 }
 
 func TestInstanceTracker_InitState(t *testing.T) {
-	iTracker, err := NewInstanceTracker()
+	iTracker, err := NewInstanceTracker(nil)
 	require.Nil(t, err)
 	require.Equal(t, 0, iTracker.numRunningInstances)
 
@@ -59,7 +61,7 @@ func TestInstanceTracker_InitState(t *testing.T) {
 }
 
 func TestInstanceTracker_GetWarmInstance(t *testing.T) {
-	iTracker, err := NewInstanceTracker()
+	iTracker, err := NewInstanceTracker(nil)
 	require.Nil(t, err)
 
 	testData := []string{"warm1", "bytecode1", "bytecode2", "warm2"}
@@ -91,7 +93,7 @@ func TestInstanceTracker_GetWarmInstance(t *testing.T) {
 }
 
 func TestInstanceTracker_UseWarmInstance(t *testing.T) {
-	iTracker, err := NewInstanceTracker()
+	iTracker, err := NewInstanceTracker(nil)
 	require.Nil(t, err)
 
 	testData := []string{"warm1", "bytecode1", "warm2", "bytecode2"}
@@ -120,7 +122,7 @@ func TestInstanceTracker_UseWarmInstance(t *testing.T) {
 }
 
 func TestInstanceTracker_IsCodeHashOnStack_Ok(t *testing.T) {
-	iTracker, err := NewInstanceTracker()
+	iTracker, err := NewInstanceTracker(nil)
 	require.Nil(t, err)
 
 	testData := []string{"alpha", "beta", "alpha", "active"}
@@ -153,7 +155,7 @@ func TestInstanceTracker_IsCodeHashOnStack_Ok(t *testing.T) {
 
 // stack: alpha<-alpha(cold)<-alpha(cold)<-alpha(cold)
 func TestInstanceTracker_PopSetActiveSelfScenario(t *testing.T) {
-	iTracker, err := NewInstanceTracker()
+	iTracker, err := NewInstanceTracker(nil)
 	require.Nil(t, err)
 
 	testData := []string{"alpha", "alpha", "alpha", "alpha", "active"}
@@ -183,7 +185,7 @@ func TestInstanceTracker_PopSetActiveSelfScenario(t *testing.T) {
 
 // stack: alpha<-beta<-alpha(cold)<-beta(cold)
 func TestInstanceTracker_PopSetActiveSimpleScenario(t *testing.T) {
-	iTracker, err := NewInstanceTracker()
+	iTracker, err := NewInstanceTracker(nil)
 	require.Nil(t, err)
 
 	testData := []string{"alpha", "beta", "alpha", "beta", "active"}
@@ -222,7 +224,7 @@ func TestInstanceTracker_PopSetActiveSimpleScenario(t *testing.T) {
 
 // stack: alpha<-beta<-gamma<-beta(cold)<-gamma(cold)<-delta<-alpha(cold)
 func TestInstanceTracker_PopSetActiveComplexScenario(t *testing.T) {
-	iTracker, err := NewInstanceTracker()
+	iTracker, err := NewInstanceTracker(nil)
 	require.Nil(t, err)
 
 	testData := []string{"alpha", "beta", "gamma", "beta", "gamma", "delta", "alpha", "active"}
@@ -251,7 +253,7 @@ func TestInstanceTracker_PopSetActiveComplexScenario(t *testing.T) {
 }
 
 func TestInstanceTracker_PopSetActiveWarmOnlyScenario(t *testing.T) {
-	iTracker, err := NewInstanceTracker()
+	iTracker, err := NewInstanceTracker(nil)
 	require.Nil(t, err)
 
 	testData := []string{"alpha", "beta", "gamma", "delta", "active"}
@@ -279,7 +281,7 @@ func TestInstanceTracker_PopSetActiveWarmOnlyScenario(t *testing.T) {
 }
 
 func TestInstanceTracker_ForceCleanInstanceWithBypass(t *testing.T) {
-	iTracker, err := NewInstanceTracker()
+	iTracker, err := NewInstanceTracker(nil)
 	require.Nil(t, err)
 
 	testData := []string{"warm1", "bytecode1"}
@@ -311,7 +313,7 @@ func TestInstanceTracker_ForceCleanInstanceWithBypass(t *testing.T) {
 }
 
 func TestInstanceTracker_DoubleForceClean(t *testing.T) {
-	iTracker, err := NewInstanceTracker()
+	iTracker, err := NewInstanceTracker(nil)
 	require.Nil(t, err)
 
 	iTracker.SetNewInstance(mock.NewInstanceMock(nil), Bytecode)
@@ -328,7 +330,7 @@ func TestInstanceTracker_DoubleForceClean(t *testing.T) {
 }
 
 func TestInstanceTracker_UnsetInstance_AlreadyNil_Ok(t *testing.T) {
-	iTracker, err := NewInstanceTracker()
+	iTracker, err := NewInstanceTracker(nil)
 	require.Nil(t, err)
 
 	iTracker.instance = nil
@@ -337,7 +339,7 @@ func TestInstanceTracker_UnsetInstance_AlreadyNil_Ok(t *testing.T) {
 }
 
 func TestInstanceTracker_UnsetInstance_Ok(t *testing.T) {
-	iTracker, err := NewInstanceTracker()
+	iTracker, err := NewInstanceTracker(nil)
 	require.Nil(t, err)
 
 	iTracker.instance = &wasmer.Instance{
@@ -366,3 +368,196 @@ func checkInstances(t *testing.T, iTracker *instanceTracker) {
 	require.Len(t, iTracker.codeHashStack, 0)
 	require.Nil(t, iTracker.CheckInstances())
 }
+
+func TestInstanceTracker_WarmInstanceCacheConfig(t *testing.T) {
+	iTracker, err := NewInstanceTracker(&vmhost.WarmInstanceCacheConfig{Enabled: true, Size: -1})
+	require.True(t, errors.Is(err, vmhost.ErrInvalidWarmInstanceCacheConfig))
+	require.Nil(t, iTracker)
+
+	iTracker, err = NewInstanceTracker(&vmhost.WarmInstanceCacheConfig{Enabled: true, EvictionPolicy: "MRU"})
+	require.True(t, errors.Is(err, vmhost.ErrInvalidWarmInstanceCacheConfig))
+	require.Nil(t, iTracker)
+
+	iTracker, err = NewInstanceTracker(&vmhost.WarmInstanceCacheConfig{Enabled: false})
+	require.Nil(t, err)
+	require.False(t, iTracker.IsWarmInstanceCacheEnabled())
+	require.Nil(t, iTracker.warmInstanceCache)
+
+	iTracker, err = NewInstanceTracker(&vmhost.WarmInstanceCacheConfig{Enabled: true, EvictionPolicy: vmhost.LFUEviction})
+	require.Nil(t, err)
+	require.True(t, iTracker.IsWarmInstanceCacheEnabled())
+	require.Equal(t, vmhost.DefaultWarmInstanceCacheSize, iTracker.warmInstanceCache.MaxSize())
+}
+
+func TestInstanceTracker_WarmInstanceCacheStats(t *testing.T) {
+	iTracker, err := NewInstanceTracker(&vmhost.WarmInstanceCacheConfig{
+		Enabled:        true,
+		Size:           2,
+		EvictionPolicy: vmhost.SizeAwareEviction,
+	})
+	require.Nil(t, err)
+
+	for i, codeHash := range []string{"first", "second", "third"} {
+		cacheLevel := Bytecode
+		if i == 1 {
+			cacheLevel = Precompiled
+		}
+		iTracker.SetNewInstance(mock.NewInstanceMock([]byte(codeHash)), cacheLevel)
+		iTracker.codeHash = []byte(codeHash)
+		iTracker.SetCodeSize(uint64(10 * (i + 1)))
+		iTracker.SaveAsWarmInstance()
+	}
+
+	require.True(t, iTracker.UseWarmInstance([]byte("third"), false))
+	require.False(t, iTracker.UseWarmInstance([]byte("second"), false))
+
+	stats := iTracker.GetWarmInstanceCacheStats()
+	require.Equal(t, vmhost.WarmInstanceCacheStats{
+		Hits:                     1,
+		Misses:                   1,
+		Evictions:                1,
+		InstancesFromPrecompiled: 1,
+		InstancesFromBytecode:    2,
+		NumWarmInstances:         2,
+		WarmInstancesSizeInBytes: 40 + 2*2*65536,
+	}, stats)
+
+	// clearing the cache is not counted as evictions
+	iTracker.ClearWarmInstanceCache()
+	stats = iTracker.GetWarmInstanceCacheStats()
+	require.Equal(t, uint64(1), stats.Evictions)
+	require.Equal(t, 0, stats.NumWarmInstances)
+	require.Equal(t, uint64(0), stats.WarmInstancesSizeInBytes)
+}
+
+// instanceWithGlobals is a mocked instance exposing its globals
+type instanceWithGlobals struct {
+	*mock.InstanceMock
+	globals []uint64
+}
+
+// GetGlobals -
+func (instance *instanceWithGlobals) GetGlobals() []uint64 {
+	globals := make([]uint64, len(instance.globals))
+	copy(globals, instance.globals)
+	return globals
+}
+
+// SetGlobals -
+func (instance *instanceWithGlobals) SetGlobals(values []uint64) bool {
+	if len(values) != len(instance.globals) {
+		return false
+	}
+
+	copy(instance.globals, values)
+	return true
+}
+
+func TestInstanceTracker_WarmInstanceSnapshotRestoredOnReuse(t *testing.T) {
+	iTracker, err := NewInstanceTracker(&vmhost.WarmInstanceCacheConfig{Enabled: true, RestoreWarmInstances: true})
+	require.Nil(t, err)
+
+	instance := &instanceWithGlobals{
+		InstanceMock: mock.NewInstanceMock([]byte("code")),
+		globals:      []uint64{7, 9},
+	}
+	copy(instance.GetMemory().Data(), "abc")
+	iTracker.SetNewInstance(instance, Bytecode)
+	iTracker.codeHash = []byte("code")
+	iTracker.SaveAsWarmInstance()
+
+	// the snapshot is counted in the size of the warm instance
+	stats := iTracker.GetWarmInstanceCacheStats()
+	require.Equal(t, uint64(2*2*65536+2*8), stats.WarmInstancesSizeInBytes)
+
+	copy(instance.GetMemory().Data(), "zzz")
+	instance.globals[0] = 8
+	require.True(t, iTracker.UseWarmInstance([]byte("code"), false))
+	require.Equal(t, []byte("abc"), instance.GetMemory().Data()[:3])
+	require.Equal(t, []uint64{7, 9}, instance.globals)
+
+	// the warm instance is discarded when its memory grew, as it cannot shrink back to the snapshot
+	require.Nil(t, instance.GetMemory().Grow(1))
+	require.False(t, iTracker.UseWarmInstance([]byte("code"), false))
+	require.False(t, iTracker.warmInstanceCache.Has([]byte("code")))
+	require.Empty(t, iTracker.warmInstanceSnapshots)
+	require.True(t, instance.AlreadyCleaned())
+
+	stats = iTracker.GetWarmInstanceCacheStats()
+	require.Equal(t, uint64(1), stats.Hits)
+	require.Equal(t, uint64(1), stats.Misses)
+	require.Equal(t, uint64(0), stats.Evictions)
+	require.Equal(t, uint64(0), stats.WarmInstancesSizeInBytes)
+}
+
+func TestInstanceTracker_WarmInstanceSnapshotDisabled(t *testing.T) {
+	iTracker, err := NewInstanceTracker(&vmhost.WarmInstanceCacheConfig{Enabled: true})
+	require.Nil(t, err)
+
+	instance := mock.NewInstanceMock([]byte("code"))
+	iTracker.SetNewInstance(instance, Bytecode)
+	iTracker.codeHash = []byte("code")
+	iTracker.SaveAsWarmInstance()
+	require.Empty(t, iTracker.warmInstanceSnapshots)
+
+	copy(instance.GetMemory().Data(), "zzz")
+	require.True(t, iTracker.UseWarmInstance([]byte("code"), false))
+	require.Equal(t, []byte("zzz"), instance.GetMemory().Data()[:3])
+}
+
+func TestInstanceTracker_RejectWarmInstance(t *testing.T) {
+	iTracker, err := NewInstanceTracker(&vmhost.WarmInstanceCacheConfig{Enabled: true, VerifyWarmInstances: true})
+	require.Nil(t, err)
+	require.True(t, iTracker.IsWarmInstanceVerificationEnabled())
+
+	instance := mock.NewInstanceMock([]byte("code"))
+	iTracker.SetNewInstance(instance, Bytecode)
+	iTracker.codeHash = []byte("code")
+	iTracker.SaveAsWarmInstance()
+
+	// only the warm instances can be rejected
+	iTracker.RejectWarmInstance()
+	require.True(t, iTracker.warmInstanceCache.Has([]byte("code")))
+
+	require.True(t, iTracker.UseWarmInstance([]byte("code"), false))
+	iTracker.RejectWarmInstance()
+	require.Nil(t, iTracker.Instance())
+	require.Equal(t, []byte("code"), iTracker.CodeHash())
+	require.False(t, iTracker.warmInstanceCache.Has([]byte("code")))
+	require.True(t, instance.AlreadyCleaned())
+
+	stats := iTracker.GetWarmInstanceCacheStats()
+	require.Equal(t, uint64(0), stats.Hits)
+	require.Equal(t, uint64(1), stats.Misses)
+	require.Equal(t, uint64(1), stats.StateMismatches)
+	require.Equal(t, uint64(0), stats.Evictions)
+
+	iTracker, err = NewInstanceTracker(&vmhost.WarmInstanceCacheConfig{Enabled: false, VerifyWarmInstances: true})
+	require.Nil(t, err)
+	require.False(t, iTracker.IsWarmInstanceVerificationEnabled())
+}
+
+func TestInstanceTracker_InitialMemoryPages(t *testing.T) {
+	iTracker, err := NewInstanceTracker(&vmhost.WarmInstanceCacheConfig{Enabled: true})
+	require.Nil(t, err)
+
+	instance := mock.NewInstanceMock([]byte("code"))
+	iTracker.SetNewInstance(instance, Bytecode)
+	iTracker.codeHash = []byte("code")
+	iTracker.SaveAsWarmInstance()
+	require.Equal(t, uint32(2), iTracker.InitialMemoryPages())
+
+	// the warm instance keeps its grown memory, but not its initial pages
+	require.Nil(t, instance.GetMemory().Grow(3))
+	iTracker.PushState()
+	otherInstance := mock.NewInstanceMock([]byte("other"))
+	require.Nil(t, otherInstance.GetMemory().Grow(1))
+	iTracker.SetNewInstance(otherInstance, Bytecode)
+	require.Equal(t, uint32(3), iTracker.InitialMemoryPages())
+	iTracker.PopSetActiveState()
+	require.Equal(t, uint32(2), iTracker.InitialMemoryPages())
+
+	iTracker.UnsetInstance()
+	require.True(t, iTracker.UseWarmInstance([]byte("code"), false))
+	require.Equal(t, uint32(2), iTracker.InitialMemoryPages())
+}
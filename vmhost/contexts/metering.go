@@ -23,8 +23,15 @@ type meteringContext struct {
 	gasForExecution    uint64
 	gasUsedByAccounts  map[string]uint64
 
-	gasTracer       vmhost.GasTracing
-	traceGasEnabled bool
+	// gasRefundedByBuiltins is kept across nested calls, like the gas trace
+	gasRefundedByBuiltins uint64
+
+	gasTracer                 vmhost.GasTracing
+	traceGasEnabled           bool
+	callTreeGasTracingEnabled bool
+
+	gasInvariantChecker         vmhost.GasInvariantChecking
+	gasInvariantCheckingEnabled bool
 }
 
 // NewMeteringContext creates a new meteringContext
@@ -45,6 +52,8 @@ func NewMeteringContext(
 		gasSchedule:       gasSchedule,
 		blockGasLimit:     blockGasLimit,
 		gasUsedByAccounts: make(map[string]uint64),
+
+		gasInvariantCheckingEnabled: gasInvariantCheckingByDefault,
 	}
 
 	context.InitState()
@@ -60,13 +69,34 @@ func (context *meteringContext) InitState() {
 	context.gasForExecution = 0
 	context.gasUsedByAccounts = make(map[string]uint64)
 
-	var newGasTracer vmhost.GasTracing
+	// the call tree is kept across nested calls, so that it covers the whole execution, while the
+	// flat gas trace alone is restarted by each nested call, as it always was
+	if len(context.stateStack) == 0 || !context.callTreeGasTracingEnabled {
+		context.gasTracer = context.newGasTracer()
+	}
+	if len(context.stateStack) == 0 {
+		context.gasRefundedByBuiltins = 0
+		context.gasInvariantChecker = context.newGasInvariantChecker()
+	}
+}
+
+func (context *meteringContext) newGasInvariantChecker() vmhost.GasInvariantChecking {
+	if context.gasInvariantCheckingEnabled {
+		return NewEnabledGasInvariantChecker()
+	}
+
+	return NewDisabledGasInvariantChecker()
+}
+
+func (context *meteringContext) newGasTracer() vmhost.GasTracing {
+	if context.callTreeGasTracingEnabled {
+		return NewCallTreeGasTracer(context.GasLeft)
+	}
 	if context.traceGasEnabled {
-		newGasTracer = NewEnabledGasTracer()
-	} else {
-		newGasTracer = NewDisabledGasTracer()
+		return NewEnabledGasTracer()
 	}
-	context.gasTracer = newGasTracer
+
+	return NewDisabledGasTracer()
 }
 
 // InitStateFromContractCallInput initializes the internal state of the
@@ -76,6 +106,14 @@ func (context *meteringContext) InitStateFromContractCallInput(input *vmcommon.V
 	context.unlockGasIfAsyncCallback(input)
 	context.initialGasProvided = input.GasProvided
 	context.gasForExecution = input.GasProvided
+
+	runtime := context.host.Runtime()
+	function := ""
+	callInput := runtime.GetVMInput()
+	if callInput != nil {
+		function = callInput.Function
+	}
+	context.gasInvariantChecker.BeginFrame(runtime.GetContextAddress(), function, input.CallType, input.GasProvided)
 }
 
 // PushState pushes the current state of the MeteringContext on its internal state stack
@@ -88,6 +126,7 @@ func (context *meteringContext) PushState() {
 	}
 
 	context.stateStack = append(context.stateStack, newState)
+	context.gasInvariantChecker.SuspendFrame(context.GasLeft())
 }
 
 // PopSetActiveState pops the state at the top of the internal state stack, and
@@ -98,6 +137,8 @@ func (context *meteringContext) PopSetActiveState() {
 		return
 	}
 
+	context.gasInvariantChecker.EndFrame("PopSetActiveState", context.GasSpentByContract(), false)
+
 	prevState := context.stateStack[stateStackLen-1]
 	context.stateStack = context.stateStack[:stateStackLen-1]
 
@@ -114,6 +155,7 @@ func (context *meteringContext) PopDiscard() {
 		return
 	}
 
+	context.gasInvariantChecker.EndFrame("PopDiscard", context.GasSpentByContract(), false)
 	context.stateStack = context.stateStack[:stateStackLen-1]
 }
 
@@ -125,6 +167,8 @@ func (context *meteringContext) PopMergeActiveState() {
 		return
 	}
 
+	context.gasInvariantChecker.EndFrame("PopMergeActiveState", context.GasSpentByContract(), true)
+
 	prevState := context.stateStack[stateStackLen-1]
 	context.stateStack = context.stateStack[:stateStackLen-1]
 
@@ -217,13 +261,21 @@ func (context *meteringContext) TrackGasUsedByBuiltinFunction(
 	}
 
 	context.UseGas(gasUsed)
+	context.gasRefundedByBuiltins = math.AddUint64(context.gasRefundedByBuiltins, builtinOutput.GasRemaining)
 	logMetering.Trace("gas used by builtin function", "gas", gasUsed)
 }
 
+// GetGasRefundedByBuiltins returns the gas returned by the builtin functions to their callers
+// during the current execution, including the nested calls
+func (context *meteringContext) GetGasRefundedByBuiltins() uint64 {
+	return context.gasRefundedByBuiltins
+}
+
 func (context *meteringContext) checkGas(vmOutput *vmcommon.VMOutput) error {
 	gasUsed := context.getCurrentTotalUsedGas()
 	totalGas := math.AddUint64(gasUsed, vmOutput.GasRemaining)
 	gasProvided := context.GetGasProvided()
+	context.gasInvariantChecker.CheckOutputGas(totalGas)
 
 	if totalGas != gasProvided {
 		logOutput.Error("gas usage mismatch", "total gas", totalGas, "gas provided", gasProvided)
@@ -339,6 +391,7 @@ func (context *meteringContext) UseAndTraceGas(gas uint64) {
 func (context *meteringContext) UseGasAndAddTracedGas(functionName string, gas uint64) {
 	context.UseGas(gas)
 	context.addToGasTrace(functionName, gas)
+	context.gasInvariantChecker.SetLastHook(functionName)
 }
 
 // GetGasTrace returns the gasTrace map
@@ -346,6 +399,11 @@ func (context *meteringContext) GetGasTrace() map[string]map[string][]uint64 {
 	return context.gasTracer.GetGasTrace()
 }
 
+// GetGasCallTree returns the gas call tree recorded for the current execution
+func (context *meteringContext) GetGasCallTree() []*vmhost.GasTraceFrame {
+	return context.gasTracer.GetCallTree()
+}
+
 // RestoreGas subtracts the given gas from the gas used that is set in the runtime context.
 func (context *meteringContext) RestoreGas(gas uint64) {
 	gasUsed := context.host.Runtime().GetPointsUsed()
@@ -515,16 +573,38 @@ func (context *meteringContext) deductInitialGas(
 // SetGasTracing enables/disables gas tracing
 func (context *meteringContext) SetGasTracing(enableGasTracing bool) {
 	context.traceGasEnabled = enableGasTracing
-	if context.traceGasEnabled {
-		context.gasTracer = NewEnabledGasTracer()
-	} else {
-		context.gasTracer = NewDisabledGasTracer()
-	}
+	context.gasTracer = context.newGasTracer()
+}
+
+// SetCallTreeGasTracing enables/disables the recording of the gas call tree, which also
+// maintains the flat gas trace
+func (context *meteringContext) SetCallTreeGasTracing(enableCallTreeGasTracing bool) {
+	context.callTreeGasTracingEnabled = enableCallTreeGasTracing
+	context.gasTracer = context.newGasTracer()
+}
+
+// SetGasInvariantChecking enables or disables the checking of the conservation of gas at
+// the boundaries of the call stack frames, starting with the next execution
+func (context *meteringContext) SetGasInvariantChecking(enableGasInvariantChecking bool) {
+	context.gasInvariantCheckingEnabled = enableGasInvariantChecking
+}
+
+// GetGasInvariantViolation returns the first gas imbalance found during the current execution,
+// or nil if none was found or the checking is disabled
+func (context *meteringContext) GetGasInvariantViolation() *vmhost.GasInvariantViolation {
+	return context.gasInvariantChecker.GetViolation()
+}
+
+// SetGasTraceFrameMemoryPages records the memory pages of the contract call frame of the gas
+// call tree which is currently open
+func (context *meteringContext) SetGasTraceFrameMemoryPages(pages uint32) {
+	context.gasTracer.SetFrameMemoryPages(pages)
 }
 
 // StartGasTracing sets initial trace for the upcoming gas usage.
 func (context *meteringContext) StartGasTracing(functionName string) {
-	if context.traceGasEnabled {
+	context.gasInvariantChecker.SetLastHook(functionName)
+	if context.traceGasEnabled || context.callTreeGasTracingEnabled {
 		scAddress := context.getSCAddress()
 		if len(scAddress) != 0 {
 			context.gasTracer.BeginTrace(scAddress, functionName)
@@ -532,6 +612,16 @@ func (context *meteringContext) StartGasTracing(functionName string) {
 	}
 }
 
+// BeginGasTraceFrame opens a frame of the gas call tree for the current contract
+func (context *meteringContext) BeginGasTraceFrame(kind vmhost.GasTraceFrameKind, name string) {
+	context.gasTracer.BeginFrame(kind, context.getSCAddress(), name)
+}
+
+// EndGasTraceFrame closes the frame of the gas call tree opened last
+func (context *meteringContext) EndGasTraceFrame() {
+	context.gasTracer.EndFrame()
+}
+
 func (context *meteringContext) traceGas(usedGas uint64) {
 	context.gasTracer.AddToCurrentTrace(usedGas)
 }
@@ -569,3 +569,93 @@ func TestMeteringContext_GasTracer(t *testing.T) {
 	require.Equal(t, gasUsed2, gasTrace["scAddress2"]["function2"][0])
 
 }
+
+func TestMeteringContext_GasTracerOnNestedCalls(t *testing.T) {
+	t.Parallel()
+
+	mockRuntime := &contextmock.RuntimeContextMock{
+		SCAddress: []byte("parent"),
+	}
+	host := &contextmock.VMHostMock{
+		RuntimeContext: mockRuntime,
+	}
+
+	startNestedCall := func(metering *meteringContext) {
+		metering.InitState()
+		metering.gasForExecution = 10000
+		metering.StartGasTracing("parentFunction")
+		metering.UseAndTraceGas(1000)
+
+		metering.PushState()
+		mockRuntime.SCAddress = []byte("child")
+		metering.InitState()
+		metering.gasForExecution = 5000
+		metering.StartGasTracing("childFunction")
+		metering.UseAndTraceGas(500)
+	}
+
+	t.Run("flat trace restarted by the nested call", func(t *testing.T) {
+		metering, _ := NewMeteringContext(host, config.MakeGasMapForTests(), uint64(100000))
+		metering.SetGasTracing(true)
+		mockRuntime.SCAddress = []byte("parent")
+
+		startNestedCall(metering)
+		gasTrace := metering.GetGasTrace()
+		require.Equal(t, map[string]map[string][]uint64{
+			"child": {"childFunction": {500}},
+		}, gasTrace)
+	})
+	t.Run("call tree kept across the nested call", func(t *testing.T) {
+		metering, _ := NewMeteringContext(host, config.MakeGasMapForTests(), uint64(100000))
+		metering.SetCallTreeGasTracing(true)
+		mockRuntime.SCAddress = []byte("parent")
+
+		startNestedCall(metering)
+		gasTrace := metering.GetGasTrace()
+		require.Equal(t, []uint64{1000}, gasTrace["parent"]["parentFunction"])
+		require.Equal(t, []uint64{500}, gasTrace["child"]["childFunction"])
+	})
+}
+
+func TestMeteringContext_GasInvariantChecking(t *testing.T) {
+	t.Parallel()
+
+	mockRuntime := &contextmock.RuntimeContextMock{
+		SCAddress: []byte("parent"),
+		VMInput:   &vmcommon.ContractCallInput{Function: "parentFunction"},
+	}
+	host := &contextmock.VMHostMock{
+		RuntimeContext: mockRuntime,
+	}
+
+	metering, _ := NewMeteringContext(host, config.MakeGasMapForTests(), uint64(100000))
+	host.MeteringContext = metering
+	zeroCodeCosts(metering)
+
+	metering.SetGasInvariantChecking(true)
+	metering.InitState()
+	metering.InitStateFromContractCallInput(&vmcommon.VMInput{GasProvided: 10000})
+	metering.UseGasAndAddTracedGas("parentHook", 1000)
+	require.Nil(t, metering.GetGasInvariantViolation())
+
+	metering.PushState()
+	mockRuntime.SCAddress = []byte("child")
+	mockRuntime.VMInput = &vmcommon.ContractCallInput{Function: "childFunction"}
+	metering.InitStateFromContractCallInput(&vmcommon.VMInput{GasProvided: 20000})
+	metering.StartGasTracing("childHook")
+	metering.PopSetActiveState()
+
+	violation := metering.GetGasInvariantViolation()
+	require.NotNil(t, violation)
+	require.Equal(t, "PopSetActiveState", violation.Boundary)
+	require.Equal(t, 1, violation.Depth)
+	require.Equal(t, []byte("child"), violation.Address)
+	require.Equal(t, "childFunction", violation.Function)
+	require.Equal(t, "childHook", violation.LastHook)
+	require.Equal(t, uint64(9000), violation.Expected)
+	require.Equal(t, uint64(20000), violation.Actual)
+
+	metering.SetGasInvariantChecking(false)
+	metering.InitState()
+	require.Nil(t, metering.GetGasInvariantViolation())
+}
@@ -0,0 +1,116 @@
+package contexts
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"sync/atomic"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/wasmer"
+)
+
+// opcodeTraceFileInUse is set while a profiler of the process owns the opcode trace file, which
+// Wasmer always writes under the same name in the working directory
+var opcodeTraceFileInUse int32
+
+// opcodeProfiler collects the opcode traces written by Wasmer for the instances of the
+// profiled contracts, accumulating them into one OpcodeProfile per contract; as the trace file
+// is shared by all the traced instances, a single profiler of the process owns it and only one
+// instance is traced at a time, the profiled contracts called while it runs not being traced
+type opcodeProfiler struct {
+	contracts      map[string]struct{}
+	profiles       map[string]*vmhost.OpcodeProfile
+	traceFilePath  string
+	ownsTraceFile  bool
+	tracedInstance wasmer.InstanceHandler
+}
+
+func newDisabledOpcodeProfiler() *opcodeProfiler {
+	return &opcodeProfiler{
+		contracts:     make(map[string]struct{}),
+		profiles:      make(map[string]*vmhost.OpcodeProfile),
+		traceFilePath: wasmer.OpcodeTraceFileName,
+	}
+}
+
+func newOpcodeProfiler(contractAddresses [][]byte) (*opcodeProfiler, error) {
+	profiler := newDisabledOpcodeProfiler()
+	for _, address := range contractAddresses {
+		profiler.contracts[string(address)] = struct{}{}
+	}
+	if len(profiler.contracts) == 0 {
+		return profiler, nil
+	}
+
+	if !atomic.CompareAndSwapInt32(&opcodeTraceFileInUse, 0, 1) {
+		return nil, vmhost.ErrOpcodeProfilingInUse
+	}
+	profiler.ownsTraceFile = true
+
+	return profiler, nil
+}
+
+// release gives up the opcode trace file, for another profiler of the process to take it
+func (profiler *opcodeProfiler) release() {
+	if profiler.ownsTraceFile {
+		profiler.ownsTraceFile = false
+		atomic.StoreInt32(&opcodeTraceFileInUse, 0)
+	}
+}
+
+func (profiler *opcodeProfiler) isProfiled(address []byte) bool {
+	_, ok := profiler.contracts[string(address)]
+	return ok
+}
+
+// shouldTrace returns true if the contract is profiled and no traced instance is running,
+// in which case the instance of the contract is to be traced
+func (profiler *opcodeProfiler) shouldTrace(address []byte) bool {
+	if !profiler.isProfiled(address) {
+		return false
+	}
+
+	return check.IfNil(profiler.tracedInstance) || profiler.tracedInstance.AlreadyCleaned()
+}
+
+func (profiler *opcodeProfiler) startTracing(instance wasmer.InstanceHandler) {
+	profiler.tracedInstance = instance
+}
+
+func (profiler *opcodeProfiler) isTraced(instance wasmer.InstanceHandler) bool {
+	return !check.IfNil(profiler.tracedInstance) && profiler.tracedInstance == instance
+}
+
+// collectTrace reads and removes the opcode trace file, adding its opcodes to the profile of
+// the given contract; a missing trace file means there is nothing to collect
+func (profiler *opcodeProfiler) collectTrace(address []byte, opcodeCosts [wasmer.OpcodeCount]uint32) error {
+	traceFile, err := os.Open(profiler.traceFilePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	counts, err := wasmer.ReadOpcodeTrace(traceFile)
+	_ = traceFile.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(profiler.traceFilePath)
+	if err != nil {
+		return err
+	}
+
+	profile, ok := profiler.profiles[string(address)]
+	if !ok {
+		profile = &vmhost.OpcodeProfile{SCAddress: []byte(string(address))}
+		profiler.profiles[string(address)] = profile
+	}
+	profile.AddCounts(counts, opcodeCosts)
+
+	return nil
+}
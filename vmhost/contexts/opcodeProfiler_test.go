@@ -0,0 +1,92 @@
+package contexts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	mock "github.com/multiversx/mx-chain-vm-v1_4-go/mock/context"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/wasmer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpcodeProfiler_IsProfiled(t *testing.T) {
+	profiler, err := newOpcodeProfiler([][]byte{[]byte("contract1")})
+	require.Nil(t, err)
+	require.True(t, profiler.isProfiled([]byte("contract1")))
+	require.False(t, profiler.isProfiled([]byte("contract2")))
+	profiler.release()
+
+	profiler, err = newOpcodeProfiler(nil)
+	require.Nil(t, err)
+	require.False(t, profiler.isProfiled([]byte("contract1")))
+}
+
+func TestOpcodeProfiler_SingleOwnerOfTheTraceFile(t *testing.T) {
+	profiler, err := newOpcodeProfiler([][]byte{[]byte("contract1")})
+	require.Nil(t, err)
+
+	_, err = newOpcodeProfiler([][]byte{[]byte("contract2")})
+	require.Equal(t, vmhost.ErrOpcodeProfilingInUse, err)
+
+	disabledProfiler, err := newOpcodeProfiler(nil)
+	require.Nil(t, err)
+	disabledProfiler.release()
+
+	profiler.release()
+	profiler, err = newOpcodeProfiler([][]byte{[]byte("contract2")})
+	require.Nil(t, err)
+	profiler.release()
+}
+
+func TestOpcodeProfiler_SingleTracedInstance(t *testing.T) {
+	profiler, err := newOpcodeProfiler([][]byte{[]byte("contract1"), []byte("contract2")})
+	require.Nil(t, err)
+	defer profiler.release()
+
+	require.True(t, profiler.shouldTrace([]byte("contract1")))
+	require.False(t, profiler.shouldTrace([]byte("contract3")))
+
+	tracedInstance := mock.NewInstanceMock(nil)
+	profiler.startTracing(tracedInstance)
+	require.True(t, profiler.isTraced(tracedInstance))
+	require.False(t, profiler.isTraced(mock.NewInstanceMock(nil)))
+	require.False(t, profiler.shouldTrace([]byte("contract2")))
+
+	tracedInstance.Clean()
+	require.True(t, profiler.shouldTrace([]byte("contract2")))
+}
+
+func TestOpcodeProfiler_CollectTrace(t *testing.T) {
+	profiler, err := newOpcodeProfiler([][]byte{[]byte("contract1")})
+	require.Nil(t, err)
+	defer profiler.release()
+	profiler.traceFilePath = filepath.Join(t.TempDir(), wasmer.OpcodeTraceFileName)
+
+	opcodeCosts := [wasmer.OpcodeCount]uint32{}
+	opcodeCosts[wasmer.OpcodeLocalGet] = 3
+	opcodeCosts[wasmer.OpcodeI32Add] = 1
+	opcodeCosts[wasmer.OpcodeCall] = 10
+
+	err = profiler.collectTrace([]byte("contract1"), opcodeCosts)
+	require.Nil(t, err)
+	require.Len(t, profiler.profiles, 0)
+
+	trace := "LocalGet { local_index: 0 }\nLocalGet { local_index: 1 }\nI32Add\nCall { function_index: 2 }\nUnknownOpcode\n"
+	err = os.WriteFile(profiler.traceFilePath, []byte(trace), 0644)
+	require.Nil(t, err)
+
+	err = profiler.collectTrace([]byte("contract1"), opcodeCosts)
+	require.Nil(t, err)
+	_, err = os.Stat(profiler.traceFilePath)
+	require.True(t, os.IsNotExist(err))
+
+	profile := profiler.profiles["contract1"]
+	require.Equal(t, []byte("contract1"), profile.SCAddress)
+	require.Equal(t, uint64(2), profile.Counts[wasmer.OpcodeLocalGet])
+	require.Equal(t, uint64(6), profile.Gas[wasmer.OpcodeLocalGet])
+	require.Equal(t, uint64(1), profile.Counts[wasmer.OpcodeI32Add])
+	require.Equal(t, uint64(1), profile.Counts[wasmer.OpcodeCall])
+	require.Equal(t, uint64(17), profile.TotalGas())
+}
@@ -9,10 +9,12 @@ import (
 	"unsafe"
 
 	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/vm"
 	logger "github.com/multiversx/mx-chain-logger-go"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 	"github.com/multiversx/mx-chain-vm-v1_4-go/math"
 	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/compiledstore"
 	"github.com/multiversx/mx-chain-vm-v1_4-go/wasmer"
 )
 
@@ -20,9 +22,7 @@ var logRuntime = logger.GetOrCreate("vm/runtime")
 
 var _ vmhost.RuntimeContext = (*runtimeContext)(nil)
 
-const warmCacheSize = 100
-
-// WarmInstancesEnabled controls the usage of warm instances
+// WarmInstancesEnabled controls the usage of warm instances when the host parameters do not configure the warm instance cache
 const WarmInstancesEnabled = true
 
 // HashComputer provides hash computation
@@ -50,9 +50,16 @@ type runtimeContext struct {
 	asyncCallInfo    *vmhost.AsyncCallInfo
 	asyncContextInfo *vmhost.AsyncContextInfo
 
-	validator       *wasmValidator
-	instanceBuilder vmhost.InstanceBuilder
-	hasher          vmhost.HashComputer
+	validator         *wasmValidator
+	instanceBuilder   vmhost.InstanceBuilder
+	hasher            vmhost.HashComputer
+	coverageTracker   vmhost.CoverageTracker
+	opcodeProfiler    *opcodeProfiler
+	memoryUsage       *vmhost.MemoryUsage
+	compiledCodeStore vmhost.CompiledCodeStore
+
+	// warmInstancesFingerprint is the compilation fingerprint of the warm instances
+	warmInstancesFingerprint []byte
 
 	errors vmhost.WrappableError
 }
@@ -63,6 +70,9 @@ func NewRuntimeContext(
 	vmType []byte,
 	builtInFuncContainer vmcommon.BuiltInFunctionContainer,
 	hasher vmhost.HashComputer,
+	warmInstanceCacheConfig *vmhost.WarmInstanceCacheConfig,
+	compiledCodeStore vmhost.CompiledCodeStore,
+	instanceBuilder vmhost.InstanceBuilder,
 ) (*runtimeContext, error) {
 
 	if check.IfNil(host) {
@@ -78,24 +88,34 @@ func NewRuntimeContext(
 		return nil, vmhost.ErrNilHasher
 	}
 
+	if check.IfNil(compiledCodeStore) {
+		compiledCodeStore = compiledstore.NewDisabledCompiledCodeStore()
+	}
+	if instanceBuilder == nil {
+		instanceBuilder = &WasmerInstanceBuilder{}
+	}
+
 	scAPINames := host.GetAPIMethods().Names()
 
 	context := &runtimeContext{
-		host:       host,
-		vmType:     vmType,
-		stateStack: make([]*runtimeContext, 0),
-		validator:  newWASMValidator(scAPINames, builtInFuncContainer),
-		hasher:     hasher,
-		errors:     nil,
-	}
-
-	iTracker, err := NewInstanceTracker()
+		host:              host,
+		vmType:            vmType,
+		stateStack:        make([]*runtimeContext, 0),
+		validator:         newWASMValidator(scAPINames, builtInFuncContainer),
+		hasher:            hasher,
+		coverageTracker:   NewDisabledCoverageTracker(),
+		opcodeProfiler:    newDisabledOpcodeProfiler(),
+		compiledCodeStore: compiledCodeStore,
+		errors:            nil,
+	}
+
+	iTracker, err := NewInstanceTracker(warmInstanceCacheConfig)
 	if err != nil {
 		return nil, err
 	}
 	context.iTracker = iTracker
 
-	context.instanceBuilder = &WasmerInstanceBuilder{}
+	context.instanceBuilder = instanceBuilder
 	context.InitState()
 
 	return context, nil
@@ -114,6 +134,7 @@ func (context *runtimeContext) InitState() {
 	}
 	context.iTracker.InitState()
 	context.errors = nil
+	context.memoryUsage = vmhost.NewMemoryUsage()
 
 	logRuntime.Trace("init state")
 }
@@ -124,6 +145,16 @@ func (context *runtimeContext) ClearWarmInstanceCache() {
 	context.iTracker.UnsetInstance()
 }
 
+// IsWarmInstanceCacheEnabled returns true if the instances are kept warm after their execution
+func (context *runtimeContext) IsWarmInstanceCacheEnabled() bool {
+	return context.iTracker.IsWarmInstanceCacheEnabled()
+}
+
+// GetWarmInstanceCacheStats returns the counters of the warm instance cache
+func (context *runtimeContext) GetWarmInstanceCacheStats() vmhost.WarmInstanceCacheStats {
+	return context.iTracker.GetWarmInstanceCacheStats()
+}
+
 // ReplaceInstanceBuilder replaces the instance builder, allowing the creation
 // of mocked Wasmer instances; this is used for tests only
 func (context *runtimeContext) ReplaceInstanceBuilder(builder vmhost.InstanceBuilder) {
@@ -155,7 +186,12 @@ func (context *runtimeContext) StartWasmerInstance(contract []byte, gasLimit uin
 		logRuntime.Trace("code was new", "new", newCode)
 	}()
 
-	warmInstanceUsed := context.useWarmInstanceIfExists(gasLimit, newCode)
+	if context.opcodeProfiler.shouldTrace(context.codeAddress) {
+		return context.makeProfiledInstanceFromContractByteCode(contract, gasLimit, newCode)
+	}
+
+	context.clearStaleWarmInstances()
+	warmInstanceUsed := context.useWarmInstanceIfExists(contract, gasLimit, newCode)
 	if warmInstanceUsed {
 		return nil
 	}
@@ -168,29 +204,84 @@ func (context *runtimeContext) StartWasmerInstance(contract []byte, gasLimit uin
 	return context.makeInstanceFromContractByteCode(contract, gasLimit, newCode)
 }
 
+// PrewarmInstance creates the instance of the target outside any execution and saves it as a warm
+// instance, from its compiled code if stored, otherwise from its bytecode; it returns true if the
+// instance was already warm. The code hash of the account is used when the target has an address.
+func (context *runtimeContext) PrewarmInstance(target vmhost.PrewarmTarget) (bool, error) {
+	if !context.iTracker.IsWarmInstanceCacheEnabled() {
+		return false, vmhost.ErrWarmInstanceCacheDisabled
+	}
+
+	blockchain := context.host.Blockchain()
+	codeHash := target.CodeHash
+	if len(target.Address) > 0 {
+		codeHash = blockchain.GetCodeHash(target.Address)
+		if len(codeHash) == 0 {
+			return false, vmhost.ErrContractNotFound
+		}
+	}
+	if len(codeHash) == 0 {
+		return false, vmhost.ErrInvalidPrewarmTarget
+	}
+
+	context.clearStaleWarmInstances()
+	if context.iTracker.warmInstanceCache.Has(codeHash) {
+		return true, nil
+	}
+
+	if context.RunningInstancesCount() >= context.maxWasmerInstances {
+		return false, vmhost.ErrMaxInstancesReached
+	}
+
+	var code []byte
+	if len(target.Address) > 0 {
+		var err error
+		code, err = blockchain.GetCode(target.Address)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	context.iTracker.UnsetInstance()
+	context.iTracker.SetCodeSize(uint64(len(code)))
+	context.iTracker.SetCodeHash(codeHash)
+	defer context.iTracker.UnsetInstance()
+
+	compiledCodeUsed := context.makeInstanceFromCompiledCode(0, false)
+	if !compiledCodeUsed {
+		if len(code) == 0 {
+			return false, vmhost.ErrContractNotFound
+		}
+
+		err := context.makeInstanceFromContractByteCode(code, 0, false)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if !context.iTracker.warmInstanceCache.Has(codeHash) {
+		// the compiled code could not be cached, so the instance was not saved as warm
+		context.iTracker.ForceCleanInstance(true)
+		return false, vmhost.ErrContractInvalid
+	}
+
+	logRuntime.Trace("prewarm instance", "codeHash", codeHash, "compiled code", compiledCodeUsed)
+	return false, nil
+}
+
 func (context *runtimeContext) makeInstanceFromCompiledCode(gasLimit uint64, newCode bool) bool {
 	codeHash := context.iTracker.CodeHash()
 	if newCode || len(codeHash) == 0 {
 		return false
 	}
 
-	blockchain := context.host.Blockchain()
-	found, compiledCode := blockchain.GetCompiledCode(codeHash)
+	compiledCode, found := context.getCompiledCode(codeHash)
 	if !found {
 		logRuntime.Trace("instance creation", "code", "cached compilation", "error", "compiled code was not found")
 		return false
 	}
 
-	gasSchedule := context.host.Metering().GasSchedule()
-	options := wasmer.CompilationOptions{
-		GasLimit:           gasLimit,
-		UnmeteredLocals:    uint64(gasSchedule.WASMOpcodeCost.LocalsUnmetered),
-		MaxMemoryGrow:      uint64(gasSchedule.WASMOpcodeCost.MaxMemoryGrow),
-		MaxMemoryGrowDelta: uint64(gasSchedule.WASMOpcodeCost.MaxMemoryGrowDelta),
-		OpcodeTrace:        false,
-		Metering:           true,
-		RuntimeBreakpoints: true,
-	}
+	options := context.compilationOptions(gasLimit, false)
 	newInstance, err := context.instanceBuilder.NewInstanceFromCompiledCodeWithOptions(compiledCode, options)
 	if err != nil {
 		logRuntime.Error("instance creation", "code", "cached compilation", "error", err)
@@ -199,8 +290,7 @@ func (context *runtimeContext) makeInstanceFromCompiledCode(gasLimit uint64, new
 
 	context.iTracker.SetNewInstance(newInstance, Precompiled)
 
-	hostReference := uintptr(unsafe.Pointer(&context.host))
-	context.iTracker.Instance().SetContextData(hostReference)
+	context.setInstanceHostReference()
 	context.verifyCode = false
 
 	context.saveWarmInstance()
@@ -212,16 +302,45 @@ func (context *runtimeContext) makeInstanceFromCompiledCode(gasLimit uint64, new
 }
 
 func (context *runtimeContext) makeInstanceFromContractByteCode(contract []byte, gasLimit uint64, newCode bool) error {
+	err := context.makeInstanceWithOptions(contract, gasLimit, newCode, false)
+	if err != nil {
+		return err
+	}
+
+	context.saveCompiledCode()
+
+	return nil
+}
+
+// makeProfiledInstanceFromContractByteCode always compiles the contract with opcode tracing,
+// keeping the traced instance out of the compiled code cache and of the warm instances
+func (context *runtimeContext) makeProfiledInstanceFromContractByteCode(contract []byte, gasLimit uint64, newCode bool) error {
+	err := context.makeInstanceWithOptions(contract, gasLimit, newCode, true)
+	if err != nil {
+		return err
+	}
+
+	context.opcodeProfiler.startTracing(context.iTracker.Instance())
+	context.collectOpcodeTrace()
+
+	return nil
+}
+
+func (context *runtimeContext) compilationOptions(gasLimit uint64, opcodeTrace bool) wasmer.CompilationOptions {
 	gasSchedule := context.host.Metering().GasSchedule()
-	options := wasmer.CompilationOptions{
+	return wasmer.CompilationOptions{
 		GasLimit:           gasLimit,
 		UnmeteredLocals:    uint64(gasSchedule.WASMOpcodeCost.LocalsUnmetered),
 		MaxMemoryGrow:      uint64(gasSchedule.WASMOpcodeCost.MaxMemoryGrow),
 		MaxMemoryGrowDelta: uint64(gasSchedule.WASMOpcodeCost.MaxMemoryGrowDelta),
-		OpcodeTrace:        false,
+		OpcodeTrace:        opcodeTrace,
 		Metering:           true,
 		RuntimeBreakpoints: true,
 	}
+}
+
+func (context *runtimeContext) makeInstanceWithOptions(contract []byte, gasLimit uint64, newCode bool, opcodeTrace bool) error {
+	options := context.compilationOptions(gasLimit, opcodeTrace)
 	newInstance, err := context.instanceBuilder.NewInstanceWithOptions(contract, options)
 	if err != nil {
 		context.iTracker.UnsetInstance()
@@ -236,8 +355,7 @@ func (context *runtimeContext) makeInstanceFromContractByteCode(contract []byte,
 		context.iTracker.SetCodeHash(codeHash)
 	}
 
-	hostReference := uintptr(unsafe.Pointer(&context.host))
-	context.iTracker.Instance().SetContextData(hostReference)
+	context.setInstanceHostReference()
 
 	if newCode {
 		err = context.VerifyContractCode()
@@ -252,14 +370,14 @@ func (context *runtimeContext) makeInstanceFromContractByteCode(contract []byte,
 		"from", "bytecode",
 		"id", context.iTracker.Instance().ID(),
 		"codeHash", context.iTracker.CodeHash(),
+		"opcodeTrace", opcodeTrace,
 	)
-	context.saveCompiledCode()
 
 	return nil
 }
 
-func (context *runtimeContext) useWarmInstanceIfExists(gasLimit uint64, newCode bool) bool {
-	if !WarmInstancesEnabled {
+func (context *runtimeContext) useWarmInstanceIfExists(contract []byte, gasLimit uint64, newCode bool) bool {
+	if !context.iTracker.IsWarmInstanceCacheEnabled() {
 		return false
 	}
 
@@ -277,17 +395,45 @@ func (context *runtimeContext) useWarmInstanceIfExists(gasLimit uint64, newCode
 		return false
 	}
 
+	if context.iTracker.IsWarmInstanceVerificationEnabled() && !context.verifyWarmInstance(contract) {
+		return false
+	}
+
 	context.SetPointsUsed(0)
 	context.iTracker.Instance().SetGasLimit(gasLimit)
 	context.SetRuntimeBreakpointValue(vmhost.BreakpointNone)
 
-	hostReference := uintptr(unsafe.Pointer(&context.host))
-	context.iTracker.Instance().SetContextData(hostReference)
+	context.setInstanceHostReference()
 	context.verifyCode = false
 	logRuntime.Trace("start instance", "from", "warm", "id", context.iTracker.Instance().ID())
 	return true
 }
 
+// verifyWarmInstance compares the active warm instance, just reset, with a fresh instance compiled from
+// the contract; a warm instance whose state differs is rejected, to be replaced by a cold one
+func (context *runtimeContext) verifyWarmInstance(contract []byte) bool {
+	warmInstance := context.iTracker.Instance()
+	freshInstance, err := context.instanceBuilder.NewInstanceWithOptions(contract, context.compilationOptions(0, false))
+	if err != nil {
+		logRuntime.Debug("warm instance verification", "codeHash", context.iTracker.CodeHash(), "error", err)
+		return true
+	}
+	defer freshInstance.Clean()
+
+	err = compareInstanceStates(warmInstance, freshInstance)
+	if err == nil {
+		return true
+	}
+
+	logRuntime.Error("warm instance state differs from a fresh instance",
+		"id", warmInstance.ID(),
+		"codeHash", context.iTracker.CodeHash(),
+		"error", err,
+	)
+	context.iTracker.RejectWarmInstance()
+	return false
+}
+
 // GetSCCode returns the SC code of the current SC.
 func (context *runtimeContext) GetSCCode() ([]byte, error) {
 	blockchain := context.host.Blockchain()
@@ -317,11 +463,18 @@ func (context *runtimeContext) saveCompiledCode() {
 	}
 
 	codeHash := context.iTracker.CodeHash()
+	fingerprint := context.host.CompilationFingerprint()
+	compiledCodeKey := vmhost.CompiledCodeKey(codeHash, fingerprint)
 	blockchain := context.host.Blockchain()
-	blockchain.SaveCompiledCode(codeHash, compiledCode)
+	blockchain.SaveCompiledCode(compiledCodeKey, compiledCode)
 	logRuntime.Trace("save compiled code", "codeHash", codeHash)
 
-	found, _ := blockchain.GetCompiledCode(codeHash)
+	err = context.compiledCodeStore.SaveCompiledCode(codeHash, fingerprint, compiledCode)
+	if err != nil {
+		logRuntime.Debug("save compiled code to store", "codeHash", codeHash, "error", err)
+	}
+
+	found, _ := blockchain.GetCompiledCode(compiledCodeKey)
 	if !found {
 		logRuntime.Trace("save compiled code silent fail, code hash not found")
 	}
@@ -329,8 +482,54 @@ func (context *runtimeContext) saveCompiledCode() {
 	context.saveWarmInstance()
 }
 
+// getCompiledCode looks up the compiled code saved through the blockchain hook, then in the compiled code store;
+// both are keyed on the compilation fingerprint as well, so the compiled codes produced under other opcode costs
+// are not found, to be recompiled from the bytecode
+func (context *runtimeContext) getCompiledCode(codeHash []byte) ([]byte, bool) {
+	fingerprint := context.host.CompilationFingerprint()
+	found, compiledCode := context.host.Blockchain().GetCompiledCode(vmhost.CompiledCodeKey(codeHash, fingerprint))
+	if found {
+		return compiledCode, true
+	}
+
+	compiledCode, found = context.compiledCodeStore.GetCompiledCode(codeHash, fingerprint)
+	if found {
+		logRuntime.Trace("instance creation", "code", "cached compilation", "from", "compiled code store")
+	}
+
+	return compiledCode, found
+}
+
+// clearStaleWarmInstances clears the warm instances if they were compiled under other opcode costs than the current ones
+func (context *runtimeContext) clearStaleWarmInstances() {
+	fingerprint := context.host.CompilationFingerprint()
+	if bytes.Equal(fingerprint, context.warmInstancesFingerprint) {
+		return
+	}
+
+	if context.warmInstancesFingerprint != nil {
+		logRuntime.Debug("clearing warm instances", "reason", "compilation fingerprint changed")
+		context.iTracker.ClearWarmInstanceCache()
+	}
+	context.warmInstancesFingerprint = fingerprint
+}
+
+// setInstanceHostReference passes the host to the EI functions called by the current instance; the
+// engines not going through cgo keep it as a pointer, the Wasmer instances as their context data
+func (context *runtimeContext) setInstanceHostReference() {
+	instance := context.iTracker.Instance()
+	handler, ok := instance.(vmhost.HostReferenceHandler)
+	if ok {
+		handler.SetHostReference(&context.host)
+		return
+	}
+
+	hostReference := uintptr(unsafe.Pointer(&context.host))
+	instance.SetContextData(hostReference)
+}
+
 func (context *runtimeContext) saveWarmInstance() {
-	if !WarmInstancesEnabled {
+	if !context.iTracker.IsWarmInstanceCacheEnabled() {
 		return
 	}
 
@@ -937,11 +1136,145 @@ func (context *runtimeContext) CallFunction(funcName string) error {
 		return vmhost.ErrFuncNotFound
 	}
 
+	context.trackFunctionCall(instance, funcName)
+
+	frameKind := vmhost.ContractCallFrame
+	if context.vmInput != nil && context.vmInput.CallType == vm.AsynchronousCallBack {
+		frameKind = vmhost.AsyncCallbackFrame
+	}
+
+	metering := context.host.Metering()
+	metering.BeginGasTraceFrame(frameKind, funcName)
+	initialPages := context.iTracker.InitialMemoryPages()
 	_, err := instance.CallFunction(funcName)
 
+	peakPages := memoryPages(instance)
+	context.memoryUsage.AddCall(&vmhost.CallMemoryUsage{
+		SCAddress:    context.codeAddress,
+		CodeHash:     context.iTracker.CodeHash(),
+		Function:     funcName,
+		InitialPages: initialPages,
+		PeakPages:    peakPages,
+	})
+	metering.SetGasTraceFrameMemoryPages(peakPages)
+	if err == nil {
+		err = context.useGasForMemoryGrowth(math.SubUint64(uint64(peakPages), uint64(initialPages)))
+	}
+	metering.EndGasTraceFrame()
+
+	if context.opcodeProfiler.isTraced(instance) {
+		context.collectOpcodeTrace()
+	}
+
 	return err
 }
 
+// useGasForMemoryGrowth charges the pages of linear memory grown by the call which just
+// finished, counted from the memory declared by the module, so that a warm instance keeping
+// the memory grown by its previous executions is charged like a fresh one; the call runs out
+// of gas if the gas left does not cover them
+func (context *runtimeContext) useGasForMemoryGrowth(grownPages uint64) error {
+	metering := context.host.Metering()
+	gasToUse := math.MulUint64(grownPages, metering.GasSchedule().BaseOperationCost.MemoryGrowPerPage)
+	if gasToUse == 0 {
+		return nil
+	}
+
+	err := metering.UseGasBounded(gasToUse)
+	if err != nil {
+		context.SetRuntimeBreakpointValue(vmhost.BreakpointOutOfGas)
+		return err
+	}
+
+	return nil
+}
+
+// GetMemoryUsage returns the linear memory used by the contract calls of the current execution
+func (context *runtimeContext) GetMemoryUsage() *vmhost.MemoryUsage {
+	return context.memoryUsage
+}
+
+func memoryPages(instance wasmer.InstanceHandler) uint32 {
+	memory := instance.GetMemory()
+	if check.IfNil(memory) {
+		return 0
+	}
+
+	return memory.Length() / vmhost.WASMPageSize
+}
+
+// SetOpcodeProfiling enables the opcode profiling of the given contracts, discarding the
+// profiles collected so far; their instances are compiled with opcode tracing, bypassing the
+// warm instances and the compiled code cache, and no contract is profiled if none is given.
+// Wasmer writes the opcode trace into the same file for all the instances, so a single host of
+// the process may profile at a time, until it disables the profiling or is closed, and the
+// profiled contracts called from within a traced instance run untraced
+func (context *runtimeContext) SetOpcodeProfiling(contractAddresses [][]byte) error {
+	context.opcodeProfiler.release()
+
+	profiler, err := newOpcodeProfiler(contractAddresses)
+	if err != nil {
+		context.opcodeProfiler = newDisabledOpcodeProfiler()
+		return err
+	}
+
+	context.opcodeProfiler = profiler
+	return nil
+}
+
+// GetOpcodeProfiles returns the opcode profiles collected for the profiled contracts, by address
+func (context *runtimeContext) GetOpcodeProfiles() map[string]*vmhost.OpcodeProfile {
+	return context.opcodeProfiler.profiles
+}
+
+func (context *runtimeContext) collectOpcodeTrace() {
+	gasSchedule := context.host.Metering().GasSchedule()
+	opcodeCosts := gasSchedule.WASMOpcodeCost.ToOpcodeCostsArray()
+	err := context.opcodeProfiler.collectTrace(context.codeAddress, opcodeCosts)
+	if err != nil {
+		logRuntime.Error("opcode profiling", "error", err)
+	}
+}
+
+// SetCoverageTracker replaces the tracker of the contract functions and EI functions
+// called during execution; a nil tracker disables coverage tracking
+func (context *runtimeContext) SetCoverageTracker(tracker vmhost.CoverageTracker) {
+	if check.IfNil(tracker) {
+		tracker = NewDisabledCoverageTracker()
+	}
+	_, isDisabled := tracker.(*disabledCoverageTracker)
+	if !isDisabled {
+		vmhost.EnableEICallTracking()
+	}
+	context.coverageTracker = tracker
+}
+
+// TrackEICall records a call of the given EI function made by the running contract
+func (context *runtimeContext) TrackEICall(eiFunctionName string) {
+	context.coverageTracker.TrackEICall(context.iTracker.CodeHash(), eiFunctionName)
+}
+
+func (context *runtimeContext) trackFunctionCall(instance wasmer.InstanceHandler, funcName string) {
+	codeHash := context.iTracker.CodeHash()
+	if !context.coverageTracker.IsContractTracked(codeHash) {
+		exportedFunctions := make([]string, 0)
+		for name := range instance.GetExports() {
+			exportedFunctions = append(exportedFunctions, name)
+		}
+
+		importedEIFunctions := make([]string, 0)
+		for name := range context.host.GetAPIMethods().Names() {
+			if instance.IsFunctionImported(name) {
+				importedEIFunctions = append(importedEIFunctions, name)
+			}
+		}
+
+		context.coverageTracker.TrackContract(codeHash, exportedFunctions, importedEIFunctions)
+	}
+
+	context.coverageTracker.TrackFunctionCall(codeHash, funcName)
+}
+
 // GetFunctionToCall returns the function to call from the wasmer instance exports.
 func (context *runtimeContext) GetFunctionToCall() (string, error) {
 	instance := context.iTracker.Instance()
@@ -1185,7 +1518,7 @@ func (context *runtimeContext) EndExecution() {
 
 // ValidateInstances checks the state of the instances after execution
 func (context *runtimeContext) ValidateInstances() error {
-	if !WarmInstancesEnabled {
+	if !context.iTracker.IsWarmInstanceCacheEnabled() {
 		return nil
 	}
 
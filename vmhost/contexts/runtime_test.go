@@ -69,6 +69,9 @@ func makeDefaultRuntimeContext(t *testing.T, host vmhost.VMHost) *runtimeContext
 		vmType,
 		builtInFunctions.NewBuiltInFunctionContainer(),
 		defaultHasher,
+		nil,
+		nil,
+		nil,
 	)
 	require.Nil(t, err)
 	require.NotNil(t, runtimeContext)
@@ -83,22 +86,22 @@ func TestNewRuntimeContextErrors(t *testing.T) {
 	hasher := defaultHasher
 
 	t.Run("NilHost", func(t *testing.T) {
-		runtimeContext, err := NewRuntimeContext(nil, vmType, bfc, hasher)
+		runtimeContext, err := NewRuntimeContext(nil, vmType, bfc, hasher, nil, nil, nil)
 		require.Nil(t, runtimeContext)
 		require.ErrorIs(t, err, vmhost.ErrNilHost)
 	})
 	t.Run("NilVMType", func(t *testing.T) {
-		runtimeContext, err := NewRuntimeContext(host, nil, bfc, hasher)
+		runtimeContext, err := NewRuntimeContext(host, nil, bfc, hasher, nil, nil, nil)
 		require.Nil(t, runtimeContext)
 		require.ErrorIs(t, err, vmhost.ErrNilVMType)
 	})
 	t.Run("NilBuiltinFuncContainer", func(t *testing.T) {
-		runtimeContext, err := NewRuntimeContext(host, vmType, nil, hasher)
+		runtimeContext, err := NewRuntimeContext(host, vmType, nil, hasher, nil, nil, nil)
 		require.Nil(t, runtimeContext)
 		require.ErrorIs(t, err, vmhost.ErrNilBuiltInFunctionsContainer)
 	})
 	t.Run("NilHasher", func(t *testing.T) {
-		runtimeContext, err := NewRuntimeContext(host, vmType, bfc, nil)
+		runtimeContext, err := NewRuntimeContext(host, vmType, bfc, nil, nil, nil, nil)
 		require.Nil(t, runtimeContext)
 		require.ErrorIs(t, err, vmhost.ErrNilHasher)
 	})
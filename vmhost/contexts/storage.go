@@ -19,6 +19,7 @@ type storageContext struct {
 	stateStack                 [][]byte
 	protectedKeyPrefix         []byte
 	vmStorageProtectionEnabled bool
+	storageAccounting          *vmhost.StorageAccounting
 }
 
 // NewStorageContext creates a new storageContext
@@ -43,13 +44,15 @@ func NewStorageContext(
 		stateStack:                 make([][]byte, 0),
 		protectedKeyPrefix:         protectedKeyPrefix,
 		vmStorageProtectionEnabled: true,
+		storageAccounting:          vmhost.NewStorageAccounting(),
 	}
 
 	return context, nil
 }
 
-// InitState does nothing
+// InitState resets the storage accounting of the execution
 func (context *storageContext) InitState() {
+	context.storageAccounting = vmhost.NewStorageAccounting()
 }
 
 // PushState appends the current address to the state stack.
@@ -260,6 +263,8 @@ func (context *storageContext) SetStorage(key []byte, value []byte) (vmhost.Stor
 		return vmhost.StorageUnchanged, err
 	}
 
+	context.storageAccounting.RecordOriginalLength(context.address, key, len(oldValue))
+
 	gasForKey := context.computeGasForKey(key, usedCache)
 	metering.UseGas(gasForKey)
 
@@ -421,6 +426,11 @@ func (context *storageContext) IsUseDifferentGasCostFlagSet() bool {
 	return enableEpochsHandler.IsFlagEnabled(vmhost.StorageAPICostOptimizationFlag)
 }
 
+// GetStorageAccounting returns the storage accounting of the current execution
+func (context *storageContext) GetStorageAccounting() *vmhost.StorageAccounting {
+	return context.storageAccounting
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (context *storageContext) IsInterfaceNil() bool {
 	return context == nil
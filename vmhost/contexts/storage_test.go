@@ -394,6 +394,83 @@ func TestStorageContext_SetStorage_GasUsage(t *testing.T) {
 	require.Equal(t, value, storedValue)
 }
 
+func TestStorageContext_SetStorage_StorageAccounting(t *testing.T) {
+	address := []byte("account")
+	mockOutput := &contextmock.OutputContextMock{}
+	account := mockOutput.NewVMOutputAccount(address)
+	mockOutput.OutputAccountMock = account
+	mockOutput.OutputAccountIsNew = false
+
+	releaseCost := uint64(5)
+	gasMap := config.MakeGasMapForTests()
+	gasMap["BaseOperationCost"]["ReleasePerByte"] = releaseCost
+
+	mockRuntime := &contextmock.RuntimeContextMock{}
+	mockMetering := &contextmock.MeteringContextMock{}
+	mockMetering.SetGasSchedule(gasMap)
+	mockMetering.GasLeftMock = uint64(1000000)
+
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return flag == vmhost.StorageAPICostOptimizationFlag
+		},
+	}
+	host := &contextmock.VMHostMock{
+		OutputContext:            mockOutput,
+		MeteringContext:          mockMetering,
+		RuntimeContext:           mockRuntime,
+		EnableEpochsHandlerField: enableEpochsHandler,
+	}
+	originalValue := []byte("original")
+	bcHook := &contextmock.BlockchainHookStub{
+		GetStorageDataCalled: func(_ []byte, _ []byte) ([]byte, uint32, error) {
+			return originalValue, 0, nil
+		},
+	}
+
+	storageCtx, _ := NewStorageContext(host, bcHook, reservedTestPrefix)
+	storageCtx.SetAddress(address)
+
+	// deleted value frees all of its bytes
+	_, err := storageCtx.SetStorage([]byte("deleted"), nil)
+	require.Nil(t, err)
+
+	// shrunk value frees the bytes it lost
+	_, err = storageCtx.SetStorage([]byte("shrunk"), []byte("abcdef"))
+	require.Nil(t, err)
+	_, err = storageCtx.SetStorage([]byte("shrunk"), []byte("abc"))
+	require.Nil(t, err)
+
+	// value shrunk and then restored within the same execution frees nothing
+	_, err = storageCtx.SetStorage([]byte("restored"), []byte("ab"))
+	require.Nil(t, err)
+	_, err = storageCtx.SetStorage([]byte("restored"), originalValue)
+	require.Nil(t, err)
+
+	// value added and then deleted within the same execution adds nothing
+	bcHook.GetStorageDataCalled = nil
+	_, err = storageCtx.SetStorage([]byte("temporary"), []byte("abc"))
+	require.Nil(t, err)
+	_, err = storageCtx.SetStorage([]byte("temporary"), nil)
+	require.Nil(t, err)
+
+	vmOutput := &vmcommon.VMOutput{
+		OutputAccounts: map[string]*vmcommon.OutputAccount{string(address): account},
+	}
+	usage := storageCtx.GetStorageAccounting().ComputeUsage(vmOutput, releaseCost)
+
+	bytesFreed := uint64(len(originalValue) + len(originalValue) - len("abc"))
+	require.Len(t, usage.Accounts, 1)
+	require.Equal(t, address, usage.Accounts[0].Address)
+	require.Equal(t, uint64(0), usage.Accounts[0].BytesAdded)
+	require.Equal(t, bytesFreed, usage.Accounts[0].BytesFreed)
+	require.Equal(t, bytesFreed*releaseCost, usage.GasRefund)
+
+	storageCtx.InitState()
+	usage = storageCtx.GetStorageAccounting().ComputeUsage(vmOutput, releaseCost)
+	require.Len(t, usage.Accounts, 0)
+}
+
 func TestStorageContext_StorageProtection(t *testing.T) {
 	address := []byte("account")
 	mockOutput := &contextmock.OutputContextMock{}
@@ -0,0 +1,230 @@
+package contexts
+
+import (
+	"sort"
+
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+)
+
+var _ Cacher = (*policyCache)(nil)
+
+type policyCacheEntry struct {
+	key         []byte
+	value       interface{}
+	sizeInBytes uint64
+	uses        uint64
+	lastUse     uint64
+}
+
+// policyCache is a Cacher for the warm instances which evicts according to a configurable policy
+// and keeps the size in bytes of its contents under an optional budget. Like the LRU cache, it
+// calls the eviction callback with the key as string for every value leaving the cache, whether
+// evicted, removed or cleared, so that the evicted instances are cleaned. The latest value put is never evicted by
+// its own Put, even if it alone exceeds the budget, because it is the active instance.
+type policyCache struct {
+	policy       vmhost.WarmInstanceEvictionPolicy
+	maxSize      int
+	memoryBudget uint64
+	onEvicted    func(key interface{}, value interface{})
+
+	entries     map[string]*policyCacheEntry
+	sizeInBytes uint64
+	clock       uint64
+}
+
+func newPolicyCache(
+	policy vmhost.WarmInstanceEvictionPolicy,
+	maxSize int,
+	memoryBudget uint64,
+	onEvicted func(key interface{}, value interface{}),
+) *policyCache {
+	return &policyCache{
+		policy:       policy,
+		maxSize:      maxSize,
+		memoryBudget: memoryBudget,
+		onEvicted:    onEvicted,
+		entries:      make(map[string]*policyCacheEntry),
+	}
+}
+
+// Clear removes all the values, calling the eviction callback for each of them
+func (cache *policyCache) Clear() {
+	for _, key := range cache.Keys() {
+		cache.Remove(key)
+	}
+}
+
+// Put adds or replaces a value, then evicts the other values while the cache is over its limits;
+// it returns true if an eviction occurred
+func (cache *policyCache) Put(key []byte, value interface{}, sizeInBytes int) (evicted bool) {
+	entry, ok := cache.entries[string(key)]
+	if ok {
+		cache.sizeInBytes -= entry.sizeInBytes
+	} else {
+		entry = &policyCacheEntry{key: key}
+		cache.entries[string(key)] = entry
+	}
+
+	entry.value = value
+	entry.sizeInBytes = uint64(sizeInBytes)
+	cache.sizeInBytes += entry.sizeInBytes
+	cache.touch(entry)
+
+	for cache.isOverLimits() {
+		victim := cache.selectVictim(entry)
+		if victim == nil {
+			break
+		}
+
+		cache.Remove(victim.key)
+		evicted = true
+	}
+
+	return evicted
+}
+
+// Get returns the value of the key, counting it as used
+func (cache *policyCache) Get(key []byte) (value interface{}, ok bool) {
+	entry, ok := cache.entries[string(key)]
+	if !ok {
+		return nil, false
+	}
+
+	cache.touch(entry)
+	return entry.value, true
+}
+
+// Has returns true if the key is in the cache, without counting it as used
+func (cache *policyCache) Has(key []byte) bool {
+	_, ok := cache.entries[string(key)]
+	return ok
+}
+
+// Peek returns the value of the key, without counting it as used
+func (cache *policyCache) Peek(key []byte) (value interface{}, ok bool) {
+	entry, ok := cache.entries[string(key)]
+	if !ok {
+		return nil, false
+	}
+
+	return entry.value, true
+}
+
+// HasOrAdd adds the value only if the key is not in the cache
+func (cache *policyCache) HasOrAdd(key []byte, value interface{}, sizeInBytes int) (has, added bool) {
+	if cache.Has(key) {
+		return true, false
+	}
+
+	cache.Put(key, value, sizeInBytes)
+	return false, true
+}
+
+// Remove removes the key, calling the eviction callback for its value
+func (cache *policyCache) Remove(key []byte) {
+	entry, ok := cache.entries[string(key)]
+	if !ok {
+		return
+	}
+
+	delete(cache.entries, string(key))
+	cache.sizeInBytes -= entry.sizeInBytes
+	if cache.onEvicted != nil {
+		cache.onEvicted(string(entry.key), entry.value)
+	}
+}
+
+// Keys returns the keys, from the least to the most recently used
+func (cache *policyCache) Keys() [][]byte {
+	entries := make([]*policyCacheEntry, 0, len(cache.entries))
+	for _, entry := range cache.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].lastUse < entries[j].lastUse
+	})
+
+	keys := make([][]byte, 0, len(entries))
+	for _, entry := range entries {
+		keys = append(keys, entry.key)
+	}
+
+	return keys
+}
+
+// Len returns the number of values in the cache
+func (cache *policyCache) Len() int {
+	return len(cache.entries)
+}
+
+// SizeInBytesContained returns the size in bytes of all the values in the cache
+func (cache *policyCache) SizeInBytesContained() uint64 {
+	return cache.sizeInBytes
+}
+
+// MaxSize returns the maximum number of values in the cache
+func (cache *policyCache) MaxSize() int {
+	return cache.maxSize
+}
+
+// RegisterHandler does nothing
+func (cache *policyCache) RegisterHandler(_ func(key []byte, value interface{}), _ string) {
+}
+
+// UnRegisterHandler does nothing
+func (cache *policyCache) UnRegisterHandler(_ string) {
+}
+
+// Close does nothing
+func (cache *policyCache) Close() error {
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (cache *policyCache) IsInterfaceNil() bool {
+	return cache == nil
+}
+
+func (cache *policyCache) touch(entry *policyCacheEntry) {
+	cache.clock++
+	entry.lastUse = cache.clock
+	entry.uses++
+}
+
+func (cache *policyCache) isOverLimits() bool {
+	if len(cache.entries) > cache.maxSize {
+		return true
+	}
+
+	return cache.memoryBudget > 0 && cache.sizeInBytes > cache.memoryBudget
+}
+
+// selectVictim returns the entry to evict according to the policy, never the protected one
+func (cache *policyCache) selectVictim(protected *policyCacheEntry) *policyCacheEntry {
+	var victim *policyCacheEntry
+	for _, entry := range cache.entries {
+		if entry == protected {
+			continue
+		}
+		if victim == nil || cache.isBetterVictim(entry, victim) {
+			victim = entry
+		}
+	}
+
+	return victim
+}
+
+func (cache *policyCache) isBetterVictim(entry *policyCacheEntry, victim *policyCacheEntry) bool {
+	switch cache.policy {
+	case vmhost.LFUEviction:
+		if entry.uses != victim.uses {
+			return entry.uses < victim.uses
+		}
+	case vmhost.SizeAwareEviction:
+		if entry.sizeInBytes != victim.sizeInBytes {
+			return entry.sizeInBytes > victim.sizeInBytes
+		}
+	}
+
+	return entry.lastUse < victim.lastUse
+}
@@ -0,0 +1,96 @@
+package contexts
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/stretchr/testify/require"
+)
+
+func makeTestPolicyCache(policy vmhost.WarmInstanceEvictionPolicy, maxSize int, memoryBudget uint64) (*policyCache, *[]string) {
+	evicted := make([]string, 0)
+	cache := newPolicyCache(policy, maxSize, memoryBudget, func(key interface{}, _ interface{}) {
+		evicted = append(evicted, key.(string))
+	})
+
+	return cache, &evicted
+}
+
+func TestPolicyCache_LRU(t *testing.T) {
+	cache, evicted := makeTestPolicyCache(vmhost.LRUEviction, 2, 0)
+
+	require.False(t, cache.Put([]byte("a"), 1, 10))
+	require.False(t, cache.Put([]byte("b"), 2, 10))
+	_, ok := cache.Get([]byte("a"))
+	require.True(t, ok)
+
+	require.True(t, cache.Put([]byte("c"), 3, 10))
+	require.Equal(t, []string{"b"}, *evicted)
+	require.Equal(t, [][]byte{[]byte("a"), []byte("c")}, cache.Keys())
+	require.Equal(t, uint64(20), cache.SizeInBytesContained())
+}
+
+func TestPolicyCache_LFU(t *testing.T) {
+	cache, evicted := makeTestPolicyCache(vmhost.LFUEviction, 2, 0)
+
+	cache.Put([]byte("a"), 1, 10)
+	cache.Get([]byte("a"))
+	cache.Get([]byte("a"))
+	cache.Put([]byte("b"), 2, 10)
+	cache.Get([]byte("b"))
+
+	// "a" is the least recently used, but it is used more often than "b"
+	cache.Put([]byte("c"), 3, 10)
+	require.Equal(t, []string{"b"}, *evicted)
+	require.True(t, cache.Has([]byte("a")))
+	require.True(t, cache.Has([]byte("c")))
+}
+
+func TestPolicyCache_SizeAware(t *testing.T) {
+	cache, evicted := makeTestPolicyCache(vmhost.SizeAwareEviction, 2, 0)
+
+	cache.Put([]byte("a"), 1, 10)
+	cache.Put([]byte("b"), 2, 50)
+	cache.Get([]byte("a"))
+
+	cache.Put([]byte("c"), 3, 20)
+	require.Equal(t, []string{"b"}, *evicted)
+	require.Equal(t, uint64(30), cache.SizeInBytesContained())
+}
+
+func TestPolicyCache_MemoryBudget(t *testing.T) {
+	cache, evicted := makeTestPolicyCache(vmhost.LRUEviction, 10, 100)
+
+	cache.Put([]byte("a"), 1, 40)
+	cache.Put([]byte("b"), 2, 40)
+	cache.Put([]byte("c"), 3, 40)
+	require.Equal(t, []string{"a"}, *evicted)
+	require.Equal(t, uint64(80), cache.SizeInBytesContained())
+
+	// the value just put is kept, even when it alone exceeds the budget
+	cache.Put([]byte("d"), 4, 200)
+	require.Equal(t, []string{"a", "b", "c"}, *evicted)
+	require.Equal(t, 1, cache.Len())
+	require.Equal(t, uint64(200), cache.SizeInBytesContained())
+}
+
+func TestPolicyCache_RemoveAndClear(t *testing.T) {
+	cache, evicted := makeTestPolicyCache(vmhost.LFUEviction, 10, 0)
+
+	cache.Put([]byte("a"), 1, 10)
+	cache.Put([]byte("b"), 2, 10)
+	cache.Put([]byte("c"), 3, 10)
+
+	cache.Remove([]byte("b"))
+	cache.Remove([]byte("missing"))
+	require.Equal(t, []string{"b"}, *evicted)
+
+	value, ok := cache.Peek([]byte("a"))
+	require.True(t, ok)
+	require.Equal(t, 1, value)
+
+	cache.Clear()
+	require.Equal(t, []string{"b", "a", "c"}, *evicted)
+	require.Equal(t, 0, cache.Len())
+	require.Equal(t, uint64(0), cache.SizeInBytesContained())
+}
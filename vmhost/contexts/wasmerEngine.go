@@ -0,0 +1,140 @@
+package contexts
+
+import (
+	"sync"
+
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/vmhooksmeta"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/wasmer"
+)
+
+var _ vmhost.WasmEngine = (*WasmerEngine)(nil)
+
+// wasmerGlobalState serializes the creation of the Wasmer instances, which are linked with the imports
+// and compiled with the opcode costs last set process-wide; it records the engine which set them last,
+// so that an engine only sets its own again after another engine has replaced them
+var wasmerGlobalState = struct {
+	sync.Mutex
+	appliedEngine  *WasmerEngine
+	appliedVersion uint64
+}{}
+
+// wasmerProcessInit configures the process-wide serialization and signal handling of Wasmer, once
+var wasmerProcessInit sync.Once
+
+// wasmerSetImports and wasmerSetOpcodeCosts set the process-wide configuration of Wasmer
+var wasmerSetImports = wasmer.SetImports
+var wasmerSetOpcodeCosts = wasmer.SetOpcodeCosts
+
+// WasmerEngine is the default engine, executing the contracts with the Wasmer library linked through cgo.
+// Its imports and opcode costs are its own: they are set in Wasmer right before it creates an instance,
+// so that hosts with different gas schedules can run side by side in the same process.
+type WasmerEngine struct {
+	WasmerInstanceBuilder
+
+	imports     *wasmer.Imports
+	opcodeCosts *[wasmer.OpcodeCount]uint32
+	version     uint64
+}
+
+// NewWasmerEngine creates a new WasmerEngine; the first one created in the process configures the
+// signal handling of Wasmer, including the SIGSEGV passthrough, which cannot be changed afterwards
+func NewWasmerEngine(sigsegvPassthrough bool) *WasmerEngine {
+	wasmerProcessInit.Do(func() {
+		wasmer.SetRkyvSerializationEnabled(true)
+		if sigsegvPassthrough {
+			wasmer.SetSIGSEGVPassthrough()
+		}
+		wasmer.ForceInstallSighandlers()
+	})
+
+	return &WasmerEngine{}
+}
+
+// SetImports sets the EI functions imported by the Wasmer instances created by this engine
+func (engine *WasmerEngine) SetImports(imports *vmhooksmeta.EIFunctions) error {
+	if imports == nil {
+		return vmhost.ErrNilImports
+	}
+
+	converted := wasmer.ConvertImports(imports)
+
+	wasmerGlobalState.Lock()
+	engine.imports = converted
+	engine.version++
+	wasmerGlobalState.Unlock()
+
+	return nil
+}
+
+// SetOpcodeCosts sets the opcode costs metered by the Wasmer instances created by this engine
+func (engine *WasmerEngine) SetOpcodeCosts(opcodeCosts *[wasmer.OpcodeCount]uint32) {
+	costs := *opcodeCosts
+
+	wasmerGlobalState.Lock()
+	engine.opcodeCosts = &costs
+	engine.version++
+	wasmerGlobalState.Unlock()
+}
+
+// NewInstanceWithOptions creates a new Wasmer instance from WASM bytecode, with the imports and
+// opcode costs of this engine
+func (engine *WasmerEngine) NewInstanceWithOptions(
+	contractCode []byte,
+	options wasmer.CompilationOptions,
+) (wasmer.InstanceHandler, error) {
+	wasmerGlobalState.Lock()
+	defer wasmerGlobalState.Unlock()
+
+	err := engine.applyToWasmer()
+	if err != nil {
+		return nil, err
+	}
+
+	return engine.WasmerInstanceBuilder.NewInstanceWithOptions(contractCode, options)
+}
+
+// NewInstanceFromCompiledCodeWithOptions creates a new Wasmer instance from precompiled machine code,
+// linked with the imports of this engine
+func (engine *WasmerEngine) NewInstanceFromCompiledCodeWithOptions(
+	compiledCode []byte,
+	options wasmer.CompilationOptions,
+) (wasmer.InstanceHandler, error) {
+	wasmerGlobalState.Lock()
+	defer wasmerGlobalState.Unlock()
+
+	err := engine.applyToWasmer()
+	if err != nil {
+		return nil, err
+	}
+
+	return engine.WasmerInstanceBuilder.NewInstanceFromCompiledCodeWithOptions(compiledCode, options)
+}
+
+// applyToWasmer sets the imports and opcode costs of the engine in Wasmer, unless they are already
+// set; it must be called with wasmerGlobalState locked
+func (engine *WasmerEngine) applyToWasmer() error {
+	if wasmerGlobalState.appliedEngine == engine && wasmerGlobalState.appliedVersion == engine.version {
+		return nil
+	}
+
+	if engine.imports != nil {
+		err := wasmerSetImports(engine.imports)
+		if err != nil {
+			wasmerGlobalState.appliedEngine = nil
+			return err
+		}
+	}
+	if engine.opcodeCosts != nil {
+		wasmerSetOpcodeCosts(engine.opcodeCosts)
+	}
+
+	wasmerGlobalState.appliedEngine = engine
+	wasmerGlobalState.appliedVersion = engine.version
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (engine *WasmerEngine) IsInterfaceNil() bool {
+	return engine == nil
+}
@@ -0,0 +1,99 @@
+package contexts
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/wasmer"
+	"github.com/stretchr/testify/require"
+)
+
+func replaceWasmerSetters(t *testing.T) *[]uint32 {
+	var appliedCosts []uint32
+	setImports, setOpcodeCosts := wasmerSetImports, wasmerSetOpcodeCosts
+	wasmerSetImports = func(_ *wasmer.Imports) error {
+		return nil
+	}
+	wasmerSetOpcodeCosts = func(opcodeCosts *[wasmer.OpcodeCount]uint32) {
+		appliedCosts = append(appliedCosts, opcodeCosts[wasmer.OpcodeI64Add])
+	}
+	t.Cleanup(func() {
+		wasmerSetImports, wasmerSetOpcodeCosts = setImports, setOpcodeCosts
+		wasmerGlobalState.Lock()
+		wasmerGlobalState.appliedEngine = nil
+		wasmerGlobalState.Unlock()
+	})
+
+	return &appliedCosts
+}
+
+func makeWasmerEngineWithAddCost(addCost uint32) *WasmerEngine {
+	var opcodeCosts [wasmer.OpcodeCount]uint32
+	opcodeCosts[wasmer.OpcodeI64Add] = addCost
+
+	engine := &WasmerEngine{}
+	engine.SetOpcodeCosts(&opcodeCosts)
+	return engine
+}
+
+func applyToWasmer(engine *WasmerEngine) error {
+	wasmerGlobalState.Lock()
+	defer wasmerGlobalState.Unlock()
+
+	return engine.applyToWasmer()
+}
+
+func TestWasmerEngine_SetImportsNil(t *testing.T) {
+	engine := &WasmerEngine{}
+	require.Equal(t, vmhost.ErrNilImports, engine.SetImports(nil))
+}
+
+func TestWasmerEngine_AppliesItsOwnOpcodeCosts(t *testing.T) {
+	appliedCosts := replaceWasmerSetters(t)
+
+	engineA := makeWasmerEngineWithAddCost(1)
+	engineB := makeWasmerEngineWithAddCost(2)
+
+	require.Nil(t, applyToWasmer(engineA))
+	require.Nil(t, applyToWasmer(engineA))
+	require.Equal(t, []uint32{1}, *appliedCosts)
+
+	require.Nil(t, applyToWasmer(engineB))
+	require.Nil(t, applyToWasmer(engineA))
+	require.Equal(t, []uint32{1, 2, 1}, *appliedCosts)
+
+	var opcodeCosts [wasmer.OpcodeCount]uint32
+	opcodeCosts[wasmer.OpcodeI64Add] = 3
+	engineA.SetOpcodeCosts(&opcodeCosts)
+	opcodeCosts[wasmer.OpcodeI64Add] = 4
+	require.Nil(t, applyToWasmer(engineA))
+	require.Equal(t, []uint32{1, 2, 1, 3}, *appliedCosts)
+}
+
+func TestWasmerEngine_ConcurrentEngines(t *testing.T) {
+	appliedCosts := replaceWasmerSetters(t)
+
+	numEngines := 4
+	numInstances := 100
+	var wg sync.WaitGroup
+	for i := 0; i < numEngines; i++ {
+		engine := makeWasmerEngineWithAddCost(uint32(i + 1))
+		wg.Add(1)
+		go func(engine *WasmerEngine, addCost uint32) {
+			defer wg.Done()
+			for j := 0; j < numInstances; j++ {
+				wasmerGlobalState.Lock()
+				err := engine.applyToWasmer()
+				lastApplied := (*appliedCosts)[len(*appliedCosts)-1]
+				wasmerGlobalState.Unlock()
+
+				require.Nil(t, err)
+				require.Equal(t, addCost, lastApplied)
+			}
+		}(engine, uint32(i+1))
+	}
+	wg.Wait()
+
+	require.LessOrEqual(t, len(*appliedCosts), numEngines*numInstances)
+}
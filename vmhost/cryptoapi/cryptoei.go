@@ -286,6 +286,7 @@ func CryptoImports(imports vmhooksmeta.EIFunctionReceiver) error {
 
 //export v1_4_sha256
 func v1_4_sha256(context unsafe.Pointer, dataOffset int32, length int32, resultOffset int32) int32 {
+	vmhost.TrackEICall(context, "sha256")
 	runtime := vmhost.GetRuntimeContext(context)
 	crypto := vmhost.GetCryptoContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -315,6 +316,7 @@ func v1_4_sha256(context unsafe.Pointer, dataOffset int32, length int32, resultO
 
 //export v1_4_managedSha256
 func v1_4_managedSha256(context unsafe.Pointer, inputHandle, outputHandle int32) int32 {
+	vmhost.TrackEICall(context, "managedSha256")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	crypto := vmhost.GetCryptoContext(context)
@@ -341,6 +343,7 @@ func v1_4_managedSha256(context unsafe.Pointer, inputHandle, outputHandle int32)
 
 //export v1_4_keccak256
 func v1_4_keccak256(context unsafe.Pointer, dataOffset int32, length int32, resultOffset int32) int32 {
+	vmhost.TrackEICall(context, "keccak256")
 	runtime := vmhost.GetRuntimeContext(context)
 	crypto := vmhost.GetCryptoContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -370,6 +373,7 @@ func v1_4_keccak256(context unsafe.Pointer, dataOffset int32, length int32, resu
 
 //export v1_4_managedKeccak256
 func v1_4_managedKeccak256(context unsafe.Pointer, inputHandle, outputHandle int32) int32 {
+	vmhost.TrackEICall(context, "managedKeccak256")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	crypto := vmhost.GetCryptoContext(context)
@@ -396,6 +400,7 @@ func v1_4_managedKeccak256(context unsafe.Pointer, inputHandle, outputHandle int
 
 //export v1_4_ripemd160
 func v1_4_ripemd160(context unsafe.Pointer, dataOffset int32, length int32, resultOffset int32) int32 {
+	vmhost.TrackEICall(context, "ripemd160")
 	runtime := vmhost.GetRuntimeContext(context)
 	crypto := vmhost.GetCryptoContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -425,6 +430,7 @@ func v1_4_ripemd160(context unsafe.Pointer, dataOffset int32, length int32, resu
 
 //export v1_4_managedRipemd160
 func v1_4_managedRipemd160(context unsafe.Pointer, inputHandle int32, outputHandle int32) int32 {
+	vmhost.TrackEICall(context, "managedRipemd160")
 	host := vmhost.GetVMHost(context)
 	return ManagedRipemd160WithHost(host, inputHandle, outputHandle)
 }
@@ -462,6 +468,7 @@ func v1_4_verifyBLS(
 	messageLength int32,
 	sigOffset int32,
 ) int32 {
+	vmhost.TrackEICall(context, "verifyBLS")
 	runtime := vmhost.GetRuntimeContext(context)
 	crypto := vmhost.GetCryptoContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -504,6 +511,7 @@ func v1_4_managedVerifyBLS(
 	messageHandle int32,
 	sigHandle int32,
 ) int32 {
+	vmhost.TrackEICall(context, "managedVerifyBLS")
 	host := vmhost.GetVMHost(context)
 	return ManagedVerifyBLSWithHost(host, keyHandle, messageHandle, sigHandle)
 }
@@ -558,6 +566,7 @@ func v1_4_verifyEd25519(
 	messageLength int32,
 	sigOffset int32,
 ) int32 {
+	vmhost.TrackEICall(context, "verifyEd25519")
 	runtime := vmhost.GetRuntimeContext(context)
 	crypto := vmhost.GetCryptoContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -598,6 +607,7 @@ func v1_4_managedVerifyEd25519(
 	context unsafe.Pointer,
 	keyHandle, messageHandle, sigHandle int32,
 ) int32 {
+	vmhost.TrackEICall(context, "managedVerifyEd25519")
 	host := vmhost.GetVMHost(context)
 	return ManagedVerifyEd25519WithHost(host, keyHandle, messageHandle, sigHandle)
 }
@@ -651,6 +661,19 @@ func v1_4_verifyCustomSecp256k1(
 	messageLength int32,
 	sigOffset int32,
 	hashType int32,
+) int32 {
+	vmhost.TrackEICall(context, "verifyCustomSecp256k1")
+	return verifyCustomSecp256k1(context, keyOffset, keyLength, messageOffset, messageLength, sigOffset, hashType)
+}
+
+func verifyCustomSecp256k1(
+	context unsafe.Pointer,
+	keyOffset int32,
+	keyLength int32,
+	messageOffset int32,
+	messageLength int32,
+	sigOffset int32,
+	hashType int32,
 ) int32 {
 	runtime := vmhost.GetRuntimeContext(context)
 	crypto := vmhost.GetCryptoContext(context)
@@ -707,6 +730,7 @@ func v1_4_managedVerifyCustomSecp256k1(
 	keyHandle, messageHandle, sigHandle int32,
 	hashType int32,
 ) int32 {
+	vmhost.TrackEICall(context, "managedVerifyCustomSecp256k1")
 	host := vmhost.GetVMHost(context)
 	return ManagedVerifyCustomSecp256k1WithHost(
 		host,
@@ -766,7 +790,8 @@ func v1_4_verifySecp256k1(
 	messageLength int32,
 	sigOffset int32,
 ) int32 {
-	return v1_4_verifyCustomSecp256k1(
+	vmhost.TrackEICall(context, "verifySecp256k1")
+	return verifyCustomSecp256k1(
 		context,
 		keyOffset,
 		keyLength,
@@ -782,6 +807,7 @@ func v1_4_managedVerifySecp256k1(
 	context unsafe.Pointer,
 	keyHandle, messageHandle, sigHandle int32,
 ) int32 {
+	vmhost.TrackEICall(context, "managedVerifySecp256k1")
 	host := vmhost.GetVMHost(context)
 	return ManagedVerifySecp256k1WithHost(host, keyHandle, messageHandle, sigHandle)
 }
@@ -808,6 +834,7 @@ func v1_4_encodeSecp256k1DerSignature(
 	sLength int32,
 	sigOffset int32,
 ) int32 {
+	vmhost.TrackEICall(context, "encodeSecp256k1DerSignature")
 	runtime := vmhost.GetRuntimeContext(context)
 	crypto := vmhost.GetCryptoContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -839,6 +866,7 @@ func v1_4_managedEncodeSecp256k1DerSignature(
 	context unsafe.Pointer,
 	rHandle, sHandle, sigHandle int32,
 ) int32 {
+	vmhost.TrackEICall(context, "managedEncodeSecp256k1DerSignature")
 	host := vmhost.GetVMHost(context)
 	return ManagedEncodeSecp256k1DerSignatureWithHost(host, rHandle, sHandle, sigHandle)
 }
@@ -882,6 +910,7 @@ func v1_4_addEC(
 	sndPointXHandle int32,
 	sndPointYHandle int32,
 ) {
+	vmhost.TrackEICall(context, "addEC")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -936,6 +965,7 @@ func v1_4_doubleEC(
 	pointXHandle int32,
 	pointYHandle int32,
 ) {
+	vmhost.TrackEICall(context, "doubleEC")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -979,6 +1009,7 @@ func v1_4_isOnCurveEC(
 	pointXHandle int32,
 	pointYHandle int32,
 ) int32 {
+	vmhost.TrackEICall(context, "isOnCurveEC")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -1020,6 +1051,7 @@ func v1_4_scalarBaseMultEC(
 	dataOffset int32,
 	length int32,
 ) int32 {
+	vmhost.TrackEICall(context, "scalarBaseMultEC")
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	managedType := vmhost.GetManagedTypesContext(context)
@@ -1056,6 +1088,7 @@ func v1_4_managedScalarBaseMultEC(
 	ecHandle int32,
 	dataHandle int32,
 ) int32 {
+	vmhost.TrackEICall(context, "managedScalarBaseMultEC")
 	host := vmhost.GetVMHost(context)
 	return ManagedScalarBaseMultECWithHost(
 		host,
@@ -1140,6 +1173,7 @@ func v1_4_scalarMultEC(
 	dataOffset int32,
 	length int32,
 ) int32 {
+	vmhost.TrackEICall(context, "scalarMultEC")
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	managedType := vmhost.GetManagedTypesContext(context)
@@ -1178,6 +1212,7 @@ func v1_4_managedScalarMultEC(
 	pointYHandle int32,
 	dataHandle int32,
 ) int32 {
+	vmhost.TrackEICall(context, "managedScalarMultEC")
 	host := vmhost.GetVMHost(context)
 	return ManagedScalarMultECWithHost(
 		host,
@@ -1272,6 +1307,7 @@ func v1_4_marshalEC(
 	ecHandle int32,
 	resultOffset int32,
 ) int32 {
+	vmhost.TrackEICall(context, "marshalEC")
 	runtime := vmhost.GetRuntimeContext(context)
 	host := vmhost.GetVMHost(context)
 	result, err := commonMarshalEC(host, xPairHandle, yPairHandle, ecHandle)
@@ -1295,6 +1331,7 @@ func v1_4_managedMarshalEC(
 	ecHandle int32,
 	resultHandle int32,
 ) int32 {
+	vmhost.TrackEICall(context, "managedMarshalEC")
 	host := vmhost.GetVMHost(context)
 	return ManagedMarshalECWithHost(
 		host,
@@ -1370,6 +1407,7 @@ func v1_4_marshalCompressedEC(
 	ecHandle int32,
 	resultOffset int32,
 ) int32 {
+	vmhost.TrackEICall(context, "marshalCompressedEC")
 	runtime := vmhost.GetRuntimeContext(context)
 	host := vmhost.GetVMHost(context)
 	result, err := commonMarshalCompressedEC(host, xPairHandle, yPairHandle, ecHandle)
@@ -1393,6 +1431,7 @@ func v1_4_managedMarshalCompressedEC(
 	ecHandle int32,
 	resultHandle int32,
 ) int32 {
+	vmhost.TrackEICall(context, "managedMarshalCompressedEC")
 	host := vmhost.GetVMHost(context)
 	return ManagedMarshalCompressedECWithHost(
 		host,
@@ -1469,6 +1508,7 @@ func v1_4_unmarshalEC(
 	dataOffset int32,
 	length int32,
 ) int32 {
+	vmhost.TrackEICall(context, "unmarshalEC")
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	managedType := vmhost.GetManagedTypesContext(context)
@@ -1499,6 +1539,7 @@ func v1_4_managedUnmarshalEC(
 	ecHandle int32,
 	dataHandle int32,
 ) int32 {
+	vmhost.TrackEICall(context, "managedUnmarshalEC")
 	host := vmhost.GetVMHost(context)
 	return ManagedUnmarshalECWithHost(
 		host,
@@ -1584,6 +1625,7 @@ func v1_4_unmarshalCompressedEC(
 	dataOffset int32,
 	length int32,
 ) int32 {
+	vmhost.TrackEICall(context, "unmarshalCompressedEC")
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	managedType := vmhost.GetManagedTypesContext(context)
@@ -1614,6 +1656,7 @@ func v1_4_managedUnmarshalCompressedEC(
 	ecHandle int32,
 	dataHandle int32,
 ) int32 {
+	vmhost.TrackEICall(context, "managedUnmarshalCompressedEC")
 	host := vmhost.GetVMHost(context)
 	return ManagedUnmarshalCompressedECWithHost(
 		host,
@@ -1697,6 +1740,7 @@ func v1_4_generateKeyEC(
 	ecHandle int32,
 	resultOffset int32,
 ) int32 {
+	vmhost.TrackEICall(context, "generateKeyEC")
 	runtime := vmhost.GetRuntimeContext(context)
 	host := vmhost.GetVMHost(context)
 	result, err := commonGenerateEC(host, xPubKeyHandle, yPubKeyHandle, ecHandle)
@@ -1720,6 +1764,7 @@ func v1_4_managedGenerateKeyEC(
 	ecHandle int32,
 	resultHandle int32,
 ) int32 {
+	vmhost.TrackEICall(context, "managedGenerateKeyEC")
 	host := vmhost.GetVMHost(context)
 	return ManagedGenerateKeyECWithHost(
 		host,
@@ -1793,6 +1838,7 @@ func commonGenerateEC(
 
 //export v1_4_createEC
 func v1_4_createEC(context unsafe.Pointer, dataOffset int32, dataLength int32) int32 {
+	vmhost.TrackEICall(context, "createEC")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -1828,6 +1874,7 @@ func v1_4_createEC(context unsafe.Pointer, dataOffset int32, dataLength int32) i
 
 //export v1_4_managedCreateEC
 func v1_4_managedCreateEC(context unsafe.Pointer, dataHandle int32) int32 {
+	vmhost.TrackEICall(context, "managedCreateEC")
 	host := vmhost.GetVMHost(context)
 	return ManagedCreateECWithHost(host, dataHandle)
 }
@@ -1866,6 +1913,7 @@ func ManagedCreateECWithHost(host vmhost.VMHost, dataHandle int32) int32 {
 
 //export v1_4_getCurveLengthEC
 func v1_4_getCurveLengthEC(context unsafe.Pointer, ecHandle int32) int32 {
+	vmhost.TrackEICall(context, "getCurveLengthEC")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -1883,6 +1931,7 @@ func v1_4_getCurveLengthEC(context unsafe.Pointer, ecHandle int32) int32 {
 
 //export v1_4_getPrivKeyByteLengthEC
 func v1_4_getPrivKeyByteLengthEC(context unsafe.Pointer, ecHandle int32) int32 {
+	vmhost.TrackEICall(context, "getPrivKeyByteLengthEC")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -1900,6 +1949,7 @@ func v1_4_getPrivKeyByteLengthEC(context unsafe.Pointer, ecHandle int32) int32 {
 
 //export v1_4_ellipticCurveGetValues
 func v1_4_ellipticCurveGetValues(context unsafe.Pointer, ecHandle int32, fieldOrderHandle int32, basePointOrderHandle int32, eqConstantHandle int32, xBasePointHandle int32, yBasePointHandle int32) int32 {
+	vmhost.TrackEICall(context, "ellipticCurveGetValues")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -29,6 +29,9 @@ var ErrExecutionPanicked = errors.New("VM execution panicked")
 // ErrExecutionFailedWithTimeout signals that the execution failed with timeout
 var ErrExecutionFailedWithTimeout = errors.New("execution failed with timeout")
 
+// ErrExecutionCanceled signals that the execution was interrupted by the cancellation of its context
+var ErrExecutionCanceled = errors.New("execution canceled")
+
 // ErrMemoryLimit signals that too much memory was allocated by the contract
 var ErrMemoryLimit = errors.New("memory limit reached")
 
@@ -245,3 +248,48 @@ var ErrInvalidBuiltInFunctionCall = errors.New("invalid built in function call")
 
 // ErrCannotWriteOnReadOnly signals that write operation on read only is not allowed
 var ErrCannotWriteOnReadOnly = errors.New("cannot write on read only mode")
+
+// ErrGasEstimationFailed signals that the call fails regardless of the gas provided, so no gas estimate exists
+var ErrGasEstimationFailed = errors.New("gas estimation failed")
+
+// ErrNoGasScheduleFiles signals that the gas schedule directory holds no versioned gas schedule
+var ErrNoGasScheduleFiles = errors.New("no gas schedule files")
+
+// ErrNoGasScheduleForEpoch signals that no gas schedule is active in the given epoch
+var ErrNoGasScheduleForEpoch = errors.New("no gas schedule for epoch")
+
+// ErrInvalidGasScheduleVersions signals that the versions of the gas schedules do not increase with their activation epochs
+var ErrInvalidGasScheduleVersions = errors.New("invalid gas schedule versions")
+
+// ErrNilGasScheduleChangeHandler signals that a nil gas schedule change handler was provided
+var ErrNilGasScheduleChangeHandler = errors.New("nil gas schedule change handler")
+
+// ErrActiveGasScheduleChanged signals that the file of the active gas schedule was changed
+var ErrActiveGasScheduleChanged = errors.New("file of the active gas schedule changed")
+
+// ErrInvalidWarmInstanceCacheConfig signals that the warm instance cache configuration is invalid
+var ErrInvalidWarmInstanceCacheConfig = errors.New("invalid warm instance cache config")
+
+// ErrWarmInstanceCacheDisabled signals that the warm instance cache is disabled
+var ErrWarmInstanceCacheDisabled = errors.New("warm instance cache disabled")
+
+// ErrInvalidPrewarmTarget signals that a prewarm target has neither an address nor a code hash
+var ErrInvalidPrewarmTarget = errors.New("invalid prewarm target")
+
+// ErrCompiledCodeTooLarge signals that the compiled code exceeds the size limit of the compiled code store
+var ErrCompiledCodeTooLarge = errors.New("compiled code too large")
+
+// ErrCorruptedCompiledCode signals that a stored compiled code failed the integrity check
+var ErrCorruptedCompiledCode = errors.New("corrupted compiled code")
+
+// ErrInvalidCompiledCodeStoreConfig signals that the compiled code store configuration is invalid
+var ErrInvalidCompiledCodeStoreConfig = errors.New("invalid compiled code store config")
+
+// ErrNilImports signals that nil imports were provided to a WASM engine
+var ErrNilImports = errors.New("nil imports")
+
+// ErrInvalidQueryPoolConfig signals that the query pool configuration is invalid
+var ErrInvalidQueryPoolConfig = errors.New("invalid query pool config")
+
+// ErrOpcodeProfilingInUse signals that another runtime context of the process is profiling opcodes
+var ErrOpcodeProfilingInUse = errors.New("opcode profiling already in use by another host")
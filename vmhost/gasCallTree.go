@@ -0,0 +1,50 @@
+package vmhost
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteFoldedGasStacks writes the gas call tree in the folded-stack format consumed by flamegraph
+// tools: one line per frame with non-zero self gas, holding the semicolon-separated frames from
+// the root down to it, followed by the self gas
+func WriteFoldedGasStacks(writer io.Writer, roots []*GasTraceFrame) error {
+	for _, root := range roots {
+		err := writeFoldedGasStack(writer, nil, root)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeFoldedGasStack(writer io.Writer, parentLabels []string, frame *GasTraceFrame) error {
+	labels := append(parentLabels[:len(parentLabels):len(parentLabels)], foldedFrameLabel(frame))
+	if frame.SelfGas > 0 {
+		_, err := fmt.Fprintf(writer, "%s %d\n", strings.Join(labels, ";"), frame.SelfGas)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, child := range frame.Children {
+		err := writeFoldedGasStack(writer, labels, child)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func foldedFrameLabel(frame *GasTraceFrame) string {
+	switch frame.Kind {
+	case ContractCallFrame, AsyncCallbackFrame:
+		return fmt.Sprintf("%s:%s::%s", frame.Kind, hex.EncodeToString(frame.SCAddress), frame.Name)
+	default:
+		return fmt.Sprintf("%s:%s", frame.Kind, frame.Name)
+	}
+}
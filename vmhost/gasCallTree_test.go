@@ -0,0 +1,41 @@
+package vmhost
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteFoldedGasStacks(t *testing.T) {
+	t.Parallel()
+
+	roots := []*GasTraceFrame{
+		{
+			Kind:      ContractCallFrame,
+			SCAddress: []byte{0xaa},
+			Name:      "main",
+			SelfGas:   200,
+			Children: []*GasTraceFrame{
+				{Kind: EIFunctionFrame, Name: "getArgument", SelfGas: 10},
+				{
+					Kind:    BuiltinFunctionFrame,
+					Name:    "ESDTTransfer",
+					SelfGas: 0,
+					Children: []*GasTraceFrame{
+						{Kind: AsyncCallbackFrame, SCAddress: []byte{0xbb}, Name: "callBack", SelfGas: 30},
+					},
+				},
+			},
+		},
+	}
+
+	buffer := &bytes.Buffer{}
+	err := WriteFoldedGasStacks(buffer, roots)
+	require.Nil(t, err)
+	require.Equal(t,
+		"call:aa::main 200\n"+
+			"call:aa::main;ei:getArgument 10\n"+
+			"call:aa::main;builtin:ESDTTransfer;callback:bb::callBack 30\n",
+		buffer.String())
+}
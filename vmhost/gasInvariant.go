@@ -0,0 +1,32 @@
+package vmhost
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// GasInvariantViolation describes the first gas imbalance found by the gas invariant checker:
+// the boundary where it was detected, the frame of the call stack, the last EI hook which used
+// gas in that frame and the mismatching amounts
+type GasInvariantViolation struct {
+	Boundary string
+	Depth    int
+	Address  []byte
+	Function string
+	LastHook string
+	Expected uint64
+	Actual   uint64
+}
+
+// Error returns a description of the violation
+func (violation *GasInvariantViolation) Error() string {
+	return fmt.Sprintf("gas imbalance at %s in frame %d (%s, function %s) after EI hook %q: expected %d, actual %d",
+		violation.Boundary,
+		violation.Depth,
+		hex.EncodeToString(violation.Address),
+		violation.Function,
+		violation.LastHook,
+		violation.Expected,
+		violation.Actual,
+	)
+}
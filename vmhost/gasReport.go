@@ -0,0 +1,95 @@
+package vmhost
+
+import (
+	"fmt"
+	"sort"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/math"
+)
+
+// AccountGasUsed is the gas consumed by an account during an execution
+type AccountGasUsed struct {
+	Address []byte
+	GasUsed uint64
+}
+
+// GasReport shows how the gas provided to an execution was distributed: consumed by each
+// account, transferred to other shards or contracts, locked for async callbacks and remaining.
+// GasRefundedByBuiltins is the gas returned by the builtin functions to their callers, which
+// is already counted as consumed or remaining, so it is not part of the sum.
+type GasReport struct {
+	GasProvided           uint64
+	GasUsedByAccounts     []*AccountGasUsed
+	GasTransferred        uint64
+	GasLocked             uint64
+	GasRefundedByBuiltins uint64
+	GasRemaining          uint64
+}
+
+// NewGasReport creates the gas report of an execution from its VMOutput; a failed execution
+// does not hold output accounts, so all the gas not remaining is attributed to the recipient
+func NewGasReport(
+	gasProvided uint64,
+	recipient []byte,
+	vmOutput *vmcommon.VMOutput,
+	gasRefundedByBuiltins uint64,
+) *GasReport {
+	report := &GasReport{
+		GasProvided:           gasProvided,
+		GasUsedByAccounts:     make([]*AccountGasUsed, 0, len(vmOutput.OutputAccounts)),
+		GasRefundedByBuiltins: gasRefundedByBuiltins,
+		GasRemaining:          vmOutput.GasRemaining,
+	}
+
+	if vmOutput.ReturnCode != vmcommon.Ok {
+		report.GasUsedByAccounts = append(report.GasUsedByAccounts, &AccountGasUsed{
+			Address: recipient,
+			GasUsed: math.SubUint64(gasProvided, vmOutput.GasRemaining),
+		})
+		return report
+	}
+
+	for _, outputAccount := range vmOutput.OutputAccounts {
+		if outputAccount.GasUsed > 0 {
+			report.GasUsedByAccounts = append(report.GasUsedByAccounts, &AccountGasUsed{
+				Address: outputAccount.Address,
+				GasUsed: outputAccount.GasUsed,
+			})
+		}
+
+		for _, outputTransfer := range outputAccount.OutputTransfers {
+			report.GasTransferred = math.AddUint64(report.GasTransferred, outputTransfer.GasLimit)
+			report.GasLocked = math.AddUint64(report.GasLocked, outputTransfer.GasLocked)
+		}
+	}
+
+	sort.Slice(report.GasUsedByAccounts, func(i, j int) bool {
+		return string(report.GasUsedByAccounts[i].Address) < string(report.GasUsedByAccounts[j].Address)
+	})
+
+	return report
+}
+
+// GasConsumed returns the gas consumed by all the accounts
+func (report *GasReport) GasConsumed() uint64 {
+	gasConsumed := uint64(0)
+	for _, account := range report.GasUsedByAccounts {
+		gasConsumed = math.AddUint64(gasConsumed, account.GasUsed)
+	}
+
+	return gasConsumed
+}
+
+// Check verifies that the consumed, transferred, locked and remaining gas sum up to the provided gas
+func (report *GasReport) Check() error {
+	total := report.GasConsumed()
+	total = math.AddUint64(total, report.GasTransferred)
+	total = math.AddUint64(total, report.GasLocked)
+	total = math.AddUint64(total, report.GasRemaining)
+	if total != report.GasProvided {
+		return fmt.Errorf("%w: accounted %d, provided %d", ErrInputAndOutputGasDoesNotMatch, total, report.GasProvided)
+	}
+
+	return nil
+}
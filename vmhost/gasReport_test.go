@@ -0,0 +1,61 @@
+package vmhost
+
+import (
+	"errors"
+	"testing"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGasReport_SuccessfulExecution(t *testing.T) {
+	vmOutput := &vmcommon.VMOutput{
+		ReturnCode:   vmcommon.Ok,
+		GasRemaining: 100,
+		OutputAccounts: map[string]*vmcommon.OutputAccount{
+			"second": {
+				Address: []byte("second"),
+				GasUsed: 300,
+			},
+			"first": {
+				Address: []byte("first"),
+				GasUsed: 500,
+				OutputTransfers: []vmcommon.OutputTransfer{
+					{GasLimit: 40, GasLocked: 10},
+					{GasLimit: 50},
+				},
+			},
+			"receiver": {
+				Address: []byte("receiver"),
+			},
+		},
+	}
+
+	report := NewGasReport(1000, []byte("first"), vmOutput, 20)
+	require.Nil(t, report.Check())
+	require.Equal(t, []*AccountGasUsed{
+		{Address: []byte("first"), GasUsed: 500},
+		{Address: []byte("second"), GasUsed: 300},
+	}, report.GasUsedByAccounts)
+	require.Equal(t, uint64(800), report.GasConsumed())
+	require.Equal(t, uint64(90), report.GasTransferred)
+	require.Equal(t, uint64(10), report.GasLocked)
+	require.Equal(t, uint64(20), report.GasRefundedByBuiltins)
+	require.Equal(t, uint64(100), report.GasRemaining)
+
+	report.GasProvided = 1001
+	require.True(t, errors.Is(report.Check(), ErrInputAndOutputGasDoesNotMatch))
+}
+
+func TestNewGasReport_FailedExecution(t *testing.T) {
+	vmOutput := &vmcommon.VMOutput{
+		ReturnCode:   vmcommon.OutOfGas,
+		GasRemaining: 0,
+	}
+
+	report := NewGasReport(1000, []byte("contract"), vmOutput, 0)
+	require.Nil(t, report.Check())
+	require.Equal(t, []*AccountGasUsed{
+		{Address: []byte("contract"), GasUsed: 1000},
+	}, report.GasUsedByAccounts)
+}
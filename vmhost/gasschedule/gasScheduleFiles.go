@@ -0,0 +1,125 @@
+package gasschedule
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/multiversx/mx-chain-vm-v1_4-go/config"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/pelletier/go-toml"
+)
+
+// gasScheduleFileName matches the versioned gas schedules, e.g. gasScheduleV4_epoch120.toml is the
+// version 4 of the gas schedule, active starting with epoch 120
+var gasScheduleFileName = regexp.MustCompile(`^gasScheduleV(\d+)_epoch(\d+)\.toml$`)
+
+// GasScheduleVersion identifies a gas schedule file from the directory
+type GasScheduleVersion struct {
+	Version         uint32
+	ActivationEpoch uint32
+	FileName        string
+}
+
+// readGasScheduleVersions lists the versioned gas schedules of the directory, sorted by activation epoch;
+// the other files are ignored
+func readGasScheduleVersions(directory string) ([]*GasScheduleVersion, error) {
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]*GasScheduleVersion, 0)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		matches := gasScheduleFileName.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+
+		version, errVersion := strconv.ParseUint(matches[1], 10, 32)
+		activationEpoch, errEpoch := strconv.ParseUint(matches[2], 10, 32)
+		if errVersion != nil || errEpoch != nil {
+			return nil, fmt.Errorf("%w: %s", vmhost.ErrInvalidGasScheduleVersions, entry.Name())
+		}
+
+		versions = append(versions, &GasScheduleVersion{
+			Version:         uint32(version),
+			ActivationEpoch: uint32(activationEpoch),
+			FileName:        entry.Name(),
+		})
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("%w in %s", vmhost.ErrNoGasScheduleFiles, directory)
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].ActivationEpoch < versions[j].ActivationEpoch
+	})
+
+	for i := 1; i < len(versions); i++ {
+		if versions[i].ActivationEpoch == versions[i-1].ActivationEpoch || versions[i].Version <= versions[i-1].Version {
+			return nil, fmt.Errorf("%w: %s and %s",
+				vmhost.ErrInvalidGasScheduleVersions, versions[i-1].FileName, versions[i].FileName)
+		}
+	}
+
+	return versions, nil
+}
+
+// versionForEpoch returns the gas schedule with the highest activation epoch not after the given epoch
+func versionForEpoch(versions []*GasScheduleVersion, epoch uint32) (*GasScheduleVersion, error) {
+	var selected *GasScheduleVersion
+	for _, version := range versions {
+		if version.ActivationEpoch > epoch {
+			break
+		}
+		selected = version
+	}
+	if selected == nil {
+		return nil, fmt.Errorf("%w %d", vmhost.ErrNoGasScheduleForEpoch, epoch)
+	}
+
+	return selected, nil
+}
+
+// loadGasScheduleFile reads and parses a gas schedule, returning the hash of its contents as well,
+// so that the changes of a file already loaded are detected
+func loadGasScheduleFile(path string) (config.GasScheduleMap, []byte, error) {
+	fileContents, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	loadedTree, err := toml.LoadBytes(fileContents)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot interpret %s as toml: %w", path, err)
+	}
+
+	gasSchedule := make(config.GasScheduleMap)
+	for section, costs := range loadedTree.ToMap() {
+		costsMap, ok := costs.(map[string]interface{})
+		if !ok {
+			return nil, nil, fmt.Errorf("%s: %s is not a section", path, section)
+		}
+
+		gasSchedule[section] = make(map[string]uint64)
+		for key, cost := range costsMap {
+			intCost, ok := cost.(int64)
+			if !ok || intCost < 0 {
+				return nil, nil, fmt.Errorf("%s: invalid cost for %s.%s", path, section, key)
+			}
+			gasSchedule[section][key] = uint64(intCost)
+		}
+	}
+
+	hash := sha256.Sum256(fileContents)
+	return gasSchedule, hash[:], nil
+}
@@ -0,0 +1,245 @@
+package gasschedule
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/config"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+)
+
+var log = logger.GetOrCreate("vm/gasschedule")
+
+// GasScheduleChangeHandler is notified when another gas schedule becomes active; the VM host is one
+type GasScheduleChangeHandler interface {
+	GasScheduleChange(gasSchedule config.GasScheduleMap)
+	IsInterfaceNil() bool
+}
+
+// ArgsGasScheduleProvider holds the arguments needed to create a gasScheduleProvider
+type ArgsGasScheduleProvider struct {
+	Directory     string
+	StartEpoch    uint32
+	EpochNotifier vmcommon.EpochNotifier
+}
+
+// gasScheduleProvider loads the versioned gas schedules of a directory and activates the one
+// matching the confirmed epoch. A schedule is validated before being swapped in, so an invalid
+// file keeps the previous schedule active. The directory is read again on Reload, which picks up
+// the new versions for the next epochs; the gas schedule only changes when an epoch is confirmed,
+// and the changes of the file of the active version are rejected.
+type gasScheduleProvider struct {
+	mutSchedule   sync.RWMutex
+	directory     string
+	currentEpoch  uint32
+	versions      []*GasScheduleVersion
+	activeVersion *GasScheduleVersion
+	activeHash    []byte
+	gasSchedule   config.GasScheduleMap
+	handlers      []GasScheduleChangeHandler
+
+	mutWatch  sync.Mutex
+	closeChan chan struct{}
+}
+
+// NewGasScheduleProvider creates a new gasScheduleProvider, activating the gas schedule of the start epoch
+func NewGasScheduleProvider(args ArgsGasScheduleProvider) (*gasScheduleProvider, error) {
+	if check.IfNil(args.EpochNotifier) {
+		return nil, vmhost.ErrNilEpochNotifier
+	}
+
+	provider := &gasScheduleProvider{
+		directory:    args.Directory,
+		currentEpoch: args.StartEpoch,
+		handlers:     make([]GasScheduleChangeHandler, 0),
+	}
+
+	err := provider.Reload()
+	if err != nil {
+		return nil, err
+	}
+
+	args.EpochNotifier.RegisterNotifyHandler(provider)
+
+	return provider, nil
+}
+
+// RegisterHandler adds a handler notified of the gas schedule changes, passing it the active gas schedule
+func (provider *gasScheduleProvider) RegisterHandler(handler GasScheduleChangeHandler) error {
+	if check.IfNil(handler) {
+		return vmhost.ErrNilGasScheduleChangeHandler
+	}
+
+	provider.mutSchedule.Lock()
+	defer provider.mutSchedule.Unlock()
+
+	provider.handlers = append(provider.handlers, handler)
+	handler.GasScheduleChange(provider.gasSchedule)
+
+	return nil
+}
+
+// EpochConfirmed activates the gas schedule of the new epoch, if it is another one
+func (provider *gasScheduleProvider) EpochConfirmed(epoch uint32, _ uint64) {
+	provider.mutSchedule.Lock()
+	defer provider.mutSchedule.Unlock()
+
+	provider.currentEpoch = epoch
+	err := provider.activateGasScheduleForEpoch()
+	if err != nil {
+		log.Error("gas schedule not changed", "epoch", epoch, "error", err)
+	}
+}
+
+// Reload reads the directory again, the new versions being activated by the next confirmed epochs;
+// the first load activates the gas schedule of the current epoch. A changed file of the active version
+// is reported as ErrActiveGasScheduleChanged, the loaded gas schedule staying active
+func (provider *gasScheduleProvider) Reload() error {
+	versions, err := readGasScheduleVersions(provider.directory)
+	if err != nil {
+		return err
+	}
+
+	provider.mutSchedule.Lock()
+	defer provider.mutSchedule.Unlock()
+
+	provider.versions = versions
+	if provider.activeVersion == nil {
+		return provider.activateGasScheduleForEpoch()
+	}
+
+	_, hash, err := loadGasScheduleFile(filepath.Join(provider.directory, provider.activeVersion.FileName))
+	if err != nil {
+		return err
+	}
+
+	return provider.checkActiveGasScheduleHash(hash)
+}
+
+func (provider *gasScheduleProvider) checkActiveGasScheduleHash(hash []byte) error {
+	if bytes.Equal(hash, provider.activeHash) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", vmhost.ErrActiveGasScheduleChanged, provider.activeVersion.FileName)
+}
+
+func (provider *gasScheduleProvider) activateGasScheduleForEpoch() error {
+	version, err := versionForEpoch(provider.versions, provider.currentEpoch)
+	if err != nil {
+		return err
+	}
+
+	gasSchedule, hash, err := loadGasScheduleFile(filepath.Join(provider.directory, version.FileName))
+	if err != nil {
+		return err
+	}
+
+	isSameVersion := provider.activeVersion != nil && *provider.activeVersion == *version
+	if isSameVersion {
+		return provider.checkActiveGasScheduleHash(hash)
+	}
+
+	err = validateGasSchedule(gasSchedule)
+	if err != nil {
+		return err
+	}
+
+	provider.activeVersion = version
+	provider.activeHash = hash
+	provider.gasSchedule = gasSchedule
+	log.Debug("gas schedule activated",
+		"version", version.Version,
+		"activation epoch", version.ActivationEpoch,
+		"epoch", provider.currentEpoch)
+
+	for _, handler := range provider.handlers {
+		handler.GasScheduleChange(gasSchedule)
+	}
+
+	return nil
+}
+
+// validateGasSchedule rejects the gas schedules which the host would not be able to apply
+func validateGasSchedule(gasSchedule config.GasScheduleMap) error {
+	unknownKeys, err := config.ValidateGasSchedule(gasSchedule)
+	if err != nil {
+		return err
+	}
+	for _, issue := range unknownKeys {
+		log.Debug("unknown gas schedule key", "section", issue.Section, "key", issue.Key)
+	}
+
+	_, err = config.CreateGasConfig(gasSchedule)
+	return err
+}
+
+// ActiveVersion returns the version of the active gas schedule
+func (provider *gasScheduleProvider) ActiveVersion() GasScheduleVersion {
+	provider.mutSchedule.RLock()
+	defer provider.mutSchedule.RUnlock()
+
+	return *provider.activeVersion
+}
+
+// LatestGasSchedule returns the active gas schedule
+func (provider *gasScheduleProvider) LatestGasSchedule() config.GasScheduleMap {
+	provider.mutSchedule.RLock()
+	defer provider.mutSchedule.RUnlock()
+
+	return provider.gasSchedule
+}
+
+// StartWatching reloads the directory at the given interval, until Close is called
+func (provider *gasScheduleProvider) StartWatching(interval time.Duration) {
+	provider.mutWatch.Lock()
+	defer provider.mutWatch.Unlock()
+
+	if provider.closeChan != nil {
+		return
+	}
+
+	closeChan := make(chan struct{})
+	provider.closeChan = closeChan
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				err := provider.Reload()
+				if err != nil {
+					log.Error("gas schedule reload", "error", err)
+				}
+			case <-closeChan:
+				return
+			}
+		}
+	}()
+}
+
+// Close stops watching the directory
+func (provider *gasScheduleProvider) Close() error {
+	provider.mutWatch.Lock()
+	defer provider.mutWatch.Unlock()
+
+	if provider.closeChan != nil {
+		close(provider.closeChan)
+		provider.closeChan = nil
+	}
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (provider *gasScheduleProvider) IsInterfaceNil() bool {
+	return provider == nil
+}
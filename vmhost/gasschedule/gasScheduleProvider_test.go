@@ -0,0 +1,217 @@
+package gasschedule
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/config"
+	contextmock "github.com/multiversx/mx-chain-vm-v1_4-go/mock/context"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/mock"
+	"github.com/pelletier/go-toml"
+	"github.com/stretchr/testify/require"
+)
+
+func writeGasSchedule(t *testing.T, directory string, fileName string, gasSchedule config.GasScheduleMap) {
+	tree := make(map[string]interface{})
+	for section, costs := range gasSchedule {
+		sectionTree := make(map[string]interface{})
+		for key, cost := range costs {
+			sectionTree[key] = int64(cost)
+		}
+		tree[section] = sectionTree
+	}
+
+	loadedTree, err := toml.TreeFromMap(tree)
+	require.Nil(t, err)
+	err = os.WriteFile(filepath.Join(directory, fileName), []byte(loadedTree.String()), 0644)
+	require.Nil(t, err)
+}
+
+func gasScheduleWithStoreCost(storePerByte uint64) config.GasScheduleMap {
+	gasSchedule := config.MakeGasMapForTests()
+	gasSchedule["BaseOperationCost"]["StorePerByte"] = storePerByte
+	return gasSchedule
+}
+
+func createTestProvider(t *testing.T, directory string, startEpoch uint32) (*gasScheduleProvider, vmcommon.EpochSubscriberHandler) {
+	var subscriber vmcommon.EpochSubscriberHandler
+	epochNotifier := &mock.EpochNotifierStub{
+		RegisterNotifyHandlerCalled: func(handler vmcommon.EpochSubscriberHandler) {
+			subscriber = handler
+		},
+	}
+
+	provider, err := NewGasScheduleProvider(ArgsGasScheduleProvider{
+		Directory:     directory,
+		StartEpoch:    startEpoch,
+		EpochNotifier: epochNotifier,
+	})
+	require.Nil(t, err)
+	require.False(t, check.IfNil(provider))
+
+	return provider, subscriber
+}
+
+func TestNewGasScheduleProvider(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil epoch notifier should error", func(t *testing.T) {
+		t.Parallel()
+
+		provider, err := NewGasScheduleProvider(ArgsGasScheduleProvider{Directory: t.TempDir()})
+		require.Equal(t, vmhost.ErrNilEpochNotifier, err)
+		require.True(t, check.IfNil(provider))
+	})
+	t.Run("no gas schedule files should error", func(t *testing.T) {
+		t.Parallel()
+
+		directory := t.TempDir()
+		err := os.WriteFile(filepath.Join(directory, "gasScheduleV1.toml"), []byte(""), 0644)
+		require.Nil(t, err)
+
+		_, err = NewGasScheduleProvider(ArgsGasScheduleProvider{
+			Directory:     directory,
+			EpochNotifier: &mock.EpochNotifierStub{},
+		})
+		require.True(t, errors.Is(err, vmhost.ErrNoGasScheduleFiles))
+	})
+	t.Run("no gas schedule for the start epoch should error", func(t *testing.T) {
+		t.Parallel()
+
+		directory := t.TempDir()
+		writeGasSchedule(t, directory, "gasScheduleV1_epoch5.toml", config.MakeGasMapForTests())
+
+		_, err := NewGasScheduleProvider(ArgsGasScheduleProvider{
+			Directory:     directory,
+			StartEpoch:    4,
+			EpochNotifier: &mock.EpochNotifierStub{},
+		})
+		require.True(t, errors.Is(err, vmhost.ErrNoGasScheduleForEpoch))
+	})
+	t.Run("versions not increasing with the epochs should error", func(t *testing.T) {
+		t.Parallel()
+
+		directory := t.TempDir()
+		writeGasSchedule(t, directory, "gasScheduleV2_epoch0.toml", config.MakeGasMapForTests())
+		writeGasSchedule(t, directory, "gasScheduleV1_epoch5.toml", config.MakeGasMapForTests())
+
+		_, err := NewGasScheduleProvider(ArgsGasScheduleProvider{
+			Directory:     directory,
+			EpochNotifier: &mock.EpochNotifierStub{},
+		})
+		require.True(t, errors.Is(err, vmhost.ErrInvalidGasScheduleVersions))
+	})
+	t.Run("invalid start gas schedule should error", func(t *testing.T) {
+		t.Parallel()
+
+		directory := t.TempDir()
+		writeGasSchedule(t, directory, "gasScheduleV1_epoch0.toml", gasScheduleWithStoreCost(0))
+
+		_, err := NewGasScheduleProvider(ArgsGasScheduleProvider{
+			Directory:     directory,
+			EpochNotifier: &mock.EpochNotifierStub{},
+		})
+		require.NotNil(t, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		directory := t.TempDir()
+		writeGasSchedule(t, directory, "gasScheduleV1_epoch0.toml", gasScheduleWithStoreCost(10))
+		writeGasSchedule(t, directory, "gasScheduleV2_epoch5.toml", gasScheduleWithStoreCost(20))
+
+		provider, subscriber := createTestProvider(t, directory, 7)
+		require.Equal(t, provider, subscriber)
+		require.Equal(t, GasScheduleVersion{Version: 2, ActivationEpoch: 5, FileName: "gasScheduleV2_epoch5.toml"}, provider.ActiveVersion())
+		require.Equal(t, uint64(20), provider.LatestGasSchedule()["BaseOperationCost"]["StorePerByte"])
+	})
+}
+
+func TestGasScheduleProvider_EpochConfirmed(t *testing.T) {
+	t.Parallel()
+
+	directory := t.TempDir()
+	writeGasSchedule(t, directory, "gasScheduleV1_epoch0.toml", gasScheduleWithStoreCost(10))
+	writeGasSchedule(t, directory, "gasScheduleV2_epoch5.toml", gasScheduleWithStoreCost(20))
+	writeGasSchedule(t, directory, "gasScheduleV3_epoch10.toml", gasScheduleWithStoreCost(0))
+
+	provider, _ := createTestProvider(t, directory, 0)
+
+	storeCosts := make([]uint64, 0)
+	err := provider.RegisterHandler(&contextmock.VMHostStub{
+		GasScheduleChangeCalled: func(newGasSchedule config.GasScheduleMap) {
+			storeCosts = append(storeCosts, newGasSchedule["BaseOperationCost"]["StorePerByte"])
+		},
+	})
+	require.Nil(t, err)
+	require.Equal(t, []uint64{10}, storeCosts)
+
+	provider.EpochConfirmed(4, 0)
+	require.Equal(t, uint32(1), provider.ActiveVersion().Version)
+	require.Equal(t, []uint64{10}, storeCosts)
+
+	provider.EpochConfirmed(5, 0)
+	require.Equal(t, uint32(2), provider.ActiveVersion().Version)
+	require.Equal(t, []uint64{10, 20}, storeCosts)
+
+	// the version 3 is invalid, so the version 2 stays active
+	provider.EpochConfirmed(10, 0)
+	require.Equal(t, uint32(2), provider.ActiveVersion().Version)
+	require.Equal(t, []uint64{10, 20}, storeCosts)
+
+	err = provider.RegisterHandler(nil)
+	require.Equal(t, vmhost.ErrNilGasScheduleChangeHandler, err)
+}
+
+func TestGasScheduleProvider_Reload(t *testing.T) {
+	t.Parallel()
+
+	directory := t.TempDir()
+	writeGasSchedule(t, directory, "gasScheduleV1_epoch0.toml", gasScheduleWithStoreCost(10))
+
+	provider, _ := createTestProvider(t, directory, 3)
+
+	numChanges := 0
+	err := provider.RegisterHandler(&contextmock.VMHostStub{
+		GasScheduleChangeCalled: func(_ config.GasScheduleMap) {
+			numChanges++
+		},
+	})
+	require.Nil(t, err)
+	require.Equal(t, 1, numChanges)
+
+	err = provider.Reload()
+	require.Nil(t, err)
+	require.Equal(t, 1, numChanges)
+
+	// a new version of the current epoch is only activated by the next confirmed epoch
+	writeGasSchedule(t, directory, "gasScheduleV2_epoch3.toml", gasScheduleWithStoreCost(20))
+	err = provider.Reload()
+	require.Nil(t, err)
+	require.Equal(t, 1, numChanges)
+	require.Equal(t, uint32(1), provider.ActiveVersion().Version)
+
+	provider.EpochConfirmed(4, 0)
+	require.Equal(t, 2, numChanges)
+	require.Equal(t, uint32(2), provider.ActiveVersion().Version)
+
+	// the changes of the active file are rejected, both on reload and on the next epochs
+	writeGasSchedule(t, directory, "gasScheduleV2_epoch3.toml", gasScheduleWithStoreCost(25))
+	err = provider.Reload()
+	require.True(t, errors.Is(err, vmhost.ErrActiveGasScheduleChanged))
+	provider.EpochConfirmed(5, 0)
+	require.Equal(t, 2, numChanges)
+	require.Equal(t, uint64(20), provider.LatestGasSchedule()["BaseOperationCost"]["StorePerByte"])
+
+	err = os.WriteFile(filepath.Join(directory, "gasScheduleV2_epoch3.toml"), []byte("not toml = ["), 0644)
+	require.Nil(t, err)
+	err = provider.Reload()
+	require.NotNil(t, err)
+	require.Equal(t, 2, numChanges)
+	require.Equal(t, uint64(20), provider.LatestGasSchedule()["BaseOperationCost"]["StorePerByte"])
+}
@@ -0,0 +1,75 @@
+package vmhost
+
+import (
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// goInstanceContexts holds the live instance contexts of the engines not going through cgo, so that
+// GetVMHost can tell them apart from the Wasmer instance contexts without calling into Wasmer
+var goInstanceContexts = struct {
+	sync.RWMutex
+	contexts map[unsafe.Pointer]*GoInstanceContext
+}{
+	contexts: make(map[unsafe.Pointer]*GoInstanceContext),
+}
+
+// numGoInstanceContexts keeps the lookup off the EI calls made by Wasmer instances while no such context exists
+var numGoInstanceContexts int64
+
+// GoInstanceContext is the instance context received by the EI functions when called by an engine
+// not going through cgo, in place of the Wasmer instance context; it must be released with its instance.
+// Unlike the Wasmer context data, the host reference is held as a pointer, visible to the garbage collector.
+type GoInstanceContext struct {
+	host *VMHost
+}
+
+// NewGoInstanceContext creates and registers a new GoInstanceContext
+func NewGoInstanceContext() *GoInstanceContext {
+	context := &GoInstanceContext{}
+
+	goInstanceContexts.Lock()
+	goInstanceContexts.contexts[unsafe.Pointer(context)] = context
+	goInstanceContexts.Unlock()
+	atomic.AddInt64(&numGoInstanceContexts, 1)
+
+	return context
+}
+
+// SetHost sets the reference to the host returned by GetVMHost for this context
+func (context *GoInstanceContext) SetHost(host *VMHost) {
+	context.host = host
+}
+
+// Pointer returns the pointer to be passed to the EI functions
+func (context *GoInstanceContext) Pointer() unsafe.Pointer {
+	return unsafe.Pointer(context)
+}
+
+// Release unregisters the context, after which it must not be passed to the EI functions anymore
+func (context *GoInstanceContext) Release() {
+	goInstanceContexts.Lock()
+	_, registered := goInstanceContexts.contexts[unsafe.Pointer(context)]
+	delete(goInstanceContexts.contexts, unsafe.Pointer(context))
+	goInstanceContexts.Unlock()
+
+	if registered {
+		atomic.AddInt64(&numGoInstanceContexts, -1)
+	}
+}
+
+func getGoInstanceContextHost(vmHostPtr unsafe.Pointer) (*VMHost, bool) {
+	if atomic.LoadInt64(&numGoInstanceContexts) == 0 {
+		return nil, false
+	}
+
+	goInstanceContexts.RLock()
+	context, ok := goInstanceContexts.contexts[vmHostPtr]
+	goInstanceContexts.RUnlock()
+	if !ok || context.host == nil {
+		return nil, false
+	}
+
+	return context.host, true
+}
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	goRuntime "runtime"
 	"strings"
+	"sync/atomic"
 	"unsafe"
 
 	logger "github.com/multiversx/mx-chain-logger-go"
@@ -152,11 +153,34 @@ func GetVMHost(vmHostPtr unsafe.Pointer) VMHost {
 	if logVMHookCalls {
 		logVMHookCall()
 	}
+	host, ok := getGoInstanceContextHost(vmHostPtr)
+	if ok {
+		return *host
+	}
+
 	instCtx := wasmer.IntoInstanceContext(vmHostPtr)
 	var ptr = *(*uintptr)(instCtx.Data())
 	return *(*VMHost)(unsafe.Pointer(ptr))
 }
 
+// eiCallTrackingEnabled is set once a coverage tracker is enabled in the process; until then, the
+// EI functions skip the lookup of their host when tracking their calls
+var eiCallTrackingEnabled int32
+
+// EnableEICallTracking makes TrackEICall pass the EI calls to the coverage tracker of their host
+func EnableEICallTracking() {
+	atomic.StoreInt32(&eiCallTrackingEnabled, 1)
+}
+
+// TrackEICall records the call of an EI function for coverage purposes
+func TrackEICall(vmHostPtr unsafe.Pointer, eiFunctionName string) {
+	if atomic.LoadInt32(&eiCallTrackingEnabled) == 0 {
+		return
+	}
+
+	GetVMHost(vmHostPtr).Runtime().TrackEICall(eiFunctionName)
+}
+
 // GetBlockchainContext returns the blockchain context
 func GetBlockchainContext(vmHostPtr unsafe.Pointer) BlockchainContext {
 	return GetVMHost(vmHostPtr).Blockchain()
@@ -14,7 +14,6 @@ import (
 	"github.com/multiversx/mx-chain-vm-common-go/parsers"
 	"github.com/multiversx/mx-chain-vm-v1_4-go/math"
 	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
-	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/contexts"
 )
 
 func (host *vmHost) doRunSmartContractCreate(input *vmcommon.ContractCreateInput) *vmcommon.VMOutput {
@@ -94,7 +93,7 @@ func (host *vmHost) performCodeDeployment(input vmhost.CodeDeployInput) (*vmcomm
 	}
 
 	defer func() {
-		if !contexts.WarmInstancesEnabled {
+		if !runtime.IsWarmInstanceCacheEnabled() {
 			runtime.CleanInstance()
 		}
 	}()
@@ -170,7 +169,7 @@ func (host *vmHost) checkGasForGetCode(input *vmcommon.ContractCallInput, meteri
 	return nil
 }
 
-func (host *vmHost) doRunSmartContractCall(input *vmcommon.ContractCallInput) *vmcommon.VMOutput {
+func (host *vmHost) doRunSmartContractCall(input *vmcommon.ContractCallInput, readOnly bool) *vmcommon.VMOutput {
 	host.InitState()
 	defer func() {
 		errs := host.GetRuntimeErrors()
@@ -189,6 +188,7 @@ func (host *vmHost) doRunSmartContractCall(input *vmcommon.ContractCallInput) *v
 	}()
 
 	runtime.InitStateFromContractCallInput(input)
+	runtime.SetReadOnly(readOnly)
 	metering.InitStateFromContractCallInput(&input.VMInput)
 	output.AddTxValueToAccount(input.RecipientAddr, input.CallValue)
 	storage.SetAddress(runtime.GetContextAddress())
@@ -221,7 +221,7 @@ func (host *vmHost) doRunSmartContractCall(input *vmcommon.ContractCallInput) *v
 	}
 
 	defer func() {
-		if !contexts.WarmInstancesEnabled {
+		if !runtime.IsWarmInstanceCacheEnabled() {
 			runtime.CleanInstance()
 		}
 	}()
@@ -267,6 +267,14 @@ func copyTxHashesFromContext(runtime vmhost.RuntimeContext, input *vmcommon.Cont
 func (host *vmHost) ExecuteOnDestContext(input *vmcommon.ContractCallInput) (vmOutput *vmcommon.VMOutput, asyncInfo *vmhost.AsyncContextInfo, err error) {
 	log.Trace("ExecuteOnDestContext", "caller", input.CallerAddr, "dest", input.RecipientAddr, "function", input.Function)
 
+	err = host.checkExecutionContext()
+	if err != nil {
+		host.Runtime().AddError(err, input.Function)
+		vmOutput = host.Output().CreateVMOutputInCaseOfError(err)
+		return
+	}
+	defer host.failOnInterruption()
+
 	scExecutionInput := input
 
 	blockchain := host.Blockchain()
@@ -326,12 +334,17 @@ func (host *vmHost) executeOnDestContextNoBuiltinFunction(input *vmcommon.Contra
 	storage.PushState()
 	storage.SetAddress(runtime.GetContextAddress())
 
+	boundary := callBoundaryFromCallType(input.CallType)
+	host.enterCallBoundary(boundary, input)
+
 	defer func() {
 		vmOutput = host.finishExecuteOnDestContext(err)
 
 		if err == nil && vmOutput.ReturnCode != vmcommon.Ok {
 			err = vmhost.ErrExecutionFailed
 		}
+
+		host.exitCallBoundary(boundary, err)
 	}()
 
 	// Perform a value transfer to the called SC. If the execution fails, this
@@ -355,6 +368,17 @@ func (host *vmHost) executeOnDestContextNoBuiltinFunction(input *vmcommon.Contra
 	return
 }
 
+func callBoundaryFromCallType(callType vm.CallType) vmhost.CallBoundary {
+	switch callType {
+	case vm.AsynchronousCall:
+		return vmhost.AsyncCallBoundary
+	case vm.AsynchronousCallBack:
+		return vmhost.AsyncCallbackBoundary
+	default:
+		return vmhost.DestContextCallBoundary
+	}
+}
+
 func (host *vmHost) finishExecuteOnDestContext(executeErr error) *vmcommon.VMOutput {
 	managedTypes, _, metering, output, runtime, storage := host.GetContexts()
 
@@ -403,6 +427,13 @@ func (host *vmHost) ExecuteOnSameContext(input *vmcommon.ContractCallInput) (asy
 		return nil, vmhost.ErrBuiltinCallOnSameContextDisallowed
 	}
 
+	err = host.checkExecutionContext()
+	if err != nil {
+		host.Runtime().AddError(err, input.Function)
+		return nil, err
+	}
+	defer host.failOnInterruption()
+
 	managedTypes, blockchain, metering, output, runtime, _ := host.GetContexts()
 
 	// Back up the states of the contexts (except Storage, which isn't affected
@@ -428,9 +459,12 @@ func (host *vmHost) ExecuteOnSameContext(input *vmcommon.ContractCallInput) (asy
 
 	blockchain.PushState()
 
+	host.enterCallBoundary(vmhost.SameContextCallBoundary, input)
+
 	defer func() {
 		runtime.AddError(err, input.Function)
 		host.finishExecuteOnSameContext(err)
+		host.exitCallBoundary(vmhost.SameContextCallBoundary, err)
 	}()
 
 	// Perform a value transfer to the called SC. If the execution fails, this
@@ -508,6 +542,11 @@ func (host *vmHost) CreateNewContract(input *vmcommon.ContractCreateInput) (newC
 		}
 	}()
 
+	err = host.checkExecutionContext()
+	if err != nil {
+		return
+	}
+
 	_, blockchain, metering, output, runtime, _ := host.GetContexts()
 
 	codeDeployInput := vmhost.CodeDeployInput{
@@ -758,6 +797,9 @@ func (host *vmHost) ExecuteESDTTransfer(destination []byte, sender []byte, trans
 		}
 	}
 
+	metering.BeginGasTraceFrame(vmhost.BuiltinFunctionFrame, esdtTransferInput.Function)
+	defer metering.EndGasTraceFrame()
+
 	vmOutput, err := host.Blockchain().ProcessBuiltInFunction(esdtTransferInput)
 	log.Trace("ESDT transfer", "sender", sender, "dest", destination)
 	for _, transfer := range transfers {
@@ -796,6 +838,9 @@ func (host *vmHost) callBuiltinFunction(input *vmcommon.ContractCallInput) (*vmc
 		return nil, nil, vmhost.ErrInvalidCallOnReadOnlyMode
 	}
 
+	metering.BeginGasTraceFrame(vmhost.BuiltinFunctionFrame, input.Function)
+	defer metering.EndGasTraceFrame()
+
 	vmOutput, err := host.Blockchain().ProcessBuiltInFunction(input)
 	if err != nil {
 		metering.UseGas(input.GasProvided)
@@ -0,0 +1,114 @@
+package hostCore
+
+import (
+	"fmt"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/math"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+)
+
+// EstimateGas finds, by binary search, the minimal GasProvided for which the call succeeds;
+// the input.GasProvided is used as upper bound, or the block gas limit if not set. Each attempt
+// is executed on a snapshot of the blockchain state, which is reverted afterwards.
+func (host *vmHost) EstimateGas(input *vmcommon.ContractCallInput) (*vmhost.GasEstimate, error) {
+	if input == nil {
+		return nil, vmhost.ErrInvalidArgument
+	}
+
+	upperBound := input.GasProvided
+	if upperBound == 0 {
+		upperBound = host.Metering().BlockGasLimit()
+	}
+
+	vmOutput, err := host.runForGasEstimation(input, upperBound)
+	if err != nil {
+		return nil, err
+	}
+	if vmOutput.ReturnCode != vmcommon.Ok {
+		if vmOutput.ReturnCode == vmcommon.OutOfGas {
+			return &vmhost.GasEstimate{VMOutput: vmOutput}, vmhost.ErrNotEnoughGas
+		}
+		return &vmhost.GasEstimate{VMOutput: vmOutput}, fmt.Errorf("%w: %s", vmhost.ErrGasEstimationFailed, vmOutput.ReturnCode)
+	}
+
+	// the gas consumed with the upper bound is usually the answer, so it is attempted first and
+	// then one unit less, which fails in that case and ends the search; the calls consuming
+	// the gas they are given, like the asynchronous calls locking all the gas left or the calls
+	// forwarding it to nested calls, need the binary search, started right away when they
+	// consumed the whole upper bound
+	succeeded := upperBound
+	failed := uint64(0)
+	lastAttemptSucceeded := true
+	oneLessAttempted := false
+	gasProvided := math.SubUint64(upperBound, vmOutput.GasRemaining)
+	if gasProvided == succeeded {
+		oneLessAttempted = true
+		gasProvided = failed + (succeeded-failed)/2
+	}
+	for gasProvided > failed && gasProvided < succeeded {
+		attemptOutput, attemptErr := host.runForGasEstimation(input, gasProvided)
+		if attemptErr != nil {
+			return nil, attemptErr
+		}
+
+		lastAttemptSucceeded = attemptOutput.ReturnCode == vmcommon.Ok
+		if lastAttemptSucceeded {
+			succeeded = gasProvided
+			vmOutput = attemptOutput
+		} else {
+			failed = gasProvided
+		}
+
+		if lastAttemptSucceeded && !oneLessAttempted {
+			oneLessAttempted = true
+			gasProvided = succeeded - 1
+			continue
+		}
+		gasProvided = failed + (succeeded-failed)/2
+	}
+
+	// the prepare cost is read from the metering context, which must hold the state
+	// of a successful execution
+	if !lastAttemptSucceeded {
+		vmOutput, err = host.runForGasEstimation(input, succeeded)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return host.newGasEstimate(succeeded, vmOutput), nil
+}
+
+func (host *vmHost) runForGasEstimation(input *vmcommon.ContractCallInput, gasProvided uint64) (*vmcommon.VMOutput, error) {
+	attemptInput := *input
+	attemptInput.GasProvided = gasProvided
+
+	blockchain := host.Blockchain()
+	snapshot := blockchain.GetSnapshot()
+	defer blockchain.RevertToSnapshot(snapshot)
+
+	return host.RunSmartContractCall(&attemptInput)
+}
+
+func (host *vmHost) newGasEstimate(gasProvided uint64, vmOutput *vmcommon.VMOutput) *vmhost.GasEstimate {
+	gasLockedForCallbacks := uint64(0)
+	for _, outputAccount := range vmOutput.OutputAccounts {
+		for _, outputTransfer := range outputAccount.OutputTransfers {
+			gasLockedForCallbacks = math.AddUint64(gasLockedForCallbacks, outputTransfer.GasLocked)
+		}
+	}
+
+	prepareCost := host.Metering().GetSCPrepareInitialCost()
+	executionGas := math.SubUint64(gasProvided, vmOutput.GasRemaining)
+	executionGas = math.SubUint64(executionGas, prepareCost)
+	executionGas = math.SubUint64(executionGas, gasLockedForCallbacks)
+
+	return &vmhost.GasEstimate{
+		GasProvided:           gasProvided,
+		PrepareCost:           prepareCost,
+		ExecutionGas:          executionGas,
+		GasLockedForCallbacks: gasLockedForCallbacks,
+		VMOutput:              vmOutput,
+	}
+}
@@ -2,6 +2,7 @@ package hostCore
 
 import (
 	"context"
+	"errors"
 	"runtime/debug"
 	"sync"
 	"time"
@@ -53,6 +54,11 @@ type vmHost struct {
 	closingInstance  bool
 	executionTimeout time.Duration
 
+	// executionContext is the context of the running execution, checked by the nested executions,
+	// which set executionInterrupted once they find it done
+	executionContext     context.Context
+	executionInterrupted bool
+
 	ethInput []byte
 
 	blockchainContext   vmhost.BlockchainContext
@@ -62,12 +68,20 @@ type vmHost struct {
 	storageContext      vmhost.StorageContext
 	managedTypesContext vmhost.ManagedTypesContext
 
-	gasSchedule          config.GasScheduleMap
-	scAPIMethods         *wasmer.Imports
-	builtInFuncContainer vmcommon.BuiltInFunctionContainer
-	esdtTransferParser   vmcommon.ESDTTransferParser
-	enableEpochsHandler  vmhost.EnableEpochsHandler
-	activationEpochMap   map[uint32]struct{}
+	gasSchedule            config.GasScheduleMap
+	scAPIMethods           *wasmer.Imports
+	wasmEngine             vmhost.WasmEngine
+	builtInFuncContainer   vmcommon.BuiltInFunctionContainer
+	esdtTransferParser     vmcommon.ESDTTransferParser
+	enableEpochsHandler    vmhost.EnableEpochsHandler
+	activationEpochMap     map[uint32]struct{}
+	callDebugger           vmhost.CallDebugger
+	gasReport              *vmhost.GasReport
+	storageUsage           *vmhost.StorageUsage
+	opcodeCosts            [wasmer.OpcodeCount]uint32
+	compilationFingerprint []byte
+
+	strictGasScheduleValidation bool
 }
 
 // NewVMHost creates a new VM vmHost
@@ -119,6 +133,13 @@ func NewVMHost(
 		esdtTransferParser:   hostParameters.ESDTTransferParser,
 		executionTimeout:     minExecutionTimeout,
 		enableEpochsHandler:  hostParameters.EnableEpochsHandler,
+
+		strictGasScheduleValidation: hostParameters.StrictGasScheduleValidation,
+	}
+
+	err = host.validateGasSchedule(hostParameters.GasSchedule)
+	if err != nil {
+		return nil, err
 	}
 
 	host.activationEpochMap = createActivationMap(hostParameters)
@@ -134,13 +155,16 @@ func NewVMHost(
 		return nil, err
 	}
 
-	wasmerImports := wasmer.ConvertImports(imports)
-	err = wasmer.SetImports(wasmerImports)
+	host.wasmEngine = hostParameters.WasmEngine
+	if check.IfNil(host.wasmEngine) {
+		host.wasmEngine = contexts.NewWasmerEngine(hostParameters.WasmerSIGSEGVPassthrough)
+	}
+	err = host.wasmEngine.SetImports(imports)
 	if err != nil {
 		return nil, err
 	}
 
-	host.scAPIMethods = wasmerImports
+	host.scAPIMethods = wasmer.ConvertImports(imports)
 
 	host.blockchainContext, err = contexts.NewBlockchainContext(host, blockChainHook)
 	if err != nil {
@@ -152,6 +176,9 @@ func NewVMHost(
 		hostParameters.VMType,
 		host.builtInFuncContainer,
 		hostParameters.Hasher,
+		hostParameters.WarmInstanceCache,
+		hostParameters.CompiledCodeStore,
+		host.wasmEngine,
 	)
 	if err != nil {
 		return nil, err
@@ -188,15 +215,9 @@ func NewVMHost(
 
 	host.runtimeContext.SetMaxInstanceCount(MaximumWasmerInstanceCount)
 
-	opcodeCosts := gasCostConfig.WASMOpcodeCost.ToOpcodeCostsArray()
-	wasmer.SetOpcodeCosts(&opcodeCosts)
-	wasmer.SetRkyvSerializationEnabled(true)
-
-	if hostParameters.WasmerSIGSEGVPassthrough {
-		wasmer.SetSIGSEGVPassthrough()
-	}
-
-	wasmer.ForceInstallSighandlers()
+	host.opcodeCosts = gasCostConfig.WASMOpcodeCost.ToOpcodeCostsArray()
+	host.compilationFingerprint = vmhost.ComputeCompilationFingerprint(&host.opcodeCosts)
+	host.wasmEngine.SetOpcodeCosts(&host.opcodeCosts)
 
 	host.initContexts()
 	hostParameters.EpochNotifier.RegisterNotifyHandler(host)
@@ -296,6 +317,7 @@ func (host *vmHost) close() {
 func (host *vmHost) Close() error {
 	host.mutExecution.Lock()
 	host.close()
+	_ = host.runtimeContext.SetOpcodeProfiling(nil)
 	host.closingInstance = true
 	host.mutExecution.Unlock()
 
@@ -339,6 +361,12 @@ func (host *vmHost) GasScheduleChange(newGasSchedule config.GasScheduleMap) {
 	host.mutExecution.Lock()
 	defer host.mutExecution.Unlock()
 
+	err := host.validateGasSchedule(newGasSchedule)
+	if err != nil {
+		log.Error("cannot apply new gas config", "err", err)
+		return
+	}
+
 	host.gasSchedule = newGasSchedule
 	gasCostConfig, err := config.CreateGasConfig(newGasSchedule)
 	if err != nil {
@@ -346,11 +374,37 @@ func (host *vmHost) GasScheduleChange(newGasSchedule config.GasScheduleMap) {
 		return
 	}
 
+	// the opcode costs are compiled into the instances, so the warm instances are only kept if the new
+	// gas schedule does not change them; the compiled codes are tagged with the compilation fingerprint
+	// instead, the runtime rejecting and recompiling those not matching it
 	opcodeCosts := gasCostConfig.WASMOpcodeCost.ToOpcodeCostsArray()
-	wasmer.SetOpcodeCosts(&opcodeCosts)
+	opcodeCostsChanged := opcodeCosts != host.opcodeCosts
+	host.opcodeCosts = opcodeCosts
+	host.compilationFingerprint = vmhost.ComputeCompilationFingerprint(&host.opcodeCosts)
+	host.wasmEngine.SetOpcodeCosts(&host.opcodeCosts)
 
 	host.meteringContext.SetGasSchedule(newGasSchedule)
-	host.runtimeContext.ClearWarmInstanceCache()
+	if opcodeCostsChanged {
+		host.runtimeContext.ClearWarmInstanceCache()
+	}
+}
+
+// validateGasSchedule logs the problems of the gas schedule, returning them as error only
+// if the strict validation is enabled
+func (host *vmHost) validateGasSchedule(gasSchedule config.GasScheduleMap) error {
+	unknownKeys, err := config.ValidateGasSchedule(gasSchedule)
+	for _, issue := range unknownKeys {
+		log.Debug("unknown gas schedule key", "section", issue.Section, "key", issue.Key)
+	}
+	if err == nil {
+		return nil
+	}
+	if host.strictGasScheduleValidation {
+		return err
+	}
+
+	log.Warn("gas schedule validation", "err", err)
+	return nil
 }
 
 // GetGasScheduleMap returns the currently stored gas schedule
@@ -368,18 +422,196 @@ func (host *vmHost) SetGasTracing(enableGasTracing bool) {
 	host.meteringContext.SetGasTracing(enableGasTracing)
 }
 
+// SetCallTreeGasTracing configures the recording of the gas call tree, used in scenario tests
+func (host *vmHost) SetCallTreeGasTracing(enableCallTreeGasTracing bool) {
+	host.meteringContext.SetCallTreeGasTracing(enableCallTreeGasTracing)
+}
+
+// GetGasCallTree returns the gas call tree of the last execution, used in scenario tests
+func (host *vmHost) GetGasCallTree() []*vmhost.GasTraceFrame {
+	return host.meteringContext.GetGasCallTree()
+}
+
+// SetGasInvariantChecking enables or disables the checking of the conservation of gas at the
+// boundaries of the call stack frames; it is enabled by default in the builds with the gasdebug tag
+func (host *vmHost) SetGasInvariantChecking(enableGasInvariantChecking bool) {
+	host.meteringContext.SetGasInvariantChecking(enableGasInvariantChecking)
+}
+
+// GetGasInvariantViolation returns the first gas imbalance found during the last execution
+func (host *vmHost) GetGasInvariantViolation() *vmhost.GasInvariantViolation {
+	return host.meteringContext.GetGasInvariantViolation()
+}
+
+// GetMemoryUsage returns the linear memory used by the contract calls of the last execution
+func (host *vmHost) GetMemoryUsage() *vmhost.MemoryUsage {
+	return host.runtimeContext.GetMemoryUsage()
+}
+
+// GetGasReport returns the distribution of the gas provided to the last execution
+func (host *vmHost) GetGasReport() *vmhost.GasReport {
+	return host.gasReport
+}
+
+// GetStorageUsage returns the net change of the storage written by the last execution
+func (host *vmHost) GetStorageUsage() *vmhost.StorageUsage {
+	return host.storageUsage
+}
+
+// CompilationFingerprint returns the fingerprint of the opcode costs the contracts are currently compiled with
+func (host *vmHost) CompilationFingerprint() []byte {
+	return host.compilationFingerprint
+}
+
+// GetWarmInstanceCacheStats returns the counters of the warm instance cache, since the host was created
+func (host *vmHost) GetWarmInstanceCacheStats() vmhost.WarmInstanceCacheStats {
+	return host.runtimeContext.GetWarmInstanceCacheStats()
+}
+
+// PrewarmInstances loads the instances of the targets in the warm instance cache in the background,
+// one target at a time between the executions; the returned channel receives the report once all
+// the targets were processed
+func (host *vmHost) PrewarmInstances(targets []vmhost.PrewarmTarget) (<-chan *vmhost.PrewarmReport, error) {
+	if !host.runtimeContext.IsWarmInstanceCacheEnabled() {
+		return nil, vmhost.ErrWarmInstanceCacheDisabled
+	}
+
+	reportChan := make(chan *vmhost.PrewarmReport, 1)
+	go func() {
+		reportChan <- host.prewarmInstances(targets)
+		close(reportChan)
+	}()
+
+	return reportChan, nil
+}
+
+func (host *vmHost) prewarmInstances(targets []vmhost.PrewarmTarget) *vmhost.PrewarmReport {
+	startTime := time.Now()
+	report := &vmhost.PrewarmReport{
+		NumTargets: len(targets),
+		Failures:   make([]vmhost.PrewarmFailure, 0),
+	}
+
+	for _, target := range targets {
+		alreadyWarm, err := host.prewarmInstance(target)
+		switch {
+		case err != nil:
+			report.Failures = append(report.Failures, vmhost.PrewarmFailure{Target: target, Err: err})
+			log.Debug("prewarm instance failed", "address", target.Address, "codeHash", target.CodeHash, "error", err)
+		case alreadyWarm:
+			report.NumAlreadyWarm++
+		default:
+			report.NumWarmed++
+		}
+	}
+
+	report.Duration = time.Since(startTime)
+	log.Debug("prewarm instances done",
+		"targets", report.NumTargets,
+		"warmed", report.NumWarmed,
+		"already warm", report.NumAlreadyWarm,
+		"failed", len(report.Failures),
+		"duration", report.Duration)
+
+	return report
+}
+
+func (host *vmHost) prewarmInstance(target vmhost.PrewarmTarget) (alreadyWarm bool, err error) {
+	host.mutExecution.Lock()
+	defer host.mutExecution.Unlock()
+
+	if host.closingInstance {
+		return false, vmhost.ErrVMIsClosing
+	}
+
+	defer func() {
+		r := recover()
+		if r != nil {
+			log.Error("prewarm instance panicked", "error", r, "stack", "\n"+string(debug.Stack()))
+			alreadyWarm = false
+			err = vmhost.ErrExecutionPanicked
+		}
+	}()
+
+	host.InitState()
+	return host.runtimeContext.PrewarmInstance(target)
+}
+
+// updateStorageUsage computes the storage usage from the final values of the execution; the refund
+// it holds is informative only, the gas refund of the VMOutput being the one set by the metering
+func (host *vmHost) updateStorageUsage(vmOutput *vmcommon.VMOutput) {
+	releasePerByte := host.Metering().GasSchedule().BaseOperationCost.ReleasePerByte
+	host.storageUsage = host.Storage().GetStorageAccounting().ComputeUsage(vmOutput, releasePerByte)
+}
+
+func (host *vmHost) updateGasReport(gasProvided uint64, vmOutput *vmcommon.VMOutput) {
+	host.gasReport = vmhost.NewGasReport(
+		gasProvided,
+		host.Runtime().GetContextAddress(),
+		vmOutput,
+		host.Metering().GetGasRefundedByBuiltins(),
+	)
+
+	err := host.gasReport.Check()
+	if err != nil {
+		log.Warn("gas report", "error", err)
+	}
+}
+
+// SetCallDebugger sets the debugger notified at each nested call boundary, used in scenario tests;
+// a nil debugger disables the notifications
+func (host *vmHost) SetCallDebugger(debugger vmhost.CallDebugger) {
+	host.callDebugger = debugger
+}
+
+func (host *vmHost) enterCallBoundary(boundary vmhost.CallBoundary, input *vmcommon.ContractCallInput) {
+	if check.IfNil(host.callDebugger) {
+		return
+	}
+	host.callDebugger.EnterCall(host, boundary, input)
+}
+
+func (host *vmHost) exitCallBoundary(boundary vmhost.CallBoundary, err error) {
+	if check.IfNil(host.callDebugger) {
+		return
+	}
+	host.callDebugger.ExitCall(host, boundary, err)
+}
+
+// SetCoverageTracker sets the tracker of the contract functions and EI functions reached, used in scenario tests
+func (host *vmHost) SetCoverageTracker(tracker vmhost.CoverageTracker) {
+	host.runtimeContext.SetCoverageTracker(tracker)
+}
+
 // RunSmartContractCreate executes the deployment of a new contract
-func (host *vmHost) RunSmartContractCreate(input *vmcommon.ContractCreateInput) (vmOutput *vmcommon.VMOutput, err error) {
+func (host *vmHost) RunSmartContractCreate(input *vmcommon.ContractCreateInput) (*vmcommon.VMOutput, error) {
+	return host.RunSmartContractCreateWithContext(context.Background(), input)
+}
+
+// RunSmartContractCreateWithContext executes the deployment of a new contract, interrupted when the context
+// is done or when the execution timeout of the host elapses
+func (host *vmHost) RunSmartContractCreateWithContext(
+	parentCtx context.Context,
+	input *vmcommon.ContractCreateInput,
+) (vmOutput *vmcommon.VMOutput, err error) {
 	host.mutExecution.RLock()
 	defer host.mutExecution.RUnlock()
 
 	if host.closingInstance {
 		return nil, vmhost.ErrVMIsClosing
 	}
+	if parentCtx.Err() != nil {
+		return nil, interruptionError(parentCtx)
+	}
 
 	host.setGasTracerEnabledIfLogIsTrace()
-	ctx, cancel := context.WithTimeout(context.Background(), host.executionTimeout)
+	ctx, cancel := context.WithTimeout(parentCtx, host.executionTimeout)
 	defer cancel()
+	host.executionContext = ctx
+	host.executionInterrupted = false
+	defer func() {
+		host.executionContext = nil
+	}()
 
 	log.Trace("RunSmartContractCreate begin",
 		"len(code)", len(input.ContractCode),
@@ -413,33 +645,74 @@ func (host *vmHost) RunSmartContractCreate(input *vmcommon.ContractCreateInput)
 			"returnMessage", vmOutput.ReturnMessage,
 			"gasRemaining", vmOutput.GasRemaining)
 
+		host.updateStorageUsage(vmOutput)
+		host.updateGasReport(input.GasProvided, vmOutput)
 		host.logFromGasTracer("init")
 	}()
 
+	interrupted := false
 	select {
 	case <-done:
-		return
 	case <-ctx.Done():
-		host.Runtime().FailExecution(vmhost.ErrExecutionFailedWithTimeout)
-		<-done
-		err = vmhost.ErrExecutionFailedWithTimeout
+		interrupted = host.interruptExecution(ctx, done)
 	}
 
+	err = reportInterruption(ctx, vmOutput, err, interrupted || host.executionInterrupted)
 	return
 }
 
 // RunSmartContractCall executes the call of an existing contract
-func (host *vmHost) RunSmartContractCall(input *vmcommon.ContractCallInput) (vmOutput *vmcommon.VMOutput, err error) {
+func (host *vmHost) RunSmartContractCall(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	return host.RunSmartContractCallWithContext(context.Background(), input)
+}
+
+// RunSmartContractCallWithContext executes the call of an existing contract, interrupted when the context
+// is done or when the execution timeout of the host elapses
+func (host *vmHost) RunSmartContractCallWithContext(
+	ctx context.Context,
+	input *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	return host.runSmartContractCall(ctx, input, host.executionTimeout, false)
+}
+
+// RunSmartContractQuery executes the call of an existing contract in read-only mode, as the calls made
+// through executeReadOnly, failing it when the timeout elapses; a zero timeout selects the execution
+// timeout of the host. Upgrades are rejected, being writes by definition.
+func (host *vmHost) RunSmartContractQuery(input *vmcommon.ContractCallInput, timeout time.Duration) (*vmcommon.VMOutput, error) {
+	if input.Function == vmhost.UpgradeFunctionName {
+		return nil, vmhost.ErrInvalidCallOnReadOnlyMode
+	}
+	if timeout == 0 {
+		timeout = host.executionTimeout
+	}
+
+	return host.runSmartContractCall(context.Background(), input, timeout, true)
+}
+
+func (host *vmHost) runSmartContractCall(
+	parentCtx context.Context,
+	input *vmcommon.ContractCallInput,
+	timeout time.Duration,
+	readOnly bool,
+) (vmOutput *vmcommon.VMOutput, err error) {
 	host.mutExecution.RLock()
 	defer host.mutExecution.RUnlock()
 
 	if host.closingInstance {
 		return nil, vmhost.ErrVMIsClosing
 	}
+	if parentCtx.Err() != nil {
+		return nil, interruptionError(parentCtx)
+	}
 
 	host.setGasTracerEnabledIfLogIsTrace()
-	ctx, cancel := context.WithTimeout(context.Background(), host.executionTimeout)
+	ctx, cancel := context.WithTimeout(parentCtx, timeout)
 	defer cancel()
+	host.executionContext = ctx
+	host.executionInterrupted = false
+	defer func() {
+		host.executionContext = nil
+	}()
 
 	log.Trace("RunSmartContractCall begin",
 		"function", input.Function,
@@ -465,7 +738,7 @@ func (host *vmHost) RunSmartContractCall(input *vmcommon.ContractCallInput) (vmO
 		if isUpgrade {
 			vmOutput = host.doRunSmartContractUpgrade(input)
 		} else {
-			vmOutput = host.doRunSmartContractCall(input)
+			vmOutput = host.doRunSmartContractCall(input, readOnly)
 		}
 
 		logsFromErrors := host.createLogEntryFromErrors(input.CallerAddr, input.RecipientAddr, input.Function)
@@ -479,27 +752,93 @@ func (host *vmHost) RunSmartContractCall(input *vmcommon.ContractCallInput) (vmO
 			"returnMessage", vmOutput.ReturnMessage,
 			"gasRemaining", vmOutput.GasRemaining)
 
+		host.updateStorageUsage(vmOutput)
+		host.updateGasReport(input.GasProvided, vmOutput)
 		host.logFromGasTracer(input.Function)
 	}()
 
+	interrupted := false
 	select {
 	case <-done:
 		// Normal termination.
-		return
 	case <-ctx.Done():
-		// Terminated due to timeout. The VM sets the `ExecutionFailed` breakpoint
-		// in Wasmer. Also, the VM must wait for Wasmer to reach the end of a WASM
-		// basic block in order to close the WASM instance cleanly. This is done by
-		// reading the `done` channel once more, awaiting the call to `close(done)`
+		// Terminated due to timeout or cancellation. The VM sets the `ExecutionFailed`
+		// breakpoint in Wasmer. Also, the VM must wait for Wasmer to reach the end of
+		// a WASM basic block in order to close the WASM instance cleanly. This is done
+		// by reading the `done` channel once more, awaiting the call to `close(done)`
 		// from above.
-		host.Runtime().FailExecution(vmhost.ErrExecutionFailedWithTimeout)
-		<-done
-		err = vmhost.ErrExecutionFailedWithTimeout
+		interrupted = host.interruptExecution(ctx, done)
 	}
 
+	err = reportInterruption(ctx, vmOutput, err, interrupted || host.executionInterrupted)
 	return
 }
 
+// interruptionError returns the error reporting the interruption of an execution by its context,
+// telling the cancellation apart from the deadline
+func interruptionError(ctx context.Context) error {
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return vmhost.ErrExecutionCanceled
+	}
+
+	return vmhost.ErrExecutionFailedWithTimeout
+}
+
+// interruptExecution fails the running execution, whose context is done, and waits for it to stop;
+// it returns false, leaving the execution as it is, if the execution completed in the meantime
+func (host *vmHost) interruptExecution(ctx context.Context, done <-chan struct{}) bool {
+	select {
+	case <-done:
+		return false
+	default:
+	}
+
+	host.Runtime().FailExecution(interruptionError(ctx))
+	<-done
+	return true
+}
+
+// reportInterruption attributes the failure of an interrupted execution to its context and sets the
+// interruption as the return message of the output; the executions which completed before their context
+// was done keep their output, even if it is done by now
+func reportInterruption(ctx context.Context, vmOutput *vmcommon.VMOutput, err error, interrupted bool) error {
+	if !interrupted || ctx.Err() == nil {
+		return err
+	}
+	if err != nil && !errors.Is(err, vmhost.ErrExecutionFailedWithTimeout) && !errors.Is(err, vmhost.ErrExecutionCanceled) {
+		return err
+	}
+
+	err = interruptionError(ctx)
+	if vmOutput != nil {
+		vmOutput.ReturnCode = vmcommon.ExecutionFailed
+		vmOutput.ReturnMessage = err.Error()
+	}
+
+	return err
+}
+
+// checkExecutionContext returns the interruption error once the context of the running execution is done,
+// marking the execution as interrupted
+func (host *vmHost) checkExecutionContext() error {
+	ctx := host.executionContext
+	if ctx == nil || ctx.Err() == nil {
+		return nil
+	}
+
+	host.executionInterrupted = true
+	return interruptionError(ctx)
+}
+
+// failOnInterruption fails the calling contract when the context of the running execution ended during a
+// nested execution, so that it stops when regaining control instead of carrying on
+func (host *vmHost) failOnInterruption() {
+	err := host.checkExecutionContext()
+	if err != nil {
+		host.Runtime().FailExecution(err)
+	}
+}
+
 func (host *vmHost) createLogEntryFromErrors(sndAddress, rcvAddress []byte, function string) *vmcommon.LogEntry {
 	formattedErrors := host.runtimeContext.GetAllErrors()
 	if formattedErrors == nil {
@@ -1,12 +1,16 @@
 package hostCore
 
 import (
+	"context"
 	"testing"
 
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
 	"github.com/multiversx/mx-chain-vm-common-go/parsers"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/config"
+	contextmock "github.com/multiversx/mx-chain-vm-v1_4-go/mock/context"
 	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
 	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/mock"
 	"github.com/stretchr/testify/require"
@@ -95,4 +99,83 @@ func TestNewVMHost(t *testing.T) {
 		require.Nil(t, host)
 		require.ErrorIs(t, err, vmhost.ErrNilVMType)
 	})
+	t.Run("StrictGasScheduleValidation", func(t *testing.T) {
+		hostParameters := makeHostParameters()
+		hostParameters.GasSchedule = config.MakeGasMapForTests()
+		hostParameters.GasSchedule["WASMOpcodeCost"]["I32DivU"] = 2
+		hostParameters.StrictGasScheduleValidation = true
+		host, err := NewVMHost(blockchainHook, hostParameters)
+		require.Nil(t, host)
+
+		validationErr, ok := err.(*config.GasScheduleValidationError)
+		require.True(t, ok)
+		require.Equal(t, []*config.GasScheduleIssue{
+			{Section: "WASMOpcodeCost", Key: "I32DivU", Kind: config.InconsistentGasCost},
+		}, validationErr.Issues)
+	})
+}
+
+func TestVMHost_GasScheduleChange(t *testing.T) {
+	numCompiledCodesClears := 0
+	blockchainHook := &contextmock.BlockchainHookStub{
+		ClearCompiledCodesCalled: func() {
+			numCompiledCodesClears++
+		},
+	}
+	esdtTransferParser, err := parsers.NewESDTTransferParser(worldmock.WorldMarshalizer)
+	require.Nil(t, err)
+
+	host, err := NewVMHost(blockchainHook, &vmhost.VMHostParameters{
+		VMType:               []byte("vmType"),
+		ESDTTransferParser:   esdtTransferParser,
+		BuiltInFuncContainer: builtInFunctions.NewBuiltInFunctionContainer(),
+		EpochNotifier:        &mock.EpochNotifierStub{},
+		EnableEpochsHandler:  &mock.EnableEpochsHandlerStub{},
+		Hasher:               worldmock.DefaultHasher,
+		GasSchedule:          config.MakeGasMapForTests(),
+		ProtectedKeyPrefix:   []byte(core.ProtectedKeyPrefix),
+	})
+	require.Nil(t, err)
+	// the epoch notifier stub confirms the epoch 0 on registration
+	numCompiledCodesClears = 0
+
+	fingerprint := host.CompilationFingerprint()
+
+	gasSchedule := config.MakeGasMapForTests()
+	gasSchedule["BaseOperationCost"]["StorePerByte"] = 100
+	host.GasScheduleChange(gasSchedule)
+	require.Equal(t, uint64(100), host.Metering().GasSchedule().BaseOperationCost.StorePerByte)
+	require.Equal(t, fingerprint, host.CompilationFingerprint())
+
+	// the compiled codes are kept, being rejected by the fingerprint when used
+	gasSchedule = config.MakeGasMapForTests()
+	gasSchedule["WASMOpcodeCost"]["I32Add"] = 10
+	host.GasScheduleChange(gasSchedule)
+	require.Equal(t, uint32(10), host.Metering().GasSchedule().WASMOpcodeCost.I32Add)
+	require.NotEqual(t, fingerprint, host.CompilationFingerprint())
+	require.Equal(t, 0, numCompiledCodesClears)
+}
+
+func TestReportInterruption(t *testing.T) {
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	t.Run("completed before the context was done", func(t *testing.T) {
+		vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.UserError, ReturnMessage: "user error"}
+		err := reportInterruption(canceledCtx, vmOutput, nil, false)
+		require.Nil(t, err)
+		require.Equal(t, vmcommon.UserError, vmOutput.ReturnCode)
+		require.Equal(t, "user error", vmOutput.ReturnMessage)
+	})
+	t.Run("interrupted", func(t *testing.T) {
+		vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.UserError, ReturnMessage: "user error"}
+		err := reportInterruption(canceledCtx, vmOutput, nil, true)
+		require.Equal(t, vmhost.ErrExecutionCanceled, err)
+		require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+		require.Equal(t, vmhost.ErrExecutionCanceled.Error(), vmOutput.ReturnMessage)
+	})
+	t.Run("interrupted, but failed for another reason", func(t *testing.T) {
+		err := reportInterruption(canceledCtx, nil, vmhost.ErrExecutionPanicked, true)
+		require.Equal(t, vmhost.ErrExecutionPanicked, err)
+	})
 }
@@ -0,0 +1,98 @@
+package hostCoretest
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-v1_4-go/config"
+	test "github.com/multiversx/mx-chain-vm-v1_4-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompiledCodeFingerprint_GasScheduleChangedMidRun(t *testing.T) {
+	world := mock.NewMockWorldVM14()
+	host, instance := createHostWithMockInstance(t, world, nil)
+	defer host.Reset()
+
+	codeHash := host.Blockchain().GetCodeHash(test.ParentAddress)
+	input := test.CreateTestContractCallInputBuilder().
+		WithRecipientAddr(test.ParentAddress).
+		WithGasProvided(simpleGasTestConfig.GasProvided).
+		WithFunction("noOp").
+		Build()
+	runCall := func() {
+		setZeroCodeCosts(host)
+
+		vmOutput, err := host.RunSmartContractCall(input)
+		verify := test.NewVMOutputVerifier(t, vmOutput, err)
+		verify.Ok()
+	}
+	requireCompiledCodeSavedWith := func(fingerprint []byte) {
+		found, compiledCode := world.GetCompiledCode(vmhost.CompiledCodeKey(codeHash, fingerprint))
+		require.True(t, found)
+		require.Equal(t, instance.Code, compiledCode)
+	}
+
+	runCall()
+	runCall()
+	stats := host.GetWarmInstanceCacheStats()
+	require.Equal(t, uint64(1), stats.InstancesFromBytecode)
+	require.Equal(t, uint64(1), stats.Hits)
+	requireCompiledCodeSavedWith(host.CompilationFingerprint())
+	oldFingerprint := host.CompilationFingerprint()
+
+	// a gas schedule change keeping the opcode costs keeps the warm instances
+	gasSchedule := config.MakeGasMapForTests()
+	gasSchedule["BaseOperationCost"]["StorePerByte"] = 100
+	host.GasScheduleChange(gasSchedule)
+	runCall()
+	stats = host.GetWarmInstanceCacheStats()
+	require.Equal(t, uint64(1), stats.InstancesFromBytecode)
+	require.Equal(t, uint64(2), stats.Hits)
+
+	// the warm instance and the compiled code produced under the old opcode costs are not used
+	gasSchedule = config.MakeGasMapForTests()
+	gasSchedule["WASMOpcodeCost"]["I32Add"] = 10
+	host.GasScheduleChange(gasSchedule)
+	runCall()
+	stats = host.GetWarmInstanceCacheStats()
+	require.Equal(t, uint64(2), stats.InstancesFromBytecode)
+	require.Equal(t, uint64(0), stats.InstancesFromPrecompiled)
+	requireCompiledCodeSavedWith(host.CompilationFingerprint())
+	requireCompiledCodeSavedWith(oldFingerprint)
+
+	// the recompiled code is used once the warm instances are gone
+	host.Reset()
+	runCall()
+	stats = host.GetWarmInstanceCacheStats()
+	require.Equal(t, uint64(2), stats.InstancesFromBytecode)
+	require.Equal(t, uint64(1), stats.InstancesFromPrecompiled)
+}
+
+func TestCompiledCodeFingerprint_CompiledCodeKeyedOnCodeHashOnlyIsIgnored(t *testing.T) {
+	world := mock.NewMockWorldVM14()
+	host, instance := createHostWithMockInstance(t, world, nil)
+	defer host.Reset()
+
+	// compiled code saved under the code hash alone, as by an older version, which keeps it
+	codeHash := host.Blockchain().GetCodeHash(test.ParentAddress)
+	world.SaveCompiledCode(codeHash, instance.Code)
+
+	setZeroCodeCosts(host)
+	vmOutput, err := host.RunSmartContractCall(test.CreateTestContractCallInputBuilder().
+		WithRecipientAddr(test.ParentAddress).
+		WithGasProvided(simpleGasTestConfig.GasProvided).
+		WithFunction("noOp").
+		Build())
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+
+	stats := host.GetWarmInstanceCacheStats()
+	require.Equal(t, uint64(1), stats.InstancesFromBytecode)
+	require.Equal(t, uint64(0), stats.InstancesFromPrecompiled)
+
+	found, compiledCode := world.GetCompiledCode(codeHash)
+	require.True(t, found)
+	require.Equal(t, instance.Code, compiledCode)
+}
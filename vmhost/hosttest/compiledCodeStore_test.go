@@ -0,0 +1,90 @@
+package hostCoretest
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+	"github.com/multiversx/mx-chain-vm-common-go/parsers"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/config"
+	contextmock "github.com/multiversx/mx-chain-vm-v1_4-go/mock/context"
+	test "github.com/multiversx/mx-chain-vm-v1_4-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/compiledstore"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/hostCore"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createHostWithMockInstance(t *testing.T, world *worldmock.MockWorld, compiledCodeStore vmhost.CompiledCodeStore) (vmhost.VMHost, *contextmock.InstanceMock) {
+	esdtTransferParser, _ := parsers.NewESDTTransferParser(worldmock.WorldMarshalizer)
+	host, err := hostCore.NewVMHost(world, &vmhost.VMHostParameters{
+		VMType:               test.DefaultVMType,
+		BlockGasLimit:        uint64(1000),
+		GasSchedule:          config.MakeGasMapForTests(),
+		BuiltInFuncContainer: builtInFunctions.NewBuiltInFunctionContainer(),
+		ProtectedKeyPrefix:   []byte(core.ProtectedKeyPrefix),
+		ESDTTransferParser:   esdtTransferParser,
+		EpochNotifier:        &mock.EpochNotifierStub{},
+		EnableEpochsHandler:  &mock.EnableEpochsHandlerStub{},
+		Hasher:               worldmock.DefaultHasher,
+		CompiledCodeStore:    compiledCodeStore,
+	})
+	require.Nil(t, err)
+
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
+	instance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, nil, nil, nil, 0, 0)
+	noOpMock(instance, nil)
+
+	return host, instance
+}
+
+func TestCompiledCodeStore_UsedWhenTheBlockchainHookHasNoCompiledCode(t *testing.T) {
+	compiledCodeStore, err := compiledstore.NewFileCompiledCodeStore(compiledstore.ArgsFileCompiledCodeStore{
+		Directory:    t.TempDir(),
+		MaxEntrySize: 1024,
+		MaxTotalSize: 4096,
+	})
+	require.Nil(t, err)
+
+	world := mock.NewMockWorldVM14()
+	host, _ := createHostWithMockInstance(t, world, compiledCodeStore)
+	defer host.Reset()
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithRecipientAddr(test.ParentAddress).
+		WithGasProvided(simpleGasTestConfig.GasProvided).
+		WithFunction("noOp").
+		Build()
+	runCall := func() {
+		host.Reset()
+		world.ClearCompiledCodes()
+		setZeroCodeCosts(host)
+
+		vmOutput, err := host.RunSmartContractCall(input)
+		verify := test.NewVMOutputVerifier(t, vmOutput, err)
+		verify.Ok()
+	}
+
+	runCall()
+	stats := host.GetWarmInstanceCacheStats()
+	require.Equal(t, uint64(1), stats.InstancesFromBytecode)
+	require.Equal(t, uint64(0), stats.InstancesFromPrecompiled)
+
+	runCall()
+	stats = host.GetWarmInstanceCacheStats()
+	require.Equal(t, uint64(1), stats.InstancesFromBytecode)
+	require.Equal(t, uint64(1), stats.InstancesFromPrecompiled)
+
+	// the code compiled with other opcode costs is not used
+	gasSchedule := config.MakeGasMapForTests()
+	gasSchedule["WASMOpcodeCost"]["I32Add"] = 10
+	host.GasScheduleChange(gasSchedule)
+
+	runCall()
+	stats = host.GetWarmInstanceCacheStats()
+	require.Equal(t, uint64(2), stats.InstancesFromBytecode)
+	require.Equal(t, uint64(1), stats.InstancesFromPrecompiled)
+}
@@ -0,0 +1,43 @@
+package hostCoretest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-v1_4-go/interpreter"
+	test "github.com/multiversx/mx-chain-vm-v1_4-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/contexts"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/hostCore"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoverage_EICallsCountedOnce(t *testing.T) {
+	world := mock.NewMockWorldVM14()
+	host, err := hostCore.NewVMHost(world, makeWasmEngineHostParameters(interpreter.NewEngine()))
+	require.Nil(t, err)
+	defer host.Reset()
+
+	coverageTracker := contexts.NewEnabledCoverageTracker()
+	host.SetCoverageTracker(coverageTracker)
+
+	scAddress := test.MakeTestSCAddress("counter")
+	world.AcctMap.CreateSmartContractAccount(test.ParentAddress, scAddress, test.GetTestSCCode("counter", "../../"), world)
+
+	vmOutput, err := host.RunSmartContractCall(createCounterCallInput(scAddress))
+	test.NewVMOutputVerifier(t, vmOutput, err).
+		Ok().
+		ReturnData(big.NewInt(1).Bytes())
+
+	coverage := coverageTracker.GetCoverage()
+	require.Len(t, coverage, 1)
+	for _, contractCoverage := range coverage {
+		require.Equal(t, map[string]uint64{increment: 1}, contractCoverage.FunctionCalls)
+		// the int64 EI functions are wrappers of the smallInt ones, which are not counted as called
+		require.Equal(t, map[string]uint64{
+			"int64storageLoad":  1,
+			"int64storageStore": 1,
+			"int64finish":       1,
+		}, contractCoverage.EICalls)
+	}
+}
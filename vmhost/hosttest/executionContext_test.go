@@ -0,0 +1,147 @@
+package hostCoretest
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/interpreter"
+	contextmock "github.com/multiversx/mx-chain-vm-v1_4-go/mock/context"
+	test "github.com/multiversx/mx-chain-vm-v1_4-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/hostCore"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createCounterCallInput(scAddress []byte) *vmcommon.ContractCallInput {
+	return test.CreateTestContractCallInputBuilder().
+		WithRecipientAddr(scAddress).
+		WithGasProvided(100000).
+		WithFunction(increment).
+		Build()
+}
+
+func TestExecutionContext_DoneBeforeTheExecution(t *testing.T) {
+	world := mock.NewMockWorldVM14()
+	host, err := hostCore.NewVMHost(world, makeWasmEngineHostParameters(interpreter.NewEngine()))
+	require.Nil(t, err)
+	defer host.Reset()
+
+	scAddress := test.MakeTestSCAddress("counter")
+	code := test.GetTestSCCode("counter", "../../")
+	world.AcctMap.CreateSmartContractAccount(test.ParentAddress, scAddress, code, world)
+
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	vmOutput, err := host.RunSmartContractCallWithContext(canceledCtx, createCounterCallInput(scAddress))
+	require.Equal(t, vmhost.ErrExecutionCanceled, err)
+	require.Nil(t, vmOutput)
+
+	expiredCtx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	vmOutput, err = host.RunSmartContractCallWithContext(expiredCtx, createCounterCallInput(scAddress))
+	require.Equal(t, vmhost.ErrExecutionFailedWithTimeout, err)
+	require.Nil(t, vmOutput)
+
+	vmOutput, err = host.RunSmartContractCreateWithContext(canceledCtx, test.CreateTestContractCreateInputBuilder().
+		WithContractCode(code).
+		WithGasProvided(100000).
+		Build())
+	require.Equal(t, vmhost.ErrExecutionCanceled, err)
+	require.Nil(t, vmOutput)
+
+	vmOutput, err = host.RunSmartContractCallWithContext(context.Background(), createCounterCallInput(scAddress))
+	test.NewVMOutputVerifier(t, vmOutput, err).
+		Ok().
+		ReturnData(big.NewInt(1).Bytes())
+}
+
+// waitForBreakpoint polls the breakpoint of the running instance, which the host sets from its own
+// goroutine, until it has the expected value or the timeout passes
+func waitForBreakpoint(host vmhost.VMHost, expected vmhost.BreakpointValue, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if host.Runtime().GetRuntimeBreakpointValue() == expected {
+			return true
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	return false
+}
+
+func TestExecutionContext_CanceledDuringTheExecution(t *testing.T) {
+	world := mock.NewMockWorldVM14()
+	engine := interpreter.NewEngine()
+	host, err := hostCore.NewVMHost(world, makeWasmEngineHostParameters(engine))
+	require.Nil(t, err)
+	defer host.Reset()
+
+	scAddress := test.MakeTestSCAddress("counter")
+	code := test.GetTestSCCode("counter", "../../")
+	world.AcctMap.CreateSmartContractAccount(test.ParentAddress, scAddress, code, world)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// the step handler runs on the execution goroutine, so it only records whether the breakpoint was
+	// set, to be checked once the execution returned
+	breakpointSet := false
+	engine.SetStepHandler(func(step *interpreter.Step) error {
+		if step.FunctionName == increment && step.PC == 0 {
+			cancel()
+			breakpointSet = waitForBreakpoint(host, vmhost.BreakpointExecutionFailed, time.Second)
+		}
+		return nil
+	})
+
+	vmOutput, err := host.RunSmartContractCallWithContext(ctx, createCounterCallInput(scAddress))
+	require.True(t, breakpointSet)
+	require.Equal(t, vmhost.ErrExecutionCanceled, err)
+	require.NotNil(t, vmOutput)
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, vmhost.ErrExecutionCanceled.Error(), vmOutput.ReturnMessage)
+}
+
+func TestExecutionContext_PropagatedToNestedExecutions(t *testing.T) {
+	host, world, imb := test.DefaultTestVMForCallWithInstanceMocks(t)
+	defer host.Reset()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	childExecuted := false
+	var nestedErr error
+	parentInstance := imb.CreateAndStoreInstanceMock(t, host, test.ParentAddress, nil, nil, nil, 0, 1000)
+	parentInstance.AddMockMethod("callChild", func() *contextmock.InstanceMock {
+		cancel()
+
+		childInput := test.DefaultTestContractCallInput()
+		childInput.CallerAddr = test.ParentAddress
+		childInput.RecipientAddr = test.ChildAddress
+		childInput.Function = "doSomething"
+		childInput.GasProvided = 1000
+		_, _, nestedErr = host.ExecuteOnDestContext(childInput)
+
+		return parentInstance
+	})
+	childInstance := imb.CreateAndStoreInstanceMock(t, host, test.ChildAddress, nil, nil, nil, 0, 0)
+	childInstance.AddMockMethod("doSomething", func() *contextmock.InstanceMock {
+		childExecuted = true
+		return childInstance
+	})
+	world.CreateStateBackup()
+
+	vmOutput, err := host.RunSmartContractCallWithContext(ctx, test.CreateTestContractCallInputBuilder().
+		WithRecipientAddr(test.ParentAddress).
+		WithGasProvided(10000).
+		WithFunction("callChild").
+		Build())
+	require.Equal(t, vmhost.ErrExecutionCanceled, err)
+	require.Equal(t, vmhost.ErrExecutionCanceled, nestedErr)
+	require.False(t, childExecuted)
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, vmhost.ErrExecutionCanceled.Error(), vmOutput.ReturnMessage)
+}
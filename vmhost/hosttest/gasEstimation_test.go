@@ -0,0 +1,152 @@
+package hostCoretest
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	"github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/interpreter"
+	contextmock "github.com/multiversx/mx-chain-vm-v1_4-go/mock/context"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/contexts"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/hostCore"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateGas_MinimalGas(t *testing.T) {
+	code := testcommon.GetTestSCCode("baseOps", "../../")
+	host, _ := testcommon.DefaultTestVMForCall(t, code, nil)
+	defer func() {
+		host.Reset()
+	}()
+
+	input := testcommon.DefaultTestContractCallInput()
+	input.GasProvided = 1000000
+	input.Function = "test_getCallValue_1byte"
+	input.CallValue = big.NewInt(64)
+
+	estimate, err := host.EstimateGas(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, estimate.VMOutput.ReturnCode)
+	require.Less(t, estimate.GasProvided, input.GasProvided)
+	require.Greater(t, estimate.PrepareCost, uint64(0))
+	require.Equal(t, uint64(0), estimate.GasLockedForCallbacks)
+	require.Equal(t, estimate.GasProvided, estimate.PrepareCost+estimate.ExecutionGas+estimate.VMOutput.GasRemaining)
+
+	input.GasProvided = estimate.GasProvided
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	input.GasProvided = estimate.GasProvided - 1
+	vmOutput, err = host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.NotEqual(t, vmcommon.Ok, vmOutput.ReturnCode)
+}
+
+func TestEstimateGas_FailingCall(t *testing.T) {
+	code := testcommon.GetTestSCCode("baseOps", "../../")
+	host, _ := testcommon.DefaultTestVMForCall(t, code, nil)
+	defer func() {
+		host.Reset()
+	}()
+
+	input := testcommon.DefaultTestContractCallInput()
+	input.GasProvided = 1000000
+	input.Function = "missingFunction"
+
+	estimate, err := host.EstimateGas(input)
+	require.True(t, errors.Is(err, vmhost.ErrGasEstimationFailed))
+	require.Equal(t, vmcommon.FunctionNotFound, estimate.VMOutput.ReturnCode)
+
+	input.Function = "test_getCallValue_1byte"
+	input.GasProvided = 1
+	_, err = host.EstimateGas(input)
+	require.Equal(t, vmhost.ErrNotEnoughGas, err)
+}
+
+func TestEstimateGas_ConsumedGasAttemptedFirst(t *testing.T) {
+	world := mock.NewMockWorldVM14()
+	host, err := hostCore.NewVMHost(world, makeWasmEngineHostParameters(interpreter.NewEngine()))
+	require.Nil(t, err)
+	defer host.Reset()
+
+	scAddress := testcommon.MakeTestSCAddress("counter")
+	world.AcctMap.CreateSmartContractAccount(testcommon.ParentAddress, scAddress, testcommon.GetTestSCCode("counter", "../../"), world)
+
+	input := createCounterCallInput(scAddress)
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	gasConsumed := input.GasProvided - vmOutput.GasRemaining
+
+	coverageTracker := contexts.NewEnabledCoverageTracker()
+	host.SetCoverageTracker(coverageTracker)
+
+	estimate, err := host.EstimateGas(input)
+	require.Nil(t, err)
+	require.Equal(t, gasConsumed, estimate.GasProvided)
+
+	// the upper bound, the gas it consumed, one unit less and the gas consumed again, for the
+	// prepare cost of a successful execution
+	for _, contractCoverage := range coverageTracker.GetCoverage() {
+		require.Equal(t, map[string]uint64{increment: 4}, contractCoverage.FunctionCalls)
+	}
+}
+
+func TestEstimateGas_AsyncCallLockingAllGas(t *testing.T) {
+	host, world, imb := testcommon.DefaultTestVMForCallWithInstanceMocks(t)
+	defer host.Reset()
+
+	gasUsedByParent := uint64(400)
+	gasLock := uint64(150)
+
+	parentInstance := imb.CreateAndStoreInstanceMock(t, host, testcommon.ParentAddress, nil, nil, nil, 0, 1000)
+	parentInstance.AddMockMethod("performAsyncCall", func() *contextmock.InstanceMock {
+		err := host.Metering().UseGasBounded(gasUsedByParent)
+		if err == nil {
+			err = host.Runtime().ExecuteAsyncCall(testcommon.ChildAddress, []byte("doSomething"), nil)
+		}
+		if err != nil {
+			host.Runtime().SignalUserError(err.Error())
+		}
+		return parentInstance
+	})
+
+	world.SelfShardID = 0
+	world.CurrentBlockInfo = &worldmock.BlockInfo{}
+	setZeroCodeCosts(host)
+	setAsyncCosts(host, gasLock)
+	world.CreateStateBackup()
+
+	input := testcommon.CreateTestContractCallInputBuilder().
+		WithCallerAddr(testcommon.UserAddress).
+		WithRecipientAddr(testcommon.ParentAddress).
+		WithGasProvided(100000).
+		WithFunction("performAsyncCall").
+		Build()
+
+	// the cross-shard asynchronous call takes all the gas left, so the upper bound is consumed entirely
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, uint64(0), vmOutput.GasRemaining)
+
+	estimate, err := host.EstimateGas(input)
+	require.Nil(t, err)
+	require.Less(t, estimate.GasProvided, input.GasProvided)
+	require.Greater(t, estimate.GasProvided, gasUsedByParent)
+
+	input.GasProvided = estimate.GasProvided
+	vmOutput, err = host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	input.GasProvided = estimate.GasProvided - 1
+	vmOutput, err = host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.NotEqual(t, vmcommon.Ok, vmOutput.ReturnCode)
+}
@@ -0,0 +1,56 @@
+package hostCoretest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/testcommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGasReport_SuccessfulCall(t *testing.T) {
+	code := testcommon.GetTestSCCode("baseOps", "../../")
+	host, _ := testcommon.DefaultTestVMForCall(t, code, nil)
+	defer func() {
+		host.Reset()
+	}()
+
+	input := testcommon.DefaultTestContractCallInput()
+	input.GasProvided = 1000000
+	input.Function = "test_getCallValue_1byte"
+	input.CallValue = big.NewInt(64)
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	report := host.GetGasReport()
+	require.Nil(t, report.Check())
+	require.Equal(t, input.GasProvided, report.GasProvided)
+	require.Equal(t, vmOutput.GasRemaining, report.GasRemaining)
+	require.Len(t, report.GasUsedByAccounts, 1)
+	require.Equal(t, testcommon.ParentAddress, report.GasUsedByAccounts[0].Address)
+	require.Equal(t, input.GasProvided-vmOutput.GasRemaining, report.GasConsumed())
+}
+
+func TestGasReport_FailedCall(t *testing.T) {
+	code := testcommon.GetTestSCCode("baseOps", "../../")
+	host, _ := testcommon.DefaultTestVMForCall(t, code, nil)
+	defer func() {
+		host.Reset()
+	}()
+
+	input := testcommon.DefaultTestContractCallInput()
+	input.GasProvided = 1000000
+	input.Function = "missingFunction"
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.FunctionNotFound, vmOutput.ReturnCode)
+
+	report := host.GetGasReport()
+	require.Nil(t, report.Check())
+	require.Equal(t, uint64(0), report.GasRemaining)
+	require.Equal(t, input.GasProvided, report.GasConsumed())
+}
@@ -0,0 +1,73 @@
+package hostCoretest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-v1_4-go/interpreter"
+	test "github.com/multiversx/mx-chain-vm-v1_4-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/hostCore"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterpreter_CounterContract(t *testing.T) {
+	world := mock.NewMockWorldVM14()
+	engine := interpreter.NewEngine()
+
+	var steppedOpcodes []string
+	engine.SetStepHandler(func(step *interpreter.Step) error {
+		if step.FunctionName == increment {
+			steppedOpcodes = append(steppedOpcodes, step.Opcode)
+		}
+		return nil
+	})
+
+	host, err := hostCore.NewVMHost(world, makeWasmEngineHostParameters(engine))
+	require.Nil(t, err)
+	defer host.Reset()
+
+	scAddress := test.MakeTestSCAddress("counter")
+	code := test.GetTestSCCode("counter", "../../")
+	world.AcctMap.CreateSmartContractAccount(test.ParentAddress, scAddress, code, world)
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithRecipientAddr(scAddress).
+		WithGasProvided(100000).
+		WithFunction(increment).
+		Build()
+
+	for i := int64(1); i <= 3; i++ {
+		vmOutput, err := host.RunSmartContractCall(input)
+		verify := test.NewVMOutputVerifier(t, vmOutput, err)
+		verify.Ok().
+			ReturnData(big.NewInt(i).Bytes()).
+			Storage(test.CreateStoreEntry(scAddress).WithKey([]byte("COUNTER")).WithValue(big.NewInt(i).Bytes()))
+		require.Less(t, vmOutput.GasRemaining, input.GasProvided)
+
+		err = world.UpdateAccounts(vmOutput.OutputAccounts, nil)
+		require.Nil(t, err)
+	}
+
+	require.Contains(t, steppedOpcodes, "i64.add")
+	require.Equal(t, "end", steppedOpcodes[len(steppedOpcodes)-1])
+}
+
+func TestInterpreter_OutOfGas(t *testing.T) {
+	world := mock.NewMockWorldVM14()
+	host, err := hostCore.NewVMHost(world, makeWasmEngineHostParameters(interpreter.NewEngine()))
+	require.Nil(t, err)
+	defer host.Reset()
+
+	scAddress := test.MakeTestSCAddress("counter")
+	code := test.GetTestSCCode("counter", "../../")
+	world.AcctMap.CreateSmartContractAccount(test.ParentAddress, scAddress, code, world)
+
+	vmOutput, err := host.RunSmartContractCall(test.CreateTestContractCallInputBuilder().
+		WithRecipientAddr(scAddress).
+		WithGasProvided(10).
+		WithFunction(increment).
+		Build())
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.OutOfGas()
+}
@@ -0,0 +1,126 @@
+package hostCoretest
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/interpreter"
+	mock "github.com/multiversx/mx-chain-vm-v1_4-go/mock/context"
+	test "github.com/multiversx/mx-chain-vm-v1_4-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/hostCore"
+	"github.com/stretchr/testify/require"
+)
+
+const memoryGrowPerPageGas = uint64(50)
+const grownMemoryPages = uint32(3)
+
+func growMemoryMock(instanceMock *mock.InstanceMock, _ interface{}) {
+	instanceMock.AddMockMethod("growMemory", func() *mock.InstanceMock {
+		host := instanceMock.Host
+		instance := mock.GetMockInstance(host)
+		_ = instance.GetMemory().Grow(grownMemoryPages)
+		return instance
+	})
+}
+
+func TestGasUsed_MemoryGrowth(t *testing.T) {
+	gasProvided := uint64(1000)
+	expectedUsedGas := uint64(grownMemoryPages) * memoryGrowPerPageGas
+
+	var vmHost vmhost.VMHost
+	test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(0).
+				WithConfig(nil).
+				WithMethods(growMemoryMock)).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(gasProvided).
+			WithFunction("growMemory").
+			Build()).
+		WithSetup(func(host vmhost.VMHost, world *worldmock.MockWorld) {
+			vmHost = host
+			setZeroCodeCosts(host)
+			host.Metering().GasSchedule().BaseOperationCost.MemoryGrowPerPage = memoryGrowPerPageGas
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.
+				Ok().
+				GasRemaining(gasProvided-expectedUsedGas).
+				GasUsed(test.ParentAddress, expectedUsedGas)
+
+			memoryUsage := vmHost.GetMemoryUsage()
+			require.Len(t, memoryUsage.Calls, 1)
+			call := memoryUsage.Calls[0]
+			require.Equal(t, test.ParentAddress, call.SCAddress)
+			require.Equal(t, "growMemory", call.Function)
+			require.Equal(t, grownMemoryPages, call.GrownPages())
+			require.Equal(t, call.PeakPages, memoryUsage.PeakPages())
+		})
+}
+
+func TestGasUsed_MemoryGrowth_OutOfGas(t *testing.T) {
+	test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(0).
+				WithConfig(nil).
+				WithMethods(growMemoryMock)).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(uint64(grownMemoryPages) * memoryGrowPerPageGas).
+			WithFunction("growMemory").
+			Build()).
+		WithSetup(func(host vmhost.VMHost, world *worldmock.MockWorld) {
+			setZeroCodeCosts(host)
+			host.Metering().GasSchedule().BaseOperationCost.MemoryGrowPerPage = memoryGrowPerPageGas
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.OutOfGas()
+		})
+}
+
+func TestGasUsed_MemoryGrowth_WarmInstance(t *testing.T) {
+	// the leaky instances keep their grown memory on reset, like the Wasmer instances
+	engine := interpreter.NewEngine()
+	hostParameters := makeWasmEngineHostParameters(&mock.WasmEngineStub{
+		InstanceBuilder:      &leakyInstanceBuilder{engine: engine},
+		SetImportsCalled:     engine.SetImports,
+		SetOpcodeCostsCalled: engine.SetOpcodeCosts,
+	})
+	hostParameters.WarmInstanceCache = &vmhost.WarmInstanceCacheConfig{Enabled: true}
+	world := worldmock.NewMockWorld()
+	host, err := hostCore.NewVMHost(world, hostParameters)
+	require.Nil(t, err)
+	defer host.Reset()
+	host.Metering().GasSchedule().BaseOperationCost.MemoryGrowPerPage = memoryGrowPerPageGas
+
+	scAddress := test.MakeTestSCAddress("memgrow")
+	world.AcctMap.CreateSmartContractAccount(test.ParentAddress, scAddress, test.GetTestSCCodeModule("wasmbacking/mem-grow-to", "mem-grow-to", "../../"), world)
+	input := test.CreateTestContractCallInputBuilder().
+		WithRecipientAddr(scAddress).
+		WithGasProvided(100000).
+		WithFunction("main").
+		Build()
+
+	// the contract grows its memory to 6 pages, which the warm instance reused by the second call
+	// already has, yet both calls are charged for the growth from the page declared by the module
+	vmOutput, err := host.RunSmartContractCall(input)
+	test.NewVMOutputVerifier(t, vmOutput, err).
+		Ok().
+		ReturnData([]byte{6})
+	gasRemaining := vmOutput.GasRemaining
+
+	vmOutput, err = host.RunSmartContractCall(input)
+	test.NewVMOutputVerifier(t, vmOutput, err).
+		Ok().
+		ReturnData([]byte{6})
+	require.Equal(t, uint64(1), host.GetWarmInstanceCacheStats().Hits)
+	require.Equal(t, gasRemaining, vmOutput.GasRemaining)
+
+	memoryUsage := host.GetMemoryUsage()
+	require.Len(t, memoryUsage.Calls, 1)
+	require.Equal(t, uint32(5), memoryUsage.Calls[0].GrownPages())
+}
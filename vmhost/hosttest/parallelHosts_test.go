@@ -0,0 +1,138 @@
+package hostCoretest
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/config"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/interpreter"
+	test "github.com/multiversx/mx-chain-vm-v1_4-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/hostCore"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type counterHost struct {
+	host      vmhost.VMHost
+	world     *worldmock.MockWorld
+	scAddress []byte
+}
+
+// newWasmEngine creates the engine of a counterHost; a nil engine selects the default WasmerEngine
+type newWasmEngine func() vmhost.WasmEngine
+
+func newInterpreterEngine() vmhost.WasmEngine {
+	return interpreter.NewEngine()
+}
+
+func newDefaultEngine() vmhost.WasmEngine {
+	return nil
+}
+
+func newCounterHost(t *testing.T, newEngine newWasmEngine, opcodeCost uint64) *counterHost {
+	gasSchedule := config.MakeGasMapForTests()
+	for opcode := range gasSchedule["WASMOpcodeCost"] {
+		gasSchedule["WASMOpcodeCost"][opcode] = opcodeCost
+	}
+
+	world := mock.NewMockWorldVM14()
+	hostParameters := makeWasmEngineHostParameters(newEngine())
+	hostParameters.GasSchedule = gasSchedule
+	host, err := hostCore.NewVMHost(world, hostParameters)
+	require.Nil(t, err)
+
+	scAddress := test.MakeTestSCAddress("counter")
+	code := test.GetTestSCCode("counter", "../../")
+	world.AcctMap.CreateSmartContractAccount(test.ParentAddress, scAddress, code, world)
+
+	return &counterHost{host: host, world: world, scAddress: scAddress}
+}
+
+func (ch *counterHost) increment(t *testing.T) uint64 {
+	input := test.CreateTestContractCallInputBuilder().
+		WithRecipientAddr(ch.scAddress).
+		WithGasProvided(100000).
+		WithFunction(increment).
+		Build()
+
+	vmOutput, err := ch.host.RunSmartContractCall(input)
+	test.NewVMOutputVerifier(t, vmOutput, err).Ok()
+	require.Nil(t, ch.world.UpdateAccounts(vmOutput.OutputAccounts, nil))
+
+	return input.GasProvided - vmOutput.GasRemaining
+}
+
+func TestParallelHosts_DifferentGasSchedules(t *testing.T) {
+	testParallelHostsDifferentGasSchedules(t, newInterpreterEngine)
+}
+
+func TestParallelHosts_DifferentGasSchedules_WasmerEngine(t *testing.T) {
+	testParallelHostsDifferentGasSchedules(t, newDefaultEngine)
+}
+
+func testParallelHostsDifferentGasSchedules(t *testing.T, newEngine newWasmEngine) {
+	opcodeCosts := []uint64{1, 2, 5}
+	numCalls := 20
+
+	expectedGasUsed := make([]uint64, len(opcodeCosts))
+	for i, opcodeCost := range opcodeCosts {
+		ch := newCounterHost(t, newEngine, opcodeCost)
+		expectedGasUsed[i] = ch.increment(t)
+		ch.host.Reset()
+	}
+	require.Less(t, expectedGasUsed[0], expectedGasUsed[1])
+	require.Less(t, expectedGasUsed[1], expectedGasUsed[2])
+
+	hosts := make([]*counterHost, len(opcodeCosts))
+	for i, opcodeCost := range opcodeCosts {
+		hosts[i] = newCounterHost(t, newEngine, opcodeCost)
+	}
+
+	gasUsed := make([][]uint64, len(hosts))
+	var wg sync.WaitGroup
+	for i, ch := range hosts {
+		wg.Add(1)
+		go func(i int, ch *counterHost) {
+			defer wg.Done()
+			for j := 0; j < numCalls; j++ {
+				gasUsed[i] = append(gasUsed[i], ch.increment(t))
+			}
+		}(i, ch)
+	}
+	wg.Wait()
+
+	for i, ch := range hosts {
+		for _, used := range gasUsed[i] {
+			require.Equal(t, expectedGasUsed[i], used)
+		}
+		ch.host.Reset()
+	}
+}
+
+func TestParallelHosts_GasScheduleChangeDoesNotAffectOtherHosts(t *testing.T) {
+	testParallelHostsGasScheduleChange(t, newInterpreterEngine)
+}
+
+func TestParallelHosts_GasScheduleChangeDoesNotAffectOtherHosts_WasmerEngine(t *testing.T) {
+	testParallelHostsGasScheduleChange(t, newDefaultEngine)
+}
+
+func testParallelHostsGasScheduleChange(t *testing.T, newEngine newWasmEngine) {
+	changed := newCounterHost(t, newEngine, 1)
+	defer changed.host.Reset()
+	unchanged := newCounterHost(t, newEngine, 1)
+	defer unchanged.host.Reset()
+
+	gasUsedBefore := unchanged.increment(t)
+
+	gasSchedule := config.MakeGasMapForTests()
+	for opcode := range gasSchedule["WASMOpcodeCost"] {
+		gasSchedule["WASMOpcodeCost"][opcode] = 3
+	}
+	changed.host.GasScheduleChange(gasSchedule)
+
+	require.Greater(t, changed.increment(t), gasUsedBefore)
+	require.Equal(t, gasUsedBefore, unchanged.increment(t))
+}
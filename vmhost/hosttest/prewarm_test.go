@@ -0,0 +1,66 @@
+package hostCoretest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	mock "github.com/multiversx/mx-chain-vm-v1_4-go/mock/context"
+	test "github.com/multiversx/mx-chain-vm-v1_4-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/stretchr/testify/require"
+)
+
+func noOpMock(instanceMock *mock.InstanceMock, _ interface{}) {
+	instanceMock.AddMockMethod("noOp", func() *mock.InstanceMock {
+		return mock.GetMockInstance(instanceMock.Host)
+	})
+}
+
+func TestPrewarmInstances(t *testing.T) {
+	var vmHost vmhost.VMHost
+	test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(0).
+				WithConfig(nil).
+				WithMethods(noOpMock)).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(simpleGasTestConfig.GasProvided).
+			WithFunction("noOp").
+			Build()).
+		WithSetup(func(host vmhost.VMHost, world *worldmock.MockWorld) {
+			vmHost = host
+			codeHash := host.Blockchain().GetCodeHash(test.ParentAddress)
+
+			reportChan, err := host.PrewarmInstances([]vmhost.PrewarmTarget{
+				{Address: test.ParentAddress},
+				{CodeHash: codeHash},
+				{Address: []byte("unknownAddress..................")},
+				{CodeHash: []byte("unknownCodeHash")},
+				{},
+			})
+			require.Nil(t, err)
+
+			report := <-reportChan
+			require.Equal(t, 5, report.NumTargets)
+			require.Equal(t, 1, report.NumWarmed)
+			require.Equal(t, 1, report.NumAlreadyWarm)
+			require.Len(t, report.Failures, 3)
+			require.True(t, errors.Is(report.Failures[0].Err, vmhost.ErrContractNotFound))
+			require.True(t, errors.Is(report.Failures[1].Err, vmhost.ErrContractNotFound))
+			require.Equal(t, vmhost.ErrInvalidPrewarmTarget, report.Failures[2].Err)
+
+			found, _ := world.GetCompiledCode(vmhost.CompiledCodeKey(codeHash, host.CompilationFingerprint()))
+			require.True(t, found)
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok()
+
+			stats := vmHost.GetWarmInstanceCacheStats()
+			require.Equal(t, uint64(1), stats.Hits)
+			require.Equal(t, uint64(1), stats.InstancesFromBytecode)
+			require.Equal(t, 1, stats.NumWarmInstances)
+		})
+}
@@ -0,0 +1,76 @@
+package hostCoretest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	mock "github.com/multiversx/mx-chain-vm-v1_4-go/mock/context"
+	test "github.com/multiversx/mx-chain-vm-v1_4-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/stretchr/testify/require"
+)
+
+const releasePerByteGas = uint64(3)
+
+var originalStorageValue = []byte("original value")
+
+func changeStorageMock(instanceMock *mock.InstanceMock, _ interface{}) {
+	instanceMock.AddMockMethod("changeStorage", func() *mock.InstanceMock {
+		host := instanceMock.Host
+		instance := mock.GetMockInstance(host)
+		storage := host.Storage()
+
+		_, _ = storage.SetStorage([]byte("deleted"), nil)
+
+		_, _ = storage.SetStorage([]byte("shrunk"), []byte("original"))
+		_, _ = storage.SetStorage([]byte("shrunk"), []byte("orig"))
+
+		_, _ = storage.SetStorage([]byte("restored"), []byte("o"))
+		_, _ = storage.SetStorage([]byte("restored"), originalStorageValue)
+
+		return instance
+	})
+}
+
+func TestStorageRefund_FreedBytes(t *testing.T) {
+	bytesFreed := uint64(len(originalStorageValue) + len(originalStorageValue) - len("orig"))
+	// the refund of the VMOutput is the one of the metering, which adds up the bytes freed by
+	// each write, while the storage usage only counts the final values
+	bytesFreedByWrites := bytesFreed + uint64(len(originalStorageValue)-len("o"))
+
+	var vmHost vmhost.VMHost
+	test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(0).
+				WithConfig(nil).
+				WithMethods(changeStorageMock)).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(simpleGasTestConfig.GasProvided).
+			WithFunction("changeStorage").
+			Build()).
+		WithSetup(func(host vmhost.VMHost, world *worldmock.MockWorld) {
+			vmHost = host
+			setZeroCodeCosts(host)
+			host.Metering().GasSchedule().BaseOperationCost.ReleasePerByte = releasePerByteGas
+
+			accountHandler, _ := world.GetUserAccount(test.ParentAddress)
+			account := accountHandler.(*worldmock.Account)
+			account.Storage["deleted"] = originalStorageValue
+			account.Storage["shrunk"] = originalStorageValue
+			account.Storage["restored"] = originalStorageValue
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok()
+			require.Equal(t, big.NewInt(0).SetUint64(bytesFreedByWrites*releasePerByteGas), verify.VmOutput.GasRefund)
+
+			storageUsage := vmHost.GetStorageUsage()
+			accountUsage := storageUsage.GetAccountUsage(test.ParentAddress)
+			require.NotNil(t, accountUsage)
+			require.Equal(t, uint64(0), accountUsage.BytesAdded)
+			require.Equal(t, bytesFreed, accountUsage.BytesFreed)
+			require.Equal(t, bytesFreed*releasePerByteGas, storageUsage.GasRefund)
+		})
+}
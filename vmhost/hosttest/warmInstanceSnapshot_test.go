@@ -0,0 +1,182 @@
+package hostCoretest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/interpreter"
+	contextmock "github.com/multiversx/mx-chain-vm-v1_4-go/mock/context"
+	test "github.com/multiversx/mx-chain-vm-v1_4-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/hostCore"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/mock"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/wasmer"
+	"github.com/stretchr/testify/require"
+)
+
+// leakyInstance is an interpreter instance whose Reset leaves its state as it is, like an engine
+// leaking the state of an execution into the next one
+type leakyInstance struct {
+	*interpreter.Instance
+}
+
+// Reset -
+func (instance *leakyInstance) Reset() bool {
+	return !instance.AlreadyCleaned()
+}
+
+// leakyInstanceBuilder creates leaky instances with the interpreter, keeping the last one created
+type leakyInstanceBuilder struct {
+	engine       *interpreter.Engine
+	lastInstance *leakyInstance
+}
+
+// NewInstanceWithOptions -
+func (builder *leakyInstanceBuilder) NewInstanceWithOptions(contractCode []byte, options wasmer.CompilationOptions) (wasmer.InstanceHandler, error) {
+	instance, err := builder.engine.NewInstanceWithOptions(contractCode, options)
+	if err != nil {
+		return nil, err
+	}
+
+	builder.lastInstance = &leakyInstance{Instance: instance.(*interpreter.Instance)}
+	return builder.lastInstance, nil
+}
+
+// NewInstanceFromCompiledCodeWithOptions -
+func (builder *leakyInstanceBuilder) NewInstanceFromCompiledCodeWithOptions(compiledCode []byte, options wasmer.CompilationOptions) (wasmer.InstanceHandler, error) {
+	return builder.NewInstanceWithOptions(compiledCode, options)
+}
+
+func createLeakyCounterHost(t *testing.T, cacheConfig *vmhost.WarmInstanceCacheConfig) (vmhost.VMHost, *worldmock.MockWorld, *leakyInstanceBuilder, []byte) {
+	engine := interpreter.NewEngine()
+	builder := &leakyInstanceBuilder{engine: engine}
+	hostParameters := makeWasmEngineHostParameters(&contextmock.WasmEngineStub{
+		InstanceBuilder:      builder,
+		SetImportsCalled:     engine.SetImports,
+		SetOpcodeCostsCalled: engine.SetOpcodeCosts,
+	})
+	hostParameters.WarmInstanceCache = cacheConfig
+
+	world := mock.NewMockWorldVM14()
+	host, err := hostCore.NewVMHost(world, hostParameters)
+	require.Nil(t, err)
+
+	scAddress := test.MakeTestSCAddress("counter")
+	world.AcctMap.CreateSmartContractAccount(test.ParentAddress, scAddress, test.GetTestSCCode("counter", "../../"), world)
+
+	return host, world, builder, scAddress
+}
+
+func fillMemory(instance wasmer.InstanceHandler, value byte) {
+	data := instance.GetMemory().Data()
+	for i := range data {
+		data[i] = value
+	}
+}
+
+func TestWarmInstanceSnapshot_LeakedMemoryIsRestored(t *testing.T) {
+	for _, verifyWarmInstances := range []bool{false, true} {
+		// the outputs are not committed, so the counter is incremented from zero on each call
+		host, _, builder, scAddress := createLeakyCounterHost(t, &vmhost.WarmInstanceCacheConfig{
+			Enabled:              true,
+			RestoreWarmInstances: true,
+			VerifyWarmInstances:  verifyWarmInstances,
+		})
+
+		vmOutput, err := host.RunSmartContractCall(createCounterCallInput(scAddress))
+		test.NewVMOutputVerifier(t, vmOutput, err).
+			Ok().
+			ReturnData(big.NewInt(1).Bytes())
+
+		warmInstance := builder.lastInstance
+		fillMemory(warmInstance, 0xFF)
+
+		vmOutput, err = host.RunSmartContractCall(createCounterCallInput(scAddress))
+		test.NewVMOutputVerifier(t, vmOutput, err).
+			Ok().
+			ReturnData(big.NewInt(1).Bytes())
+
+		stats := host.GetWarmInstanceCacheStats()
+		require.Equal(t, uint64(1), stats.Hits)
+		require.Equal(t, uint64(0), stats.StateMismatches)
+		require.False(t, warmInstance.AlreadyCleaned())
+		require.NotContains(t, warmInstance.GetMemory().Data(), byte(0xFF))
+
+		require.Nil(t, host.Close())
+	}
+}
+
+func TestWarmInstanceSnapshot_GrownMemoryIsNotRestored(t *testing.T) {
+	host, _, builder, scAddress := createLeakyCounterHost(t, &vmhost.WarmInstanceCacheConfig{
+		Enabled:              true,
+		RestoreWarmInstances: true,
+	})
+	defer func() {
+		_ = host.Close()
+	}()
+
+	vmOutput, err := host.RunSmartContractCall(createCounterCallInput(scAddress))
+	test.NewVMOutputVerifier(t, vmOutput, err).
+		Ok().
+		ReturnData(big.NewInt(1).Bytes())
+
+	// a grown memory cannot shrink back to the snapshot, so the warm instance is replaced
+	warmInstance := builder.lastInstance
+	require.Nil(t, warmInstance.GetMemory().Grow(1))
+
+	vmOutput, err = host.RunSmartContractCall(createCounterCallInput(scAddress))
+	test.NewVMOutputVerifier(t, vmOutput, err).
+		Ok().
+		ReturnData(big.NewInt(1).Bytes())
+
+	stats := host.GetWarmInstanceCacheStats()
+	require.Equal(t, uint64(0), stats.Hits)
+	// the first call found no warm instance either
+	require.Equal(t, uint64(2), stats.Misses)
+	require.Equal(t, 1, stats.NumWarmInstances)
+	require.True(t, warmInstance.AlreadyCleaned())
+	require.NotEqual(t, warmInstance, builder.lastInstance)
+}
+
+func TestWarmInstanceSnapshot_VerificationRejectsLeakingWarmInstances(t *testing.T) {
+	host, _, builder, scAddress := createLeakyCounterHost(t, &vmhost.WarmInstanceCacheConfig{
+		Enabled:             true,
+		VerifyWarmInstances: true,
+	})
+	defer func() {
+		_ = host.Close()
+	}()
+
+	vmOutput, err := host.RunSmartContractCall(createCounterCallInput(scAddress))
+	test.NewVMOutputVerifier(t, vmOutput, err).
+		Ok().
+		ReturnData(big.NewInt(1).Bytes())
+
+	// without the snapshots, the leaked memory survives the reset of the warm instance
+	warmInstance := builder.lastInstance
+	fillMemory(warmInstance, 0xFF)
+
+	vmOutput, err = host.RunSmartContractCall(createCounterCallInput(scAddress))
+	test.NewVMOutputVerifier(t, vmOutput, err).
+		Ok().
+		ReturnData(big.NewInt(1).Bytes())
+
+	stats := host.GetWarmInstanceCacheStats()
+	require.Equal(t, uint64(0), stats.Hits)
+	// the first call found no warm instance either
+	require.Equal(t, uint64(2), stats.Misses)
+	require.Equal(t, uint64(1), stats.StateMismatches)
+	require.Equal(t, 1, stats.NumWarmInstances)
+	require.True(t, warmInstance.AlreadyCleaned())
+
+	// the cold instance replacing the rejected one is reused
+	vmOutput, err = host.RunSmartContractCall(createCounterCallInput(scAddress))
+	test.NewVMOutputVerifier(t, vmOutput, err).
+		Ok().
+		ReturnData(big.NewInt(1).Bytes())
+
+	stats = host.GetWarmInstanceCacheStats()
+	require.Equal(t, uint64(1), stats.Hits)
+	require.Equal(t, uint64(1), stats.StateMismatches)
+}
@@ -0,0 +1,141 @@
+package hostCoretest
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+	"github.com/multiversx/mx-chain-vm-common-go/parsers"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/config"
+	contextmock "github.com/multiversx/mx-chain-vm-v1_4-go/mock/context"
+	test "github.com/multiversx/mx-chain-vm-v1_4-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/hostCore"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/mock"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/vmhooksmeta"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/wasmer"
+	"github.com/stretchr/testify/require"
+)
+
+func makeWasmEngineHostParameters(engine vmhost.WasmEngine) *vmhost.VMHostParameters {
+	esdtTransferParser, _ := parsers.NewESDTTransferParser(worldmock.WorldMarshalizer)
+	return &vmhost.VMHostParameters{
+		VMType:               test.DefaultVMType,
+		BlockGasLimit:        uint64(1000),
+		GasSchedule:          config.MakeGasMapForTests(),
+		BuiltInFuncContainer: builtInFunctions.NewBuiltInFunctionContainer(),
+		ProtectedKeyPrefix:   []byte(core.ProtectedKeyPrefix),
+		ESDTTransferParser:   esdtTransferParser,
+		EpochNotifier:        &mock.EpochNotifierStub{},
+		EnableEpochsHandler:  &mock.EnableEpochsHandlerStub{},
+		Hasher:               worldmock.DefaultHasher,
+		WasmEngine:           engine,
+	}
+}
+
+func TestWasmEngine_SelectedByTheHostParameters(t *testing.T) {
+	world := mock.NewMockWorldVM14()
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+
+	var imports *vmhooksmeta.EIFunctions
+	var opcodeCosts *[wasmer.OpcodeCount]uint32
+	engine := &contextmock.WasmEngineStub{
+		InstanceBuilder: instanceBuilderMock,
+		SetImportsCalled: func(eiFunctions *vmhooksmeta.EIFunctions) error {
+			imports = eiFunctions
+			return nil
+		},
+		SetOpcodeCostsCalled: func(costs *[wasmer.OpcodeCount]uint32) {
+			opcodeCosts = costs
+		},
+	}
+
+	host, err := hostCore.NewVMHost(world, makeWasmEngineHostParameters(engine))
+	require.Nil(t, err)
+	defer host.Reset()
+
+	require.Equal(t, host.GetAPIMethods().Count(), len(imports.FunctionMap))
+	require.Equal(t, host.Metering().GasSchedule().WASMOpcodeCost.I32Add, opcodeCosts[wasmer.OpcodeI32Add])
+
+	gasSchedule := config.MakeGasMapForTests()
+	gasSchedule["WASMOpcodeCost"]["I32Add"] = 10
+	host.GasScheduleChange(gasSchedule)
+	require.Equal(t, uint32(10), opcodeCosts[wasmer.OpcodeI32Add])
+
+	// the instances are created by the engine
+	instance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, nil, nil, nil, 0, 0)
+	noOpMock(instance, nil)
+	setZeroCodeCosts(host)
+	vmOutput, err := host.RunSmartContractCall(test.CreateTestContractCallInputBuilder().
+		WithRecipientAddr(test.ParentAddress).
+		WithGasProvided(simpleGasTestConfig.GasProvided).
+		WithFunction("noOp").
+		Build())
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+}
+
+func TestWasmEngine_EIFunctionsCalledWithoutCgo(t *testing.T) {
+	world := mock.NewMockWorldVM14()
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+
+	var imports *vmhooksmeta.EIFunctions
+	engine := &contextmock.WasmEngineStub{
+		InstanceBuilder: instanceBuilderMock,
+		SetImportsCalled: func(eiFunctions *vmhooksmeta.EIFunctions) error {
+			imports = eiFunctions
+			return nil
+		},
+	}
+
+	host, err := hostCore.NewVMHost(world, makeWasmEngineHostParameters(engine))
+	require.Nil(t, err)
+	defer host.Reset()
+
+	bindings := vmhooksmeta.NewEIFunctionBindings(imports)
+	var value *big.Int
+	instance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, nil, nil, nil, 0, 0)
+	instance.AddMockMethod("callEI", func() *contextmock.InstanceMock {
+		mockHost := instance.Host
+
+		// the host is set as by the runtime, through HostReferenceHandler.SetHostReference
+		instanceContext := vmhost.NewGoInstanceContext()
+		defer instanceContext.Release()
+		instanceContext.SetHost(&mockHost)
+
+		results, err := bindings["env"]["bigIntNew"].Call(instanceContext.Pointer(), 42)
+		require.Nil(t, err)
+		require.Len(t, results, 1)
+
+		value, err = mockHost.ManagedTypes().GetBigInt(int32(results[0]))
+		require.Nil(t, err)
+
+		return contextmock.GetMockInstance(mockHost)
+	})
+
+	setZeroCodeCosts(host)
+	vmOutput, err := host.RunSmartContractCall(test.CreateTestContractCallInputBuilder().
+		WithRecipientAddr(test.ParentAddress).
+		WithGasProvided(simpleGasTestConfig.GasProvided).
+		WithFunction("callEI").
+		Build())
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+	require.Equal(t, big.NewInt(42), value)
+}
+
+func TestWasmEngine_SetImportsError(t *testing.T) {
+	expectedErr := errors.New("expected error")
+	engine := &contextmock.WasmEngineStub{
+		SetImportsCalled: func(_ *vmhooksmeta.EIFunctions) error {
+			return expectedErr
+		},
+	}
+
+	host, err := hostCore.NewVMHost(mock.NewMockWorldVM14(), makeWasmEngineHostParameters(engine))
+	require.Nil(t, host)
+	require.Equal(t, expectedErr, err)
+}
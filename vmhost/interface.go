@@ -1,9 +1,11 @@
 package vmhost
 
 import (
+	"context"
 	"crypto/elliptic"
 	"io"
 	"math/big"
+	"time"
 
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/data/esdt"
@@ -11,6 +13,7 @@ import (
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 	"github.com/multiversx/mx-chain-vm-v1_4-go/config"
 	"github.com/multiversx/mx-chain-vm-v1_4-go/crypto"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/vmhooksmeta"
 	"github.com/multiversx/mx-chain-vm-v1_4-go/wasmer"
 )
 
@@ -64,6 +67,22 @@ type VMHost interface {
 	Reset()
 	SetGasTracing(enableGasTracing bool)
 	GetGasTrace() map[string]map[string][]uint64
+	SetCallTreeGasTracing(enableCallTreeGasTracing bool)
+	GetGasCallTree() []*GasTraceFrame
+	SetCoverageTracker(tracker CoverageTracker)
+	SetCallDebugger(debugger CallDebugger)
+	EstimateGas(input *vmcommon.ContractCallInput) (*GasEstimate, error)
+	GetGasReport() *GasReport
+	SetGasInvariantChecking(enableGasInvariantChecking bool)
+	GetGasInvariantViolation() *GasInvariantViolation
+	GetMemoryUsage() *MemoryUsage
+	GetStorageUsage() *StorageUsage
+	GetWarmInstanceCacheStats() WarmInstanceCacheStats
+	CompilationFingerprint() []byte
+	PrewarmInstances(targets []PrewarmTarget) (<-chan *PrewarmReport, error)
+	RunSmartContractQuery(input *vmcommon.ContractCallInput, timeout time.Duration) (*vmcommon.VMOutput, error)
+	RunSmartContractCallWithContext(ctx context.Context, input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error)
+	RunSmartContractCreateWithContext(ctx context.Context, input *vmcommon.ContractCreateInput) (*vmcommon.VMOutput, error)
 }
 
 // BlockchainContext defines the functionality needed for interacting with the blockchain context
@@ -141,6 +160,9 @@ type RuntimeContext interface {
 	SetReadOnly(readOnly bool)
 	StartWasmerInstance(contract []byte, gasLimit uint64, newCode bool) error
 	ClearWarmInstanceCache()
+	IsWarmInstanceCacheEnabled() bool
+	GetWarmInstanceCacheStats() WarmInstanceCacheStats
+	PrewarmInstance(target PrewarmTarget) (bool, error)
 	SetMaxInstanceCount(uint64)
 	VerifyContractCode() error
 	GetInstance() wasmer.InstanceHandler
@@ -167,6 +189,11 @@ type RuntimeContext interface {
 	ReplaceInstanceBuilder(builder InstanceBuilder)
 	EndExecution()
 	ValidateInstances() error
+	SetCoverageTracker(tracker CoverageTracker)
+	TrackEICall(eiFunctionName string)
+	SetOpcodeProfiling(contractAddresses [][]byte) error
+	GetOpcodeProfiles() map[string]*OpcodeProfile
+	GetMemoryUsage() *MemoryUsage
 }
 
 // ManagedTypesContext defines the functionality needed for interacting with the big int context
@@ -276,9 +303,17 @@ type MeteringContext interface {
 	UpdateGasStateOnSuccess(vmOutput *vmcommon.VMOutput) error
 	UpdateGasStateOnFailure(vmOutput *vmcommon.VMOutput)
 	TrackGasUsedByBuiltinFunction(builtinInput *vmcommon.ContractCallInput, builtinOutput *vmcommon.VMOutput, postBuiltinInput *vmcommon.ContractCallInput)
+	GetGasRefundedByBuiltins() uint64
+	SetGasInvariantChecking(enableGasInvariantChecking bool)
+	GetGasInvariantViolation() *GasInvariantViolation
+	SetGasTraceFrameMemoryPages(pages uint32)
 	StartGasTracing(functionName string)
 	SetGasTracing(enableGasTracing bool)
 	GetGasTrace() map[string]map[string][]uint64
+	BeginGasTraceFrame(kind GasTraceFrameKind, name string)
+	EndGasTraceFrame()
+	SetCallTreeGasTracing(enableCallTreeGasTracing bool)
+	GetGasCallTree() []*GasTraceFrame
 }
 
 // StorageStatus defines the states the storage can be in
@@ -311,6 +346,7 @@ type StorageContext interface {
 	SetProtectedStorage(key []byte, value []byte) (StorageStatus, error)
 	UseGasForStorageLoad(tracedFunctionName string, blockChainLoadCost uint64, usedCache bool)
 	IsUseDifferentGasCostFlagSet() bool
+	GetStorageAccounting() *StorageAccounting
 }
 
 // AsyncCallInfoHandler defines the functionality for working with AsyncCallInfo
@@ -328,12 +364,81 @@ type InstanceBuilder interface {
 	NewInstanceFromCompiledCodeWithOptions(compiledCode []byte, options wasmer.CompilationOptions) (wasmer.InstanceHandler, error)
 }
 
+// WasmEngine defines an engine executing the contracts, selected through VMHostParameters.WasmEngine.
+// The engine receives the EI functions once, when the host is created; the engines which do not go
+// through cgo call them with vmhooksmeta.NewEIFunctionBindings, passing a GoInstanceContext pointer
+// holding the context data set on the instance.
+type WasmEngine interface {
+	InstanceBuilder
+	SetImports(imports *vmhooksmeta.EIFunctions) error
+	SetOpcodeCosts(opcodeCosts *[wasmer.OpcodeCount]uint32)
+	IsInterfaceNil() bool
+}
+
+// HostReferenceHandler is implemented by the instances of the engines not going through cgo, which
+// receive the host reference as a pointer instead of the context data set through SetContextData
+type HostReferenceHandler interface {
+	SetHostReference(host *VMHost)
+}
+
 // GasTracing defines the functionality needed for a gas tracing
 type GasTracing interface {
 	BeginTrace(scAddress string, functionName string)
 	AddToCurrentTrace(usedGas uint64)
 	AddTracedGas(scAddress string, functionName string, usedGas uint64)
 	GetGasTrace() map[string]map[string][]uint64
+	BeginFrame(kind GasTraceFrameKind, scAddress string, name string)
+	EndFrame()
+	GetCallTree() []*GasTraceFrame
+	SetFrameMemoryPages(pages uint32)
+	IsInterfaceNil() bool
+}
+
+// GasInvariantChecking defines the functionality needed for checking the conservation of gas
+// at the boundaries of the frames of the call stack
+type GasInvariantChecking interface {
+	BeginFrame(address []byte, function string, callType vm.CallType, gasProvided uint64)
+	SuspendFrame(gasLeft uint64)
+	EndFrame(boundary string, gasSpent uint64, merged bool)
+	SetLastHook(hookName string)
+	CheckOutputGas(gasAccounted uint64)
+	GetViolation() *GasInvariantViolation
+	IsInterfaceNil() bool
+}
+
+// CoverageTracker defines the functionality needed for tracking the contract functions
+// and the EI functions reached during execution
+type CoverageTracker interface {
+	IsContractTracked(codeHash []byte) bool
+	TrackContract(codeHash []byte, exportedFunctions []string, importedEIFunctions []string)
+	TrackFunctionCall(codeHash []byte, functionName string)
+	TrackEICall(codeHash []byte, eiFunctionName string)
+	GetCoverage() map[string]*ContractCoverage
+	IsInterfaceNil() bool
+}
+
+// CallDebugger defines the functionality needed for observing the boundaries of the nested calls
+// made during execution; the host blocks while the methods are running
+type CallDebugger interface {
+	EnterCall(host VMHost, boundary CallBoundary, input *vmcommon.ContractCallInput)
+	ExitCall(host VMHost, boundary CallBoundary, err error)
+	IsInterfaceNil() bool
+}
+
+// CompiledCodeStore persists the compiled contracts independently of the blockchain hook. The entries are
+// keyed by the code hash together with the compilation fingerprint, because the metering is compiled in.
+type CompiledCodeStore interface {
+	GetCompiledCode(codeHash []byte, fingerprint []byte) ([]byte, bool)
+	SaveCompiledCode(codeHash []byte, fingerprint []byte, compiledCode []byte) error
+	IsInterfaceNil() bool
+}
+
+// QueryExecutor executes the queries, read-only calls to the contracts, in parallel; a zero timeout
+// selects the default timeout of the executor
+type QueryExecutor interface {
+	RunQuery(input *vmcommon.ContractCallInput, timeout time.Duration) (*vmcommon.VMOutput, error)
+	GasScheduleChange(newGasSchedule config.GasScheduleMap)
+	Close() error
 	IsInterfaceNil() bool
 }
 
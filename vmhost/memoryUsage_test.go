@@ -0,0 +1,22 @@
+package vmhost
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryUsage_AddCall(t *testing.T) {
+	usage := NewMemoryUsage()
+	require.Equal(t, uint32(0), usage.PeakPages())
+
+	usage.AddCall(&CallMemoryUsage{CodeHash: []byte("first"), Function: "a", InitialPages: 2, PeakPages: 5})
+	usage.AddCall(&CallMemoryUsage{CodeHash: []byte("second"), Function: "b", InitialPages: 2, PeakPages: 3})
+	usage.AddCall(&CallMemoryUsage{CodeHash: []byte("first"), Function: "c", InitialPages: 5, PeakPages: 5})
+
+	require.Len(t, usage.Calls, 3)
+	require.Equal(t, uint32(3), usage.Calls[0].GrownPages())
+	require.Equal(t, uint32(0), usage.Calls[2].GrownPages())
+	require.Equal(t, map[string]uint32{"first": 5, "second": 3}, usage.PeakPagesByCodeHash)
+	require.Equal(t, uint32(5), usage.PeakPages())
+}
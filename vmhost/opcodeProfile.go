@@ -0,0 +1,121 @@
+package vmhost
+
+import (
+	"strings"
+
+	"github.com/multiversx/mx-chain-vm-v1_4-go/wasmer"
+)
+
+// OpcodeCategory groups the opcodes by the kind of work they perform
+type OpcodeCategory uint8
+
+const (
+	// ArithmeticOpcodes are the numeric, comparison, conversion and SIMD opcodes
+	ArithmeticOpcodes OpcodeCategory = iota
+
+	// MemoryOpcodes are the opcodes accessing or resizing the linear memory
+	MemoryOpcodes
+
+	// CallOpcodes are the direct and indirect function calls
+	CallOpcodes
+
+	// ControlOpcodes are the structured control flow opcodes, other than calls
+	ControlOpcodes
+
+	// VariableOpcodes are the opcodes accessing locals and globals
+	VariableOpcodes
+)
+
+// String returns a human-readable name for the opcode category
+func (category OpcodeCategory) String() string {
+	switch category {
+	case ArithmeticOpcodes:
+		return "arithmetic"
+	case MemoryOpcodes:
+		return "memory"
+	case CallOpcodes:
+		return "call"
+	case ControlOpcodes:
+		return "control"
+	case VariableOpcodes:
+		return "variable"
+	default:
+		return "unknown"
+	}
+}
+
+var controlOpcodes = map[int]struct{}{
+	wasmer.OpcodeUnreachable: {},
+	wasmer.OpcodeNop:         {},
+	wasmer.OpcodeBlock:       {},
+	wasmer.OpcodeLoop:        {},
+	wasmer.OpcodeIf:          {},
+	wasmer.OpcodeElse:        {},
+	wasmer.OpcodeEnd:         {},
+	wasmer.OpcodeBr:          {},
+	wasmer.OpcodeBrIf:        {},
+	wasmer.OpcodeBrTable:     {},
+	wasmer.OpcodeReturn:      {},
+	wasmer.OpcodeDrop:        {},
+	wasmer.OpcodeSelect:      {},
+	wasmer.OpcodeTypedSelect: {},
+}
+
+// OpcodeCategoryOf returns the category of the opcode with the given index
+func OpcodeCategoryOf(opcode int) OpcodeCategory {
+	if opcode == wasmer.OpcodeCall || opcode == wasmer.OpcodeCallIndirect {
+		return CallOpcodes
+	}
+	if _, isControl := controlOpcodes[opcode]; isControl {
+		return ControlOpcodes
+	}
+
+	name := wasmer.OpcodeNames[opcode]
+	switch {
+	case strings.HasPrefix(name, "Local"), strings.HasPrefix(name, "Global"):
+		return VariableOpcodes
+	case strings.HasPrefix(name, "Memory"), strings.HasPrefix(name, "DataDrop"),
+		strings.Contains(name, "Load"), strings.Contains(name, "Store"):
+		return MemoryOpcodes
+	default:
+		return ArithmeticOpcodes
+	}
+}
+
+// OpcodeProfile holds the number of executions and the gas of each opcode, for one contract,
+// indexed by the opcode constants of the wasmer package
+type OpcodeProfile struct {
+	SCAddress []byte
+	Counts    [wasmer.OpcodeCount]uint64
+	Gas       [wasmer.OpcodeCount]uint64
+}
+
+// AddCounts adds the given opcode counts to the profile, costing them with the provided opcode costs
+func (profile *OpcodeProfile) AddCounts(counts [wasmer.OpcodeCount]uint64, opcodeCosts [wasmer.OpcodeCount]uint32) {
+	for opcode, count := range counts {
+		profile.Counts[opcode] += count
+		profile.Gas[opcode] += count * uint64(opcodeCosts[opcode])
+	}
+}
+
+// TotalGas returns the gas of all the opcodes in the profile
+func (profile *OpcodeProfile) TotalGas() uint64 {
+	total := uint64(0)
+	for _, gas := range profile.Gas {
+		total += gas
+	}
+
+	return total
+}
+
+// GasByCategory returns the gas of the opcodes in the profile, summed per opcode category
+func (profile *OpcodeProfile) GasByCategory() map[OpcodeCategory]uint64 {
+	gasByCategory := make(map[OpcodeCategory]uint64)
+	for opcode, gas := range profile.Gas {
+		if gas > 0 {
+			gasByCategory[OpcodeCategoryOf(opcode)] += gas
+		}
+	}
+
+	return gasByCategory
+}
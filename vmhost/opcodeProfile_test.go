@@ -0,0 +1,43 @@
+package vmhost
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-v1_4-go/wasmer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpcodeCategoryOf(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, CallOpcodes, OpcodeCategoryOf(wasmer.OpcodeCallIndirect))
+	require.Equal(t, ControlOpcodes, OpcodeCategoryOf(wasmer.OpcodeBrIf))
+	require.Equal(t, VariableOpcodes, OpcodeCategoryOf(wasmer.OpcodeGlobalSet))
+	require.Equal(t, MemoryOpcodes, OpcodeCategoryOf(wasmer.OpcodeI64Load32U))
+	require.Equal(t, MemoryOpcodes, OpcodeCategoryOf(wasmer.OpcodeMemoryGrow))
+	require.Equal(t, ArithmeticOpcodes, OpcodeCategoryOf(wasmer.OpcodeI32Mul))
+}
+
+func TestOpcodeProfile_GasByCategory(t *testing.T) {
+	t.Parallel()
+
+	counts := [wasmer.OpcodeCount]uint64{}
+	counts[wasmer.OpcodeI32Load] = 2
+	counts[wasmer.OpcodeI32Store] = 1
+	counts[wasmer.OpcodeI32Add] = 4
+
+	opcodeCosts := [wasmer.OpcodeCount]uint32{}
+	opcodeCosts[wasmer.OpcodeI32Load] = 5
+	opcodeCosts[wasmer.OpcodeI32Store] = 7
+	opcodeCosts[wasmer.OpcodeI32Add] = 1
+
+	profile := &OpcodeProfile{}
+	profile.AddCounts(counts, opcodeCosts)
+	profile.AddCounts(counts, opcodeCosts)
+
+	require.Equal(t, uint64(42), profile.TotalGas())
+	require.Equal(t, map[OpcodeCategory]uint64{
+		MemoryOpcodes:     34,
+		ArithmeticOpcodes: 8,
+	}, profile.GasByCategory())
+}
@@ -0,0 +1,27 @@
+package vmhost
+
+import "time"
+
+// PrewarmTarget identifies a contract whose instance is loaded in the warm instance cache ahead of
+// its first call. The Address allows compiling the bytecode when no compiled code is stored; when
+// it is missing, the CodeHash is used and only the compiled code can be loaded.
+type PrewarmTarget struct {
+	Address  []byte
+	CodeHash []byte
+}
+
+// PrewarmFailure holds a target which could not be loaded in the warm instance cache
+type PrewarmFailure struct {
+	Target PrewarmTarget
+	Err    error
+}
+
+// PrewarmReport describes the outcome of prewarming the warm instance cache; Duration is the
+// time spent from the first target until the last one, including the waits for the executions
+type PrewarmReport struct {
+	NumTargets     int
+	NumWarmed      int
+	NumAlreadyWarm int
+	Duration       time.Duration
+	Failures       []PrewarmFailure
+}
@@ -0,0 +1,166 @@
+package querypool
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/config"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/compiledstore"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/hostCore"
+)
+
+var log = logger.GetOrCreate("vm/querypool")
+
+var _ vmhost.QueryExecutor = (*queryPool)(nil)
+
+// ArgsQueryPool holds the arguments needed to create a queryPool. The BlockChainHook must be an immutable
+// view of the state, safe for concurrent use, since the hosts read it in parallel. A nil CompiledCodeStore
+// in the HostParameters is replaced by an in-memory one, shared by the hosts like any other store. The
+// engines are not shared: the WasmEngine of the HostParameters must be nil, each host receiving its own
+// engine from NewWasmEngine, or the default WasmerEngine when NewWasmEngine is nil.
+type ArgsQueryPool struct {
+	NumHosts       int
+	BlockChainHook vmcommon.BlockchainHook
+	HostParameters *vmhost.VMHostParameters
+	NewWasmEngine  func() vmhost.WasmEngine
+	QueryTimeout   time.Duration
+}
+
+// queryPool executes the queries in parallel on a pool of independent hosts, each query running in
+// read-only mode on an idle host; the queries arriving while all the hosts are busy wait for one,
+// the wait counting against their timeout
+type queryPool struct {
+	mutClose     sync.RWMutex
+	closed       bool
+	hosts        []vmhost.VMHost
+	idleHosts    chan vmhost.VMHost
+	queryTimeout time.Duration
+}
+
+// NewQueryPool creates a new queryPool, with its hosts
+func NewQueryPool(args ArgsQueryPool) (*queryPool, error) {
+	if args.NumHosts <= 0 {
+		return nil, fmt.Errorf("%w: %d hosts", vmhost.ErrInvalidQueryPoolConfig, args.NumHosts)
+	}
+	if args.QueryTimeout <= 0 {
+		return nil, fmt.Errorf("%w: query timeout %v", vmhost.ErrInvalidQueryPoolConfig, args.QueryTimeout)
+	}
+	if check.IfNil(args.BlockChainHook) {
+		return nil, vmhost.ErrNilBlockChainHook
+	}
+	if args.HostParameters == nil {
+		return nil, vmhost.ErrNilHostParameters
+	}
+	if !check.IfNil(args.HostParameters.WasmEngine) {
+		return nil, fmt.Errorf("%w: the hosts cannot share a WasmEngine, set NewWasmEngine instead", vmhost.ErrInvalidQueryPoolConfig)
+	}
+
+	hostParameters := *args.HostParameters
+	if check.IfNil(hostParameters.CompiledCodeStore) {
+		hostParameters.CompiledCodeStore = compiledstore.NewMemoryCompiledCodeStore()
+	}
+	blockChainHook := &readOnlyBlockchainHook{BlockchainHook: args.BlockChainHook}
+
+	pool := &queryPool{
+		hosts:        make([]vmhost.VMHost, 0, args.NumHosts),
+		idleHosts:    make(chan vmhost.VMHost, args.NumHosts),
+		queryTimeout: args.QueryTimeout,
+	}
+	for i := 0; i < args.NumHosts; i++ {
+		parameters := hostParameters
+		if args.NewWasmEngine != nil {
+			parameters.WasmEngine = args.NewWasmEngine()
+		}
+
+		host, err := hostCore.NewVMHost(blockChainHook, &parameters)
+		if err != nil {
+			_ = pool.Close()
+			return nil, err
+		}
+
+		pool.hosts = append(pool.hosts, host)
+		pool.idleHosts <- host
+	}
+
+	return pool, nil
+}
+
+// RunQuery executes the query on an idle host, in read-only mode; a zero timeout selects the query
+// timeout of the pool
+func (pool *queryPool) RunQuery(input *vmcommon.ContractCallInput, timeout time.Duration) (*vmcommon.VMOutput, error) {
+	if input == nil {
+		return nil, vmhost.ErrInvalidArgument
+	}
+	if timeout == 0 {
+		timeout = pool.queryTimeout
+	}
+
+	pool.mutClose.RLock()
+	defer pool.mutClose.RUnlock()
+
+	if pool.closed {
+		return nil, vmhost.ErrVMIsClosing
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	deadline := time.Now().Add(timeout)
+
+	var host vmhost.VMHost
+	select {
+	case host = <-pool.idleHosts:
+	case <-timer.C:
+		log.Debug("query timed out waiting for an idle host", "function", input.Function)
+		return nil, vmhost.ErrExecutionFailedWithTimeout
+	}
+	defer func() {
+		pool.idleHosts <- host
+	}()
+
+	remaining := time.Until(deadline)
+	if remaining <= 0 {
+		return nil, vmhost.ErrExecutionFailedWithTimeout
+	}
+
+	return host.RunSmartContractQuery(input, remaining)
+}
+
+// GasScheduleChange applies the new gas schedule to all the hosts, each one once its running query ends
+func (pool *queryPool) GasScheduleChange(newGasSchedule config.GasScheduleMap) {
+	for _, host := range pool.hosts {
+		host.GasScheduleChange(newGasSchedule)
+	}
+}
+
+// NumHosts returns the number of hosts of the pool
+func (pool *queryPool) NumHosts() int {
+	return len(pool.hosts)
+}
+
+// Close waits for the running queries and closes the hosts; the queries received afterwards fail
+func (pool *queryPool) Close() error {
+	pool.mutClose.Lock()
+	defer pool.mutClose.Unlock()
+
+	pool.closed = true
+
+	var lastErr error
+	for _, host := range pool.hosts {
+		err := host.Close()
+		if err != nil {
+			lastErr = err
+		}
+	}
+
+	return lastErr
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (pool *queryPool) IsInterfaceNil() bool {
+	return pool == nil
+}
@@ -0,0 +1,215 @@
+package querypool
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+	"github.com/multiversx/mx-chain-vm-common-go/parsers"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/config"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/interpreter"
+	test "github.com/multiversx/mx-chain-vm-v1_4-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/compiledstore"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var counterKey = []byte("COUNTER")
+
+func createTestArgs(world *worldmock.MockWorld) ArgsQueryPool {
+	esdtTransferParser, _ := parsers.NewESDTTransferParser(worldmock.WorldMarshalizer)
+	return ArgsQueryPool{
+		NumHosts:       4,
+		BlockChainHook: world,
+		HostParameters: &vmhost.VMHostParameters{
+			VMType:               test.DefaultVMType,
+			BlockGasLimit:        uint64(1000),
+			GasSchedule:          config.MakeGasMapForTests(),
+			BuiltInFuncContainer: builtInFunctions.NewBuiltInFunctionContainer(),
+			ProtectedKeyPrefix:   []byte(core.ProtectedKeyPrefix),
+			ESDTTransferParser:   esdtTransferParser,
+			EpochNotifier:        &mock.EpochNotifierStub{},
+			EnableEpochsHandler:  world.EnableEpochsHandler,
+			Hasher:               worldmock.DefaultHasher,
+		},
+		NewWasmEngine: func() vmhost.WasmEngine {
+			return interpreter.NewEngine()
+		},
+		QueryTimeout: time.Second,
+	}
+}
+
+func createCounterWorld(counter int64) (*worldmock.MockWorld, []byte) {
+	world := mock.NewMockWorldVM14()
+	scAddress := test.MakeTestSCAddress("counter")
+	code := test.GetTestSCCode("counter", "../../")
+	account := world.AcctMap.CreateSmartContractAccount(test.ParentAddress, scAddress, code, world)
+	account.Storage[string(counterKey)] = big.NewInt(counter).Bytes()
+
+	return world, scAddress
+}
+
+func createCounterQuery(scAddress []byte, function string) *vmcommon.ContractCallInput {
+	return test.CreateTestContractCallInputBuilder().
+		WithRecipientAddr(scAddress).
+		WithGasProvided(100000).
+		WithFunction(function).
+		Build()
+}
+
+func TestNewQueryPool(t *testing.T) {
+	t.Parallel()
+
+	world, _ := createCounterWorld(0)
+
+	t.Run("no hosts should error", func(t *testing.T) {
+		args := createTestArgs(world)
+		args.NumHosts = 0
+		pool, err := NewQueryPool(args)
+		require.True(t, errors.Is(err, vmhost.ErrInvalidQueryPoolConfig))
+		require.True(t, check.IfNil(pool))
+	})
+	t.Run("no query timeout should error", func(t *testing.T) {
+		args := createTestArgs(world)
+		args.QueryTimeout = 0
+		_, err := NewQueryPool(args)
+		require.True(t, errors.Is(err, vmhost.ErrInvalidQueryPoolConfig))
+	})
+	t.Run("nil blockchain hook should error", func(t *testing.T) {
+		args := createTestArgs(world)
+		args.BlockChainHook = nil
+		_, err := NewQueryPool(args)
+		require.Equal(t, vmhost.ErrNilBlockChainHook, err)
+	})
+	t.Run("nil host parameters should error", func(t *testing.T) {
+		args := createTestArgs(world)
+		args.HostParameters = nil
+		_, err := NewQueryPool(args)
+		require.Equal(t, vmhost.ErrNilHostParameters, err)
+	})
+	t.Run("shared wasm engine should error", func(t *testing.T) {
+		args := createTestArgs(world)
+		args.HostParameters.WasmEngine = interpreter.NewEngine()
+		_, err := NewQueryPool(args)
+		require.True(t, errors.Is(err, vmhost.ErrInvalidQueryPoolConfig))
+	})
+	t.Run("each host should get its own wasm engine", func(t *testing.T) {
+		args := createTestArgs(world)
+		engines := make(map[vmhost.WasmEngine]struct{})
+		args.NewWasmEngine = func() vmhost.WasmEngine {
+			engine := interpreter.NewEngine()
+			engines[engine] = struct{}{}
+			return engine
+		}
+		pool, err := NewQueryPool(args)
+		require.Nil(t, err)
+		require.Len(t, engines, args.NumHosts)
+		require.Nil(t, args.HostParameters.WasmEngine)
+		_ = pool.Close()
+	})
+	t.Run("should work", func(t *testing.T) {
+		pool, err := NewQueryPool(createTestArgs(world))
+		require.Nil(t, err)
+		require.False(t, check.IfNil(pool))
+		require.Equal(t, 4, pool.NumHosts())
+		require.Nil(t, pool.Close())
+	})
+}
+
+func TestQueryPool_ParallelQueries(t *testing.T) {
+	world, scAddress := createCounterWorld(42)
+	args := createTestArgs(world)
+	compiledCodeStore := compiledstore.NewMemoryCompiledCodeStore()
+	args.HostParameters.CompiledCodeStore = compiledCodeStore
+	pool, err := NewQueryPool(args)
+	require.Nil(t, err)
+	defer func() {
+		_ = pool.Close()
+	}()
+
+	numQueries := 50
+	outputs := make([]*vmcommon.VMOutput, numQueries)
+	errs := make([]error, numQueries)
+	var wg sync.WaitGroup
+	for i := 0; i < numQueries; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			outputs[i], errs[i] = pool.RunQuery(createCounterQuery(scAddress, "get"), 0)
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < numQueries; i++ {
+		require.Nil(t, errs[i])
+		require.Equal(t, vmcommon.Ok, outputs[i].ReturnCode)
+		require.Equal(t, [][]byte{big.NewInt(42).Bytes()}, outputs[i].ReturnData)
+		require.Equal(t, outputs[0].GasRemaining, outputs[i].GasRemaining)
+	}
+
+	require.Equal(t, 1, compiledCodeStore.Len())
+	require.Empty(t, world.CompiledCode)
+}
+
+func TestQueryPool_QueriesAreReadOnly(t *testing.T) {
+	world, scAddress := createCounterWorld(42)
+	pool, err := NewQueryPool(createTestArgs(world))
+	require.Nil(t, err)
+	defer func() {
+		_ = pool.Close()
+	}()
+
+	vmOutput, err := pool.RunQuery(createCounterQuery(scAddress, "increment"), 0)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, vmhost.ErrCannotWriteOnReadOnly.Error(), vmOutput.ReturnMessage)
+
+	vmOutput, err = pool.RunQuery(createCounterQuery(scAddress, vmhost.UpgradeFunctionName), 0)
+	require.Equal(t, vmhost.ErrInvalidCallOnReadOnlyMode, err)
+	require.Nil(t, vmOutput)
+
+	vmOutput, err = pool.RunQuery(createCounterQuery(scAddress, "get"), 0)
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{big.NewInt(42).Bytes()}, vmOutput.ReturnData)
+}
+
+func TestQueryPool_TimeoutWaitingForHost(t *testing.T) {
+	world, scAddress := createCounterWorld(42)
+	args := createTestArgs(world)
+	args.NumHosts = 1
+	pool, err := NewQueryPool(args)
+	require.Nil(t, err)
+	defer func() {
+		_ = pool.Close()
+	}()
+
+	host := <-pool.idleHosts
+	vmOutput, err := pool.RunQuery(createCounterQuery(scAddress, "get"), 10*time.Millisecond)
+	require.Equal(t, vmhost.ErrExecutionFailedWithTimeout, err)
+	require.Nil(t, vmOutput)
+	pool.idleHosts <- host
+
+	vmOutput, err = pool.RunQuery(createCounterQuery(scAddress, "get"), 0)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+}
+
+func TestQueryPool_Close(t *testing.T) {
+	world, scAddress := createCounterWorld(42)
+	pool, err := NewQueryPool(createTestArgs(world))
+	require.Nil(t, err)
+
+	require.Nil(t, pool.Close())
+
+	vmOutput, err := pool.RunQuery(createCounterQuery(scAddress, "get"), 0)
+	require.Equal(t, vmhost.ErrVMIsClosing, err)
+	require.Nil(t, vmOutput)
+}
@@ -0,0 +1,24 @@
+package querypool
+
+import (
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// readOnlyBlockchainHook is the view of the blockchain hook given to the hosts of the pool: the compiled
+// codes are kept by the compiled code store shared by the hosts instead of being saved through the hook
+type readOnlyBlockchainHook struct {
+	vmcommon.BlockchainHook
+}
+
+// SaveCompiledCode does nothing, the compiled code being saved in the compiled code store of the pool
+func (hook *readOnlyBlockchainHook) SaveCompiledCode(_ []byte, _ []byte) {
+}
+
+// ClearCompiledCodes does nothing
+func (hook *readOnlyBlockchainHook) ClearCompiledCodes() {
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (hook *readOnlyBlockchainHook) IsInterfaceNil() bool {
+	return hook == nil
+}
@@ -0,0 +1,110 @@
+package vmhost
+
+import (
+	"sort"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/math"
+)
+
+// AccountStorageUsage is the net change of the storage of an account during an execution,
+// together with the refund entitled by the freed bytes
+type AccountStorageUsage struct {
+	Address    []byte
+	BytesAdded uint64
+	BytesFreed uint64
+	GasRefund  uint64
+}
+
+// StorageUsage is the net change of the storage of all the accounts written by an execution; its
+// GasRefund is the refund the freed bytes would entitle, which is not applied to the VMOutput
+type StorageUsage struct {
+	Accounts  []*AccountStorageUsage
+	GasRefund uint64
+}
+
+// StorageAccounting remembers the length each written key had before the execution, so that the
+// storage usage is computed from the final values only: a value shrunk and then restored within
+// the same execution frees nothing, while a deleted value frees all of its original bytes
+type StorageAccounting struct {
+	originalLengths map[string]map[string]int
+}
+
+// NewStorageAccounting creates a new StorageAccounting
+func NewStorageAccounting() *StorageAccounting {
+	return &StorageAccounting{
+		originalLengths: make(map[string]map[string]int),
+	}
+}
+
+// RecordOriginalLength records the length the key had before the execution; only the first
+// record of a key is kept, the later ones being values written during the execution
+func (accounting *StorageAccounting) RecordOriginalLength(address []byte, key []byte, length int) {
+	accountLengths, ok := accounting.originalLengths[string(address)]
+	if !ok {
+		accountLengths = make(map[string]int)
+		accounting.originalLengths[string(address)] = accountLengths
+	}
+
+	_, ok = accountLengths[string(key)]
+	if !ok {
+		accountLengths[string(key)] = length
+	}
+}
+
+// ComputeUsage compares the final values written in the VMOutput with the original lengths
+// and computes the refund for the freed bytes; the keys not written in the VMOutput, such as
+// the ones written by reverted calls, keep their original length
+func (accounting *StorageAccounting) ComputeUsage(vmOutput *vmcommon.VMOutput, releasePerByte uint64) *StorageUsage {
+	usage := &StorageUsage{
+		Accounts: make([]*AccountStorageUsage, 0, len(accounting.originalLengths)),
+	}
+
+	for address, accountLengths := range accounting.originalLengths {
+		accountUsage := &AccountStorageUsage{
+			Address: []byte(address),
+		}
+
+		outputAccount := vmOutput.OutputAccounts[address]
+		for key, originalLength := range accountLengths {
+			finalLength := originalLength
+			if outputAccount != nil {
+				update, ok := outputAccount.StorageUpdates[key]
+				if ok && update.Written {
+					finalLength = len(update.Data)
+				}
+			}
+
+			if finalLength > originalLength {
+				accountUsage.BytesAdded += uint64(finalLength - originalLength)
+			} else {
+				accountUsage.BytesFreed += uint64(originalLength - finalLength)
+			}
+		}
+
+		if accountUsage.BytesAdded == 0 && accountUsage.BytesFreed == 0 {
+			continue
+		}
+
+		accountUsage.GasRefund = math.MulUint64(accountUsage.BytesFreed, releasePerByte)
+		usage.GasRefund = math.AddUint64(usage.GasRefund, accountUsage.GasRefund)
+		usage.Accounts = append(usage.Accounts, accountUsage)
+	}
+
+	sort.Slice(usage.Accounts, func(i, j int) bool {
+		return string(usage.Accounts[i].Address) < string(usage.Accounts[j].Address)
+	})
+
+	return usage
+}
+
+// GetAccountUsage returns the storage usage of the given account, or nil if its storage did not change
+func (usage *StorageUsage) GetAccountUsage(address []byte) *AccountStorageUsage {
+	for _, accountUsage := range usage.Accounts {
+		if string(accountUsage.Address) == string(address) {
+			return accountUsage
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package vmhost
+
+import (
+	"testing"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/stretchr/testify/require"
+)
+
+const testReleasePerByte = uint64(10)
+
+func newStorageTestOutput(address []byte, updates map[string][]byte) *vmcommon.VMOutput {
+	account := &vmcommon.OutputAccount{
+		Address:        address,
+		StorageUpdates: make(map[string]*vmcommon.StorageUpdate),
+	}
+	for key, data := range updates {
+		account.StorageUpdates[key] = &vmcommon.StorageUpdate{
+			Offset:  []byte(key),
+			Data:    data,
+			Written: true,
+		}
+	}
+
+	return &vmcommon.VMOutput{
+		OutputAccounts: map[string]*vmcommon.OutputAccount{string(address): account},
+	}
+}
+
+func TestStorageAccounting_Deletion(t *testing.T) {
+	address := []byte("account")
+	accounting := NewStorageAccounting()
+	accounting.RecordOriginalLength(address, []byte("key"), 8)
+
+	vmOutput := newStorageTestOutput(address, map[string][]byte{"key": {}})
+	usage := accounting.ComputeUsage(vmOutput, testReleasePerByte)
+
+	require.Equal(t, 8*testReleasePerByte, usage.GasRefund)
+	accountUsage := usage.GetAccountUsage(address)
+	require.Equal(t, uint64(0), accountUsage.BytesAdded)
+	require.Equal(t, uint64(8), accountUsage.BytesFreed)
+	require.Equal(t, usage.GasRefund, accountUsage.GasRefund)
+}
+
+func TestStorageAccounting_Shrinking(t *testing.T) {
+	address := []byte("account")
+	accounting := NewStorageAccounting()
+	accounting.RecordOriginalLength(address, []byte("key"), 8)
+	// the later records are values written during the execution
+	accounting.RecordOriginalLength(address, []byte("key"), 2)
+
+	vmOutput := newStorageTestOutput(address, map[string][]byte{"key": []byte("abc")})
+	usage := accounting.ComputeUsage(vmOutput, testReleasePerByte)
+
+	require.Equal(t, 5*testReleasePerByte, usage.GasRefund)
+	require.Equal(t, uint64(5), usage.GetAccountUsage(address).BytesFreed)
+}
+
+func TestStorageAccounting_RestoredValue(t *testing.T) {
+	address := []byte("account")
+	accounting := NewStorageAccounting()
+	accounting.RecordOriginalLength(address, []byte("key"), 5)
+
+	vmOutput := newStorageTestOutput(address, map[string][]byte{"key": []byte("value")})
+	usage := accounting.ComputeUsage(vmOutput, testReleasePerByte)
+
+	require.Equal(t, uint64(0), usage.GasRefund)
+	require.Len(t, usage.Accounts, 0)
+	require.Nil(t, usage.GetAccountUsage(address))
+}
+
+func TestStorageAccounting_NotWrittenKeepsOriginalLength(t *testing.T) {
+	address := []byte("account")
+	accounting := NewStorageAccounting()
+	accounting.RecordOriginalLength(address, []byte("reverted"), 5)
+	accounting.RecordOriginalLength([]byte("other"), []byte("key"), 5)
+
+	vmOutput := newStorageTestOutput(address, nil)
+	vmOutput.OutputAccounts[string(address)].StorageUpdates["reverted"] = &vmcommon.StorageUpdate{
+		Offset: []byte("reverted"),
+		Data:   []byte("value"),
+	}
+	usage := accounting.ComputeUsage(vmOutput, testReleasePerByte)
+
+	require.Equal(t, uint64(0), usage.GasRefund)
+	require.Len(t, usage.Accounts, 0)
+}
+
+func TestStorageAccounting_MultipleAccounts(t *testing.T) {
+	first := []byte("first")
+	second := []byte("second")
+	accounting := NewStorageAccounting()
+	accounting.RecordOriginalLength(second, []byte("deleted"), 4)
+	accounting.RecordOriginalLength(second, []byte("added"), 0)
+	accounting.RecordOriginalLength(first, []byte("grown"), 1)
+
+	vmOutput := newStorageTestOutput(first, map[string][]byte{"grown": []byte("abc")})
+	vmOutput.OutputAccounts[string(second)] = newStorageTestOutput(second, map[string][]byte{
+		"deleted": {},
+		"added":   []byte("ab"),
+	}).OutputAccounts[string(second)]
+	usage := accounting.ComputeUsage(vmOutput, testReleasePerByte)
+
+	require.Len(t, usage.Accounts, 2)
+	require.Equal(t, first, usage.Accounts[0].Address)
+	require.Equal(t, uint64(2), usage.Accounts[0].BytesAdded)
+	require.Equal(t, uint64(0), usage.Accounts[0].GasRefund)
+	require.Equal(t, second, usage.Accounts[1].Address)
+	require.Equal(t, uint64(2), usage.Accounts[1].BytesAdded)
+	require.Equal(t, uint64(4), usage.Accounts[1].BytesFreed)
+	require.Equal(t, 4*testReleasePerByte, usage.GasRefund)
+}
@@ -595,6 +595,7 @@ func BaseOpsAPIImports(imports vmhooksmeta.EIFunctionReceiver) error {
 
 //export v1_4_getGasLeft
 func v1_4_getGasLeft(context unsafe.Pointer) int64 {
+	vmhost.TrackEICall(context, "getGasLeft")
 	metering := vmhost.GetMeteringContext(context)
 
 	gasToUse := metering.GasSchedule().BaseOpsAPICost.GetGasLeft
@@ -605,6 +606,7 @@ func v1_4_getGasLeft(context unsafe.Pointer) int64 {
 
 //export v1_4_getSCAddress
 func v1_4_getSCAddress(context unsafe.Pointer, resultOffset int32) {
+	vmhost.TrackEICall(context, "getSCAddress")
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -620,6 +622,7 @@ func v1_4_getSCAddress(context unsafe.Pointer, resultOffset int32) {
 
 //export v1_4_getOwnerAddress
 func v1_4_getOwnerAddress(context unsafe.Pointer, resultOffset int32) {
+	vmhost.TrackEICall(context, "getOwnerAddress")
 	blockchain := vmhost.GetBlockchainContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -640,6 +643,7 @@ func v1_4_getOwnerAddress(context unsafe.Pointer, resultOffset int32) {
 
 //export v1_4_getShardOfAddress
 func v1_4_getShardOfAddress(context unsafe.Pointer, addressOffset int32) int32 {
+	vmhost.TrackEICall(context, "getShardOfAddress")
 	blockchain := vmhost.GetBlockchainContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -657,6 +661,7 @@ func v1_4_getShardOfAddress(context unsafe.Pointer, addressOffset int32) int32 {
 
 //export v1_4_isSmartContract
 func v1_4_isSmartContract(context unsafe.Pointer, addressOffset int32) int32 {
+	vmhost.TrackEICall(context, "isSmartContract")
 	blockchain := vmhost.GetBlockchainContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -676,6 +681,7 @@ func v1_4_isSmartContract(context unsafe.Pointer, addressOffset int32) int32 {
 
 //export v1_4_signalError
 func v1_4_signalError(context unsafe.Pointer, messageOffset int32, messageLength int32) {
+	vmhost.TrackEICall(context, "signalError")
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	metering.StartGasTracing(signalErrorName)
@@ -698,6 +704,7 @@ func v1_4_signalError(context unsafe.Pointer, messageOffset int32, messageLength
 
 //export v1_4_getExternalBalance
 func v1_4_getExternalBalance(context unsafe.Pointer, addressOffset int32, resultOffset int32) {
+	vmhost.TrackEICall(context, "getExternalBalance")
 	blockchain := vmhost.GetBlockchainContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -720,6 +727,7 @@ func v1_4_getExternalBalance(context unsafe.Pointer, addressOffset int32, result
 
 //export v1_4_blockHash
 func v1_4_blockHash(context unsafe.Pointer, nonce int64, resultOffset int32) int32 {
+	vmhost.TrackEICall(context, "getBlockHash")
 	blockchain := vmhost.GetBlockchainContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -783,6 +791,7 @@ func v1_4_getESDTBalance(
 	nonce int64,
 	resultOffset int32,
 ) int32 {
+	vmhost.TrackEICall(context, "getESDTBalance")
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	metering.StartGasTracing(getESDTBalanceName)
@@ -808,6 +817,7 @@ func v1_4_getESDTNFTNameLength(
 	tokenIDLen int32,
 	nonce int64,
 ) int32 {
+	vmhost.TrackEICall(context, "getESDTNFTNameLength")
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	metering.StartGasTracing(getESDTNFTNameLengthName)
@@ -833,6 +843,7 @@ func v1_4_getESDTNFTAttributeLength(
 	tokenIDLen int32,
 	nonce int64,
 ) int32 {
+	vmhost.TrackEICall(context, "getESDTNFTAttributeLength")
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	metering.StartGasTracing(getESDTNFTAttributeLengthName)
@@ -858,6 +869,7 @@ func v1_4_getESDTNFTURILength(
 	tokenIDLen int32,
 	nonce int64,
 ) int32 {
+	vmhost.TrackEICall(context, "getESDTNFTURILength")
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	metering.StartGasTracing(getESDTNFTURILengthName)
@@ -894,6 +906,7 @@ func v1_4_getESDTTokenData(
 	royaltiesHandle int32,
 	urisOffset int32,
 ) int32 {
+	vmhost.TrackEICall(context, "getESDTTokenData")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -946,6 +959,7 @@ func v1_4_getESDTTokenData(
 
 //export v1_4_getESDTLocalRoles
 func v1_4_getESDTLocalRoles(context unsafe.Pointer, tokenIdHandle int32) int64 {
+	vmhost.TrackEICall(context, "getESDTLocalRoles")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	storage := vmhost.GetStorageContext(context)
@@ -973,6 +987,7 @@ func v1_4_validateTokenIdentifier(
 	context unsafe.Pointer,
 	tokenIdHandle int32,
 ) int32 {
+	vmhost.TrackEICall(context, "validateTokenIdentifier")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -995,6 +1010,7 @@ func v1_4_validateTokenIdentifier(
 
 //export v1_4_transferValue
 func v1_4_transferValue(context unsafe.Pointer, destOffset int32, valueOffset int32, dataOffset int32, length int32) int32 {
+	vmhost.TrackEICall(context, "transferValue")
 	host := vmhost.GetVMHost(context)
 	runtime := host.Runtime()
 	metering := host.Metering()
@@ -1157,6 +1173,7 @@ func v1_4_transferValueExecute(
 	argumentsLengthOffset int32,
 	dataOffset int32,
 ) int32 {
+	vmhost.TrackEICall(context, "transferValueExecute")
 	host := vmhost.GetVMHost(context)
 	return TransferValueExecuteWithHost(
 		host,
@@ -1300,8 +1317,9 @@ func v1_4_transferESDTExecute(
 	argumentsLengthOffset int32,
 	dataOffset int32,
 ) int32 {
+	vmhost.TrackEICall(context, "transferESDTExecute")
 
-	return v1_4_transferESDTNFTExecute(context, destOffset, tokenIDOffset, tokenIDLen, valueOffset, 0,
+	return transferESDTNFTExecute(context, destOffset, tokenIDOffset, tokenIDLen, valueOffset, 0,
 		gasLimit, functionOffset, functionLength, numArguments, argumentsLengthOffset, dataOffset)
 }
 
@@ -1319,6 +1337,25 @@ func v1_4_transferESDTNFTExecute(
 	numArguments int32,
 	argumentsLengthOffset int32,
 	dataOffset int32,
+) int32 {
+	vmhost.TrackEICall(context, "transferESDTNFTExecute")
+	return transferESDTNFTExecute(context, destOffset, tokenIDOffset, tokenIDLen, valueOffset, nonce,
+		gasLimit, functionOffset, functionLength, numArguments, argumentsLengthOffset, dataOffset)
+}
+
+func transferESDTNFTExecute(
+	context unsafe.Pointer,
+	destOffset int32,
+	tokenIDOffset int32,
+	tokenIDLen int32,
+	valueOffset int32,
+	nonce int64,
+	gasLimit int64,
+	functionOffset int32,
+	functionLength int32,
+	numArguments int32,
+	argumentsLengthOffset int32,
+	dataOffset int32,
 ) int32 {
 	host := vmhost.GetVMHost(context)
 	metering := host.Metering()
@@ -1352,6 +1389,7 @@ func v1_4_multiTransferESDTNFTExecute(
 	argumentsLengthOffset int32,
 	dataOffset int32,
 ) int32 {
+	vmhost.TrackEICall(context, "multiTransferESDTNFTExecute")
 	host := vmhost.GetVMHost(context)
 	runtime := host.Runtime()
 	metering := host.Metering()
@@ -1540,6 +1578,7 @@ func v1_4_createAsyncCall(context unsafe.Pointer,
 	errorLength int32,
 	gas int64,
 ) {
+	vmhost.TrackEICall(context, "createAsyncCall")
 	host := vmhost.GetVMHost(context)
 	runtime := host.Runtime()
 
@@ -1595,6 +1634,7 @@ func v1_4_setAsyncContextCallback(context unsafe.Pointer,
 	callback int32,
 	callbackLength int32,
 ) int32 {
+	vmhost.TrackEICall(context, "setAsyncContextCallback")
 	host := vmhost.GetVMHost(context)
 	runtime := host.Runtime()
 
@@ -1633,6 +1673,7 @@ func v1_4_upgradeContract(
 	argumentsLengthOffset int32,
 	dataOffset int32,
 ) {
+	vmhost.TrackEICall(context, "upgradeContract")
 	host := vmhost.GetVMHost(context)
 	runtime := host.Runtime()
 	metering := host.Metering()
@@ -1694,6 +1735,7 @@ func v1_4_upgradeFromSourceContract(
 	argumentsLengthOffset int32,
 	dataOffset int32,
 ) {
+	vmhost.TrackEICall(context, "upgradeFromSourceContract")
 	host := vmhost.GetVMHost(context)
 	runtime := host.Runtime()
 	metering := host.Metering()
@@ -1817,6 +1859,7 @@ func upgradeContract(
 
 //export v1_4_asyncCall
 func v1_4_asyncCall(context unsafe.Pointer, destOffset int32, valueOffset int32, dataOffset int32, length int32) {
+	vmhost.TrackEICall(context, "asyncCall")
 	host := vmhost.GetVMHost(context)
 	runtime := host.Runtime()
 	metering := host.Metering()
@@ -1856,6 +1899,7 @@ func v1_4_asyncCall(context unsafe.Pointer, destOffset int32, valueOffset int32,
 
 //export v1_4_getArgumentLength
 func v1_4_getArgumentLength(context unsafe.Pointer, id int32) int32 {
+	vmhost.TrackEICall(context, "getArgumentLength")
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -1873,6 +1917,7 @@ func v1_4_getArgumentLength(context unsafe.Pointer, id int32) int32 {
 
 //export v1_4_getArgument
 func v1_4_getArgument(context unsafe.Pointer, id int32, argOffset int32) int32 {
+	vmhost.TrackEICall(context, "getArgument")
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -1895,6 +1940,7 @@ func v1_4_getArgument(context unsafe.Pointer, id int32, argOffset int32) int32 {
 
 //export v1_4_getFunction
 func v1_4_getFunction(context unsafe.Pointer, functionOffset int32) int32 {
+	vmhost.TrackEICall(context, "getFunction")
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -1912,6 +1958,7 @@ func v1_4_getFunction(context unsafe.Pointer, functionOffset int32) int32 {
 
 //export v1_4_getNumArguments
 func v1_4_getNumArguments(context unsafe.Pointer) int32 {
+	vmhost.TrackEICall(context, "getNumArguments")
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -1924,6 +1971,7 @@ func v1_4_getNumArguments(context unsafe.Pointer) int32 {
 
 //export v1_4_storageStore
 func v1_4_storageStore(context unsafe.Pointer, keyOffset int32, keyLength int32, dataOffset int32, dataLength int32) int32 {
+	vmhost.TrackEICall(context, "storageStore")
 	host := vmhost.GetVMHost(context)
 	return StorageStoreWithHost(
 		host,
@@ -1970,6 +2018,7 @@ func StorageStoreWithTypedArgs(host vmhost.VMHost, key []byte, data []byte) int3
 
 //export v1_4_storageLoadLength
 func v1_4_storageLoadLength(context unsafe.Pointer, keyOffset int32, keyLength int32) int32 {
+	vmhost.TrackEICall(context, "storageLoadLength")
 	runtime := vmhost.GetRuntimeContext(context)
 	storage := vmhost.GetStorageContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -1990,6 +2039,7 @@ func v1_4_storageLoadLength(context unsafe.Pointer, keyOffset int32, keyLength i
 
 //export v1_4_storageLoadFromAddress
 func v1_4_storageLoadFromAddress(context unsafe.Pointer, addressOffset int32, keyOffset int32, keyLength int32, dataOffset int32) int32 {
+	vmhost.TrackEICall(context, "storageLoadFromAddress")
 	host := vmhost.GetVMHost(context)
 	return StorageLoadFromAddressWithHost(
 		host,
@@ -2041,6 +2091,7 @@ func StorageLoadFromAddressWithTypedArgs(host vmhost.VMHost, address []byte, key
 
 //export v1_4_storageLoad
 func v1_4_storageLoad(context unsafe.Pointer, keyOffset int32, keyLength int32, dataOffset int32) int32 {
+	vmhost.TrackEICall(context, "storageLoad")
 	host := vmhost.GetVMHost(context)
 	return StorageLoadWithHost(
 		host,
@@ -2086,6 +2137,11 @@ func StorageLoadWithWithTypedArgs(host vmhost.VMHost, key []byte) ([]byte, error
 
 //export v1_4_setStorageLock
 func v1_4_setStorageLock(context unsafe.Pointer, keyOffset int32, keyLength int32, lockTimestamp int64) int32 {
+	vmhost.TrackEICall(context, "setStorageLock")
+	return setStorageLock(context, keyOffset, keyLength, lockTimestamp)
+}
+
+func setStorageLock(context unsafe.Pointer, keyOffset int32, keyLength int32, lockTimestamp int64) int32 {
 	host := vmhost.GetVMHost(context)
 	return SetStorageLockWithHost(
 		host,
@@ -2126,6 +2182,11 @@ func SetStorageLockWithTypedArgs(host vmhost.VMHost, key []byte, lockTimestamp i
 
 //export v1_4_getStorageLock
 func v1_4_getStorageLock(context unsafe.Pointer, keyOffset int32, keyLength int32) int64 {
+	vmhost.TrackEICall(context, "getStorageLock")
+	return getStorageLock(context, keyOffset, keyLength)
+}
+
+func getStorageLock(context unsafe.Pointer, keyOffset int32, keyLength int32) int64 {
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	storage := vmhost.GetStorageContext(context)
@@ -2151,13 +2212,14 @@ func v1_4_getStorageLock(context unsafe.Pointer, keyOffset int32, keyLength int3
 
 //export v1_4_isStorageLocked
 func v1_4_isStorageLocked(context unsafe.Pointer, keyOffset int32, keyLength int32) int32 {
+	vmhost.TrackEICall(context, "isStorageLocked")
 
-	timeLock := v1_4_getStorageLock(context, keyOffset, keyLength)
+	timeLock := getStorageLock(context, keyOffset, keyLength)
 	if timeLock < 0 {
 		return -1
 	}
 
-	currentTimestamp := v1_4_getBlockTimestamp(context)
+	currentTimestamp := getBlockTimestamp(context)
 	if timeLock <= currentTimestamp {
 		return 0
 	}
@@ -2167,11 +2229,13 @@ func v1_4_isStorageLocked(context unsafe.Pointer, keyOffset int32, keyLength int
 
 //export v1_4_clearStorageLock
 func v1_4_clearStorageLock(context unsafe.Pointer, keyOffset int32, keyLength int32) int32 {
-	return v1_4_setStorageLock(context, keyOffset, keyLength, 0)
+	vmhost.TrackEICall(context, "clearStorageLock")
+	return setStorageLock(context, keyOffset, keyLength, 0)
 }
 
 //export v1_4_getCaller
 func v1_4_getCaller(context unsafe.Pointer, resultOffset int32) {
+	vmhost.TrackEICall(context, "getCaller")
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -2188,6 +2252,7 @@ func v1_4_getCaller(context unsafe.Pointer, resultOffset int32) {
 
 //export v1_4_checkNoPayment
 func v1_4_checkNoPayment(context unsafe.Pointer) {
+	vmhost.TrackEICall(context, "checkNoPayment")
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -2207,6 +2272,7 @@ func v1_4_checkNoPayment(context unsafe.Pointer) {
 
 //export v1_4_callValue
 func v1_4_callValue(context unsafe.Pointer, resultOffset int32) int32 {
+	vmhost.TrackEICall(context, "getCallValue")
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -2226,15 +2292,21 @@ func v1_4_callValue(context unsafe.Pointer, resultOffset int32) int32 {
 
 //export v1_4_getESDTValue
 func v1_4_getESDTValue(context unsafe.Pointer, resultOffset int32) int32 {
+	vmhost.TrackEICall(context, "getESDTValue")
 	isFail := failIfMoreThanOneESDTTransfer(context)
 	if isFail {
 		return -1
 	}
-	return v1_4_getESDTValueByIndex(context, resultOffset, 0)
+	return getESDTValueByIndex(context, resultOffset, 0)
 }
 
 //export v1_4_getESDTValueByIndex
 func v1_4_getESDTValueByIndex(context unsafe.Pointer, resultOffset int32, index int32) int32 {
+	vmhost.TrackEICall(context, "getESDTValueByIndex")
+	return getESDTValueByIndex(context, resultOffset, index)
+}
+
+func getESDTValueByIndex(context unsafe.Pointer, resultOffset int32, index int32) int32 {
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -2259,15 +2331,21 @@ func v1_4_getESDTValueByIndex(context unsafe.Pointer, resultOffset int32, index
 
 //export v1_4_getESDTTokenName
 func v1_4_getESDTTokenName(context unsafe.Pointer, resultOffset int32) int32 {
+	vmhost.TrackEICall(context, "getESDTTokenName")
 	isFail := failIfMoreThanOneESDTTransfer(context)
 	if isFail {
 		return -1
 	}
-	return v1_4_getESDTTokenNameByIndex(context, resultOffset, 0)
+	return getESDTTokenNameByIndex(context, resultOffset, 0)
 }
 
 //export v1_4_getESDTTokenNameByIndex
 func v1_4_getESDTTokenNameByIndex(context unsafe.Pointer, resultOffset int32, index int32) int32 {
+	vmhost.TrackEICall(context, "getESDTTokenNameByIndex")
+	return getESDTTokenNameByIndex(context, resultOffset, index)
+}
+
+func getESDTTokenNameByIndex(context unsafe.Pointer, resultOffset int32, index int32) int32 {
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -2290,15 +2368,21 @@ func v1_4_getESDTTokenNameByIndex(context unsafe.Pointer, resultOffset int32, in
 
 //export v1_4_getESDTTokenNonce
 func v1_4_getESDTTokenNonce(context unsafe.Pointer) int64 {
+	vmhost.TrackEICall(context, "getESDTTokenNonce")
 	isFail := failIfMoreThanOneESDTTransfer(context)
 	if isFail {
 		return -1
 	}
-	return v1_4_getESDTTokenNonceByIndex(context, 0)
+	return getESDTTokenNonceByIndex(context, 0)
 }
 
 //export v1_4_getESDTTokenNonceByIndex
 func v1_4_getESDTTokenNonceByIndex(context unsafe.Pointer, index int32) int64 {
+	vmhost.TrackEICall(context, "getESDTTokenNonceByIndex")
+	return getESDTTokenNonceByIndex(context, index)
+}
+
+func getESDTTokenNonceByIndex(context unsafe.Pointer, index int32) int64 {
 	metering := vmhost.GetMeteringContext(context)
 
 	gasToUse := metering.GasSchedule().BaseOpsAPICost.GetCallValue
@@ -2314,6 +2398,7 @@ func v1_4_getESDTTokenNonceByIndex(context unsafe.Pointer, index int32) int64 {
 
 //export v1_4_getCurrentESDTNFTNonce
 func v1_4_getCurrentESDTNFTNonce(context unsafe.Pointer, addressOffset int32, tokenIDOffset int32, tokenIDLen int32) int64 {
+	vmhost.TrackEICall(context, "getCurrentESDTNFTNonce")
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	storage := vmhost.GetStorageContext(context)
@@ -2343,15 +2428,21 @@ func v1_4_getCurrentESDTNFTNonce(context unsafe.Pointer, addressOffset int32, to
 
 //export v1_4_getESDTTokenType
 func v1_4_getESDTTokenType(context unsafe.Pointer) int32 {
+	vmhost.TrackEICall(context, "getESDTTokenType")
 	isFail := failIfMoreThanOneESDTTransfer(context)
 	if isFail {
 		return -1
 	}
-	return v1_4_getESDTTokenTypeByIndex(context, 0)
+	return getESDTTokenTypeByIndex(context, 0)
 }
 
 //export v1_4_getESDTTokenTypeByIndex
 func v1_4_getESDTTokenTypeByIndex(context unsafe.Pointer, index int32) int32 {
+	vmhost.TrackEICall(context, "getESDTTokenTypeByIndex")
+	return getESDTTokenTypeByIndex(context, index)
+}
+
+func getESDTTokenTypeByIndex(context unsafe.Pointer, index int32) int32 {
 	metering := vmhost.GetMeteringContext(context)
 
 	gasToUse := metering.GasSchedule().BaseOpsAPICost.GetCallValue
@@ -2366,6 +2457,7 @@ func v1_4_getESDTTokenTypeByIndex(context unsafe.Pointer, index int32) int32 {
 
 //export v1_4_getNumESDTTransfers
 func v1_4_getNumESDTTransfers(context unsafe.Pointer) int32 {
+	vmhost.TrackEICall(context, "getNumESDTTransfers")
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -2377,15 +2469,21 @@ func v1_4_getNumESDTTransfers(context unsafe.Pointer) int32 {
 
 //export v1_4_getCallValueTokenName
 func v1_4_getCallValueTokenName(context unsafe.Pointer, callValueOffset int32, tokenNameOffset int32) int32 {
+	vmhost.TrackEICall(context, "getCallValueTokenName")
 	isFail := failIfMoreThanOneESDTTransfer(context)
 	if isFail {
 		return -1
 	}
-	return v1_4_getCallValueTokenNameByIndex(context, callValueOffset, tokenNameOffset, 0)
+	return getCallValueTokenNameByIndex(context, callValueOffset, tokenNameOffset, 0)
 }
 
 //export v1_4_getCallValueTokenNameByIndex
 func v1_4_getCallValueTokenNameByIndex(context unsafe.Pointer, callValueOffset int32, tokenNameOffset int32, index int32) int32 {
+	vmhost.TrackEICall(context, "getCallValueTokenNameByIndex")
+	return getCallValueTokenNameByIndex(context, callValueOffset, tokenNameOffset, index)
+}
+
+func getCallValueTokenNameByIndex(context unsafe.Pointer, callValueOffset int32, tokenNameOffset int32, index int32) int32 {
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -2418,6 +2516,7 @@ func v1_4_getCallValueTokenNameByIndex(context unsafe.Pointer, callValueOffset i
 
 //export v1_4_writeLog
 func v1_4_writeLog(context unsafe.Pointer, dataPointer int32, dataLength int32, topicPtr int32, numTopics int32) {
+	vmhost.TrackEICall(context, "writeLog")
 	// note: deprecated
 	runtime := vmhost.GetRuntimeContext(context)
 	output := vmhost.GetOutputContext(context)
@@ -2457,6 +2556,7 @@ func v1_4_writeEventLog(
 	dataOffset int32,
 	dataLength int32,
 ) {
+	vmhost.TrackEICall(context, "writeEventLog")
 
 	host := vmhost.GetVMHost(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -2490,6 +2590,11 @@ func v1_4_writeEventLog(
 
 //export v1_4_getBlockTimestamp
 func v1_4_getBlockTimestamp(context unsafe.Pointer) int64 {
+	vmhost.TrackEICall(context, "getBlockTimestamp")
+	return getBlockTimestamp(context)
+}
+
+func getBlockTimestamp(context unsafe.Pointer) int64 {
 	blockchain := vmhost.GetBlockchainContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -2501,6 +2606,7 @@ func v1_4_getBlockTimestamp(context unsafe.Pointer) int64 {
 
 //export v1_4_getBlockNonce
 func v1_4_getBlockNonce(context unsafe.Pointer) int64 {
+	vmhost.TrackEICall(context, "getBlockNonce")
 	blockchain := vmhost.GetBlockchainContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -2512,6 +2618,7 @@ func v1_4_getBlockNonce(context unsafe.Pointer) int64 {
 
 //export v1_4_getBlockRound
 func v1_4_getBlockRound(context unsafe.Pointer) int64 {
+	vmhost.TrackEICall(context, "getBlockRound")
 	blockchain := vmhost.GetBlockchainContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -2523,6 +2630,7 @@ func v1_4_getBlockRound(context unsafe.Pointer) int64 {
 
 //export v1_4_getBlockEpoch
 func v1_4_getBlockEpoch(context unsafe.Pointer) int64 {
+	vmhost.TrackEICall(context, "getBlockEpoch")
 	blockchain := vmhost.GetBlockchainContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -2534,6 +2642,7 @@ func v1_4_getBlockEpoch(context unsafe.Pointer) int64 {
 
 //export v1_4_getBlockRandomSeed
 func v1_4_getBlockRandomSeed(context unsafe.Pointer, pointer int32) {
+	vmhost.TrackEICall(context, "getBlockRandomSeed")
 	runtime := vmhost.GetRuntimeContext(context)
 	blockchain := vmhost.GetBlockchainContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -2548,6 +2657,7 @@ func v1_4_getBlockRandomSeed(context unsafe.Pointer, pointer int32) {
 
 //export v1_4_getStateRootHash
 func v1_4_getStateRootHash(context unsafe.Pointer, pointer int32) {
+	vmhost.TrackEICall(context, "getStateRootHash")
 	runtime := vmhost.GetRuntimeContext(context)
 	blockchain := vmhost.GetBlockchainContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -2562,6 +2672,7 @@ func v1_4_getStateRootHash(context unsafe.Pointer, pointer int32) {
 
 //export v1_4_getPrevBlockTimestamp
 func v1_4_getPrevBlockTimestamp(context unsafe.Pointer) int64 {
+	vmhost.TrackEICall(context, "getPrevBlockTimestamp")
 	blockchain := vmhost.GetBlockchainContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -2573,6 +2684,7 @@ func v1_4_getPrevBlockTimestamp(context unsafe.Pointer) int64 {
 
 //export v1_4_getPrevBlockNonce
 func v1_4_getPrevBlockNonce(context unsafe.Pointer) int64 {
+	vmhost.TrackEICall(context, "getPrevBlockNonce")
 	blockchain := vmhost.GetBlockchainContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -2584,6 +2696,7 @@ func v1_4_getPrevBlockNonce(context unsafe.Pointer) int64 {
 
 //export v1_4_getPrevBlockRound
 func v1_4_getPrevBlockRound(context unsafe.Pointer) int64 {
+	vmhost.TrackEICall(context, "getPrevBlockRound")
 	blockchain := vmhost.GetBlockchainContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -2595,6 +2708,7 @@ func v1_4_getPrevBlockRound(context unsafe.Pointer) int64 {
 
 //export v1_4_getPrevBlockEpoch
 func v1_4_getPrevBlockEpoch(context unsafe.Pointer) int64 {
+	vmhost.TrackEICall(context, "getPrevBlockEpoch")
 	blockchain := vmhost.GetBlockchainContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -2606,6 +2720,7 @@ func v1_4_getPrevBlockEpoch(context unsafe.Pointer) int64 {
 
 //export v1_4_getPrevBlockRandomSeed
 func v1_4_getPrevBlockRandomSeed(context unsafe.Pointer, pointer int32) {
+	vmhost.TrackEICall(context, "getPrevBlockRandomSeed")
 	runtime := vmhost.GetRuntimeContext(context)
 	blockchain := vmhost.GetBlockchainContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -2620,6 +2735,7 @@ func v1_4_getPrevBlockRandomSeed(context unsafe.Pointer, pointer int32) {
 
 //export v1_4_returnData
 func v1_4_returnData(context unsafe.Pointer, pointer int32, length int32) {
+	vmhost.TrackEICall(context, "finish")
 	runtime := vmhost.GetRuntimeContext(context)
 	output := vmhost.GetOutputContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -2655,6 +2771,7 @@ func v1_4_executeOnSameContext(
 	argumentsLengthOffset int32,
 	dataOffset int32,
 ) int32 {
+	vmhost.TrackEICall(context, "executeOnSameContext")
 	host := vmhost.GetVMHost(context)
 	metering := host.Metering()
 	metering.StartGasTracing(executeOnSameContextName)
@@ -2758,6 +2875,7 @@ func v1_4_executeOnDestContext(
 	argumentsLengthOffset int32,
 	dataOffset int32,
 ) int32 {
+	vmhost.TrackEICall(context, "executeOnDestContext")
 	host := vmhost.GetVMHost(context)
 	metering := host.Metering()
 	metering.StartGasTracing(executeOnDestContextName)
@@ -2856,6 +2974,7 @@ func v1_4_executeOnDestContextByCaller(
 	argumentsLengthOffset int32,
 	dataOffset int32,
 ) int32 {
+	vmhost.TrackEICall(context, "executeOnDestContextByCaller")
 	host := vmhost.GetVMHost(context)
 	metering := host.Metering()
 	metering.StartGasTracing(executeOnDestContextByCallerName)
@@ -2974,6 +3093,7 @@ func v1_4_executeReadOnly(
 	argumentsLengthOffset int32,
 	dataOffset int32,
 ) int32 {
+	vmhost.TrackEICall(context, "executeReadOnly")
 	host := vmhost.GetVMHost(context)
 	metering := host.Metering()
 	metering.StartGasTracing(executeReadOnlyName)
@@ -3081,6 +3201,7 @@ func v1_4_createContract(
 	argumentsLengthOffset int32,
 	dataOffset int32,
 ) int32 {
+	vmhost.TrackEICall(context, "createContract")
 	host := vmhost.GetVMHost(context)
 	runtime := host.Runtime()
 	metering := host.Metering()
@@ -3146,6 +3267,7 @@ func v1_4_deployFromSourceContract(
 	argumentsLengthOffset int32,
 	dataOffset int32,
 ) int32 {
+	vmhost.TrackEICall(context, "deployFromSourceContract")
 	host := vmhost.GetVMHost(context)
 	runtime := host.Runtime()
 	metering := host.Metering()
@@ -3254,6 +3376,7 @@ func createContract(
 
 //export v1_4_getNumReturnData
 func v1_4_getNumReturnData(context unsafe.Pointer) int32 {
+	vmhost.TrackEICall(context, "getNumReturnData")
 	output := vmhost.GetOutputContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -3266,6 +3389,7 @@ func v1_4_getNumReturnData(context unsafe.Pointer) int32 {
 
 //export v1_4_getReturnDataSize
 func v1_4_getReturnDataSize(context unsafe.Pointer, resultID int32) int32 {
+	vmhost.TrackEICall(context, "getReturnDataSize")
 	runtime := vmhost.GetRuntimeContext(context)
 	output := vmhost.GetOutputContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -3284,6 +3408,7 @@ func v1_4_getReturnDataSize(context unsafe.Pointer, resultID int32) int32 {
 
 //export v1_4_getReturnData
 func v1_4_getReturnData(context unsafe.Pointer, resultID int32, dataOffset int32) int32 {
+	vmhost.TrackEICall(context, "getReturnData")
 	host := vmhost.GetVMHost(context)
 
 	result := GetReturnDataWithHostAndTypedArgs(host, resultID)
@@ -3318,6 +3443,7 @@ func GetReturnDataWithHostAndTypedArgs(host vmhost.VMHost, resultID int32) []byt
 
 //export v1_4_cleanReturnData
 func v1_4_cleanReturnData(context unsafe.Pointer) {
+	vmhost.TrackEICall(context, "cleanReturnData")
 	host := vmhost.GetVMHost(context)
 	CleanReturnDataWithHost(host)
 }
@@ -3335,6 +3461,7 @@ func CleanReturnDataWithHost(host vmhost.VMHost) {
 
 //export v1_4_deleteFromReturnData
 func v1_4_deleteFromReturnData(context unsafe.Pointer, resultID int32) {
+	vmhost.TrackEICall(context, "deleteFromReturnData")
 	host := vmhost.GetVMHost(context)
 	DeleteFromReturnDataWithHost(host, resultID)
 }
@@ -3355,6 +3482,7 @@ func DeleteFromReturnDataWithHost(host vmhost.VMHost, resultID int32) {
 
 //export v1_4_getOriginalTxHash
 func v1_4_getOriginalTxHash(context unsafe.Pointer, dataOffset int32) {
+	vmhost.TrackEICall(context, "getOriginalTxHash")
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
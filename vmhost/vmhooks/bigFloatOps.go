@@ -219,6 +219,7 @@ func setResultIfNotInfinity(host vmhost.VMHost, result *big.Float, destinationHa
 
 //export v1_4_bigFloatNewFromParts
 func v1_4_bigFloatNewFromParts(context unsafe.Pointer, integralPart, fractionalPart, exponent int32) int32 {
+	vmhost.TrackEICall(context, "bigFloatNewFromParts")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -264,6 +265,7 @@ func v1_4_bigFloatNewFromParts(context unsafe.Pointer, integralPart, fractionalP
 
 //export v1_4_bigFloatNewFromFrac
 func v1_4_bigFloatNewFromFrac(context unsafe.Pointer, numerator, denominator int64) int32 {
+	vmhost.TrackEICall(context, "bigFloatNewFromFrac")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -291,6 +293,7 @@ func v1_4_bigFloatNewFromFrac(context unsafe.Pointer, numerator, denominator int
 
 //export v1_4_bigFloatNewFromSci
 func v1_4_bigFloatNewFromSci(context unsafe.Pointer, significand, exponent int64) int32 {
+	vmhost.TrackEICall(context, "bigFloatNewFromSci")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -325,6 +328,7 @@ func v1_4_bigFloatNewFromSci(context unsafe.Pointer, significand, exponent int64
 
 //export v1_4_bigFloatAdd
 func v1_4_bigFloatAdd(context unsafe.Pointer, destinationHandle, op1Handle, op2Handle int32) {
+	vmhost.TrackEICall(context, "bigFloatAdd")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext((context))
@@ -348,6 +352,7 @@ func v1_4_bigFloatAdd(context unsafe.Pointer, destinationHandle, op1Handle, op2H
 
 //export v1_4_bigFloatSub
 func v1_4_bigFloatSub(context unsafe.Pointer, destinationHandle, op1Handle, op2Handle int32) {
+	vmhost.TrackEICall(context, "bigFloatSub")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -370,6 +375,7 @@ func v1_4_bigFloatSub(context unsafe.Pointer, destinationHandle, op1Handle, op2H
 
 //export v1_4_bigFloatMul
 func v1_4_bigFloatMul(context unsafe.Pointer, destinationHandle, op1Handle, op2Handle int32) {
+	vmhost.TrackEICall(context, "bigFloatMul")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -393,6 +399,7 @@ func v1_4_bigFloatMul(context unsafe.Pointer, destinationHandle, op1Handle, op2H
 
 //export v1_4_bigFloatDiv
 func v1_4_bigFloatDiv(context unsafe.Pointer, destinationHandle, op1Handle, op2Handle int32) {
+	vmhost.TrackEICall(context, "bigFloatDiv")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -420,6 +427,7 @@ func v1_4_bigFloatDiv(context unsafe.Pointer, destinationHandle, op1Handle, op2H
 
 //export v1_4_bigFloatNeg
 func v1_4_bigFloatNeg(context unsafe.Pointer, destinationHandle, opHandle int32) {
+	vmhost.TrackEICall(context, "bigFloatNeg")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -442,6 +450,7 @@ func v1_4_bigFloatNeg(context unsafe.Pointer, destinationHandle, opHandle int32)
 
 //export v1_4_bigFloatClone
 func v1_4_bigFloatClone(context unsafe.Pointer, destinationHandle, opHandle int32) {
+	vmhost.TrackEICall(context, "bigFloatClone")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -464,6 +473,7 @@ func v1_4_bigFloatClone(context unsafe.Pointer, destinationHandle, opHandle int3
 
 //export v1_4_bigFloatCmp
 func v1_4_bigFloatCmp(context unsafe.Pointer, op1Handle, op2Handle int32) int32 {
+	vmhost.TrackEICall(context, "bigFloatCmp")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -482,6 +492,7 @@ func v1_4_bigFloatCmp(context unsafe.Pointer, op1Handle, op2Handle int32) int32
 
 //export v1_4_bigFloatAbs
 func v1_4_bigFloatAbs(context unsafe.Pointer, destinationHandle, opHandle int32) {
+	vmhost.TrackEICall(context, "bigFloatAbs")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -504,6 +515,7 @@ func v1_4_bigFloatAbs(context unsafe.Pointer, destinationHandle, opHandle int32)
 
 //export v1_4_bigFloatSign
 func v1_4_bigFloatSign(context unsafe.Pointer, opHandle int32) int32 {
+	vmhost.TrackEICall(context, "bigFloatSign")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -520,6 +532,7 @@ func v1_4_bigFloatSign(context unsafe.Pointer, opHandle int32) int32 {
 
 //export v1_4_bigFloatSqrt
 func v1_4_bigFloatSqrt(context unsafe.Pointer, destinationHandle, opHandle int32) {
+	vmhost.TrackEICall(context, "bigFloatSqrt")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -550,6 +563,7 @@ func v1_4_bigFloatSqrt(context unsafe.Pointer, destinationHandle, opHandle int32
 
 //export v1_4_bigFloatPow
 func v1_4_bigFloatPow(context unsafe.Pointer, destinationHandle, opHandle, exponent int32) {
+	vmhost.TrackEICall(context, "bigFloatPow")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -602,6 +616,7 @@ func pow(context unsafe.Pointer, base *big.Float, exp int32) (*big.Float, error)
 
 //export v1_4_bigFloatFloor
 func v1_4_bigFloatFloor(context unsafe.Pointer, destBigIntHandle, opHandle int32) {
+	vmhost.TrackEICall(context, "bigFloatFloor")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -628,6 +643,7 @@ func v1_4_bigFloatFloor(context unsafe.Pointer, destBigIntHandle, opHandle int32
 
 //export v1_4_bigFloatCeil
 func v1_4_bigFloatCeil(context unsafe.Pointer, destBigIntHandle, opHandle int32) {
+	vmhost.TrackEICall(context, "bigFloatCeil")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -654,6 +670,7 @@ func v1_4_bigFloatCeil(context unsafe.Pointer, destBigIntHandle, opHandle int32)
 
 //export v1_4_bigFloatTruncate
 func v1_4_bigFloatTruncate(context unsafe.Pointer, destBigIntHandle, opHandle int32) {
+	vmhost.TrackEICall(context, "bigFloatTruncate")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -674,6 +691,7 @@ func v1_4_bigFloatTruncate(context unsafe.Pointer, destBigIntHandle, opHandle in
 
 //export v1_4_bigFloatSetInt64
 func v1_4_bigFloatSetInt64(context unsafe.Pointer, destinationHandle int32, value int64) {
+	vmhost.TrackEICall(context, "bigFloatSetInt64")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -690,6 +708,7 @@ func v1_4_bigFloatSetInt64(context unsafe.Pointer, destinationHandle int32, valu
 
 //export v1_4_bigFloatIsInt
 func v1_4_bigFloatIsInt(context unsafe.Pointer, opHandle int32) int32 {
+	vmhost.TrackEICall(context, "bigFloatIsInt")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -709,6 +728,7 @@ func v1_4_bigFloatIsInt(context unsafe.Pointer, opHandle int32) int32 {
 
 //export v1_4_bigFloatSetBigInt
 func v1_4_bigFloatSetBigInt(context unsafe.Pointer, destinationHandle, bigIntHandle int32) {
+	vmhost.TrackEICall(context, "bigFloatSetBigInt")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -728,6 +748,7 @@ func v1_4_bigFloatSetBigInt(context unsafe.Pointer, destinationHandle, bigIntHan
 
 //export v1_4_bigFloatGetConstPi
 func v1_4_bigFloatGetConstPi(context unsafe.Pointer, destinationHandle int32) {
+	vmhost.TrackEICall(context, "bigFloatGetConstPi")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -744,6 +765,7 @@ func v1_4_bigFloatGetConstPi(context unsafe.Pointer, destinationHandle int32) {
 
 //export v1_4_bigFloatGetConstE
 func v1_4_bigFloatGetConstE(context unsafe.Pointer, destinationHandle int32) {
+	vmhost.TrackEICall(context, "bigFloatGetConstE")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
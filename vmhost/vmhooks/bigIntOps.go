@@ -331,6 +331,7 @@ func BigIntImports(imports vmhooksmeta.EIFunctionReceiver) error {
 
 //export v1_4_bigIntGetUnsignedArgument
 func v1_4_bigIntGetUnsignedArgument(context unsafe.Pointer, id int32, destinationHandle int32) {
+	vmhost.TrackEICall(context, "bigIntGetUnsignedArgument")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -350,6 +351,7 @@ func v1_4_bigIntGetUnsignedArgument(context unsafe.Pointer, id int32, destinatio
 
 //export v1_4_bigIntGetSignedArgument
 func v1_4_bigIntGetSignedArgument(context unsafe.Pointer, id int32, destinationHandle int32) {
+	vmhost.TrackEICall(context, "bigIntGetSignedArgument")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -369,6 +371,7 @@ func v1_4_bigIntGetSignedArgument(context unsafe.Pointer, id int32, destinationH
 
 //export v1_4_bigIntStorageStoreUnsigned
 func v1_4_bigIntStorageStoreUnsigned(context unsafe.Pointer, keyOffset int32, keyLength int32, sourceHandle int32) int32 {
+	vmhost.TrackEICall(context, "bigIntStorageStoreUnsigned")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	storage := vmhost.GetStorageContext(context)
@@ -395,6 +398,7 @@ func v1_4_bigIntStorageStoreUnsigned(context unsafe.Pointer, keyOffset int32, ke
 
 //export v1_4_bigIntStorageLoadUnsigned
 func v1_4_bigIntStorageLoadUnsigned(context unsafe.Pointer, keyOffset int32, keyLength int32, destinationHandle int32) int32 {
+	vmhost.TrackEICall(context, "bigIntStorageLoadUnsigned")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	storage := vmhost.GetStorageContext(context)
@@ -419,6 +423,7 @@ func v1_4_bigIntStorageLoadUnsigned(context unsafe.Pointer, keyOffset int32, key
 
 //export v1_4_bigIntGetCallValue
 func v1_4_bigIntGetCallValue(context unsafe.Pointer, destinationHandle int32) {
+	vmhost.TrackEICall(context, "bigIntGetCallValue")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -432,15 +437,21 @@ func v1_4_bigIntGetCallValue(context unsafe.Pointer, destinationHandle int32) {
 
 //export v1_4_bigIntGetESDTCallValue
 func v1_4_bigIntGetESDTCallValue(context unsafe.Pointer, destination int32) {
+	vmhost.TrackEICall(context, "bigIntGetESDTCallValue")
 	isFail := failIfMoreThanOneESDTTransfer(context)
 	if isFail {
 		return
 	}
-	v1_4_bigIntGetESDTCallValueByIndex(context, destination, 0)
+	bigIntGetESDTCallValueByIndex(context, destination, 0)
 }
 
 //export v1_4_bigIntGetESDTCallValueByIndex
 func v1_4_bigIntGetESDTCallValueByIndex(context unsafe.Pointer, destinationHandle int32, index int32) {
+	vmhost.TrackEICall(context, "bigIntGetESDTCallValueByIndex")
+	bigIntGetESDTCallValueByIndex(context, destinationHandle, index)
+}
+
+func bigIntGetESDTCallValueByIndex(context unsafe.Pointer, destinationHandle int32, index int32) {
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -458,6 +469,7 @@ func v1_4_bigIntGetESDTCallValueByIndex(context unsafe.Pointer, destinationHandl
 
 //export v1_4_bigIntGetExternalBalance
 func v1_4_bigIntGetExternalBalance(context unsafe.Pointer, addressOffset int32, result int32) {
+	vmhost.TrackEICall(context, "bigIntGetExternalBalance")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	blockchain := vmhost.GetBlockchainContext(context)
@@ -479,6 +491,7 @@ func v1_4_bigIntGetExternalBalance(context unsafe.Pointer, addressOffset int32,
 
 //export v1_4_bigIntGetESDTExternalBalance
 func v1_4_bigIntGetESDTExternalBalance(context unsafe.Pointer, addressOffset int32, tokenIDOffset int32, tokenIDLen int32, nonce int64, resultHandle int32) {
+	vmhost.TrackEICall(context, "bigIntGetESDTExternalBalance")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -501,6 +514,7 @@ func v1_4_bigIntGetESDTExternalBalance(context unsafe.Pointer, addressOffset int
 
 //export v1_4_bigIntNew
 func v1_4_bigIntNew(context unsafe.Pointer, smallValue int64) int32 {
+	vmhost.TrackEICall(context, "bigIntNew")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -512,6 +526,7 @@ func v1_4_bigIntNew(context unsafe.Pointer, smallValue int64) int32 {
 
 //export v1_4_bigIntUnsignedByteLength
 func v1_4_bigIntUnsignedByteLength(context unsafe.Pointer, referenceHandle int32) int32 {
+	vmhost.TrackEICall(context, "bigIntUnsignedByteLength")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -530,6 +545,7 @@ func v1_4_bigIntUnsignedByteLength(context unsafe.Pointer, referenceHandle int32
 
 //export v1_4_bigIntSignedByteLength
 func v1_4_bigIntSignedByteLength(context unsafe.Pointer, referenceHandle int32) int32 {
+	vmhost.TrackEICall(context, "bigIntSignedByteLength")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -548,6 +564,7 @@ func v1_4_bigIntSignedByteLength(context unsafe.Pointer, referenceHandle int32)
 
 //export v1_4_bigIntGetUnsignedBytes
 func v1_4_bigIntGetUnsignedBytes(context unsafe.Pointer, referenceHandle int32, byteOffset int32) int32 {
+	vmhost.TrackEICall(context, "bigIntGetUnsignedBytes")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -575,6 +592,7 @@ func v1_4_bigIntGetUnsignedBytes(context unsafe.Pointer, referenceHandle int32,
 
 //export v1_4_bigIntGetSignedBytes
 func v1_4_bigIntGetSignedBytes(context unsafe.Pointer, referenceHandle int32, byteOffset int32) int32 {
+	vmhost.TrackEICall(context, "bigIntGetSignedBytes")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -602,6 +620,7 @@ func v1_4_bigIntGetSignedBytes(context unsafe.Pointer, referenceHandle int32, by
 
 //export v1_4_bigIntSetUnsignedBytes
 func v1_4_bigIntSetUnsignedBytes(context unsafe.Pointer, destinationHandle int32, byteOffset int32, byteLength int32) {
+	vmhost.TrackEICall(context, "bigIntSetUnsignedBytes")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -623,6 +642,7 @@ func v1_4_bigIntSetUnsignedBytes(context unsafe.Pointer, destinationHandle int32
 
 //export v1_4_bigIntSetSignedBytes
 func v1_4_bigIntSetSignedBytes(context unsafe.Pointer, destinationHandle int32, byteOffset int32, byteLength int32) {
+	vmhost.TrackEICall(context, "bigIntSetSignedBytes")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -644,6 +664,7 @@ func v1_4_bigIntSetSignedBytes(context unsafe.Pointer, destinationHandle int32,
 
 //export v1_4_bigIntIsInt64
 func v1_4_bigIntIsInt64(context unsafe.Pointer, destinationHandle int32) int32 {
+	vmhost.TrackEICall(context, "bigIntIsInt64")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -663,6 +684,7 @@ func v1_4_bigIntIsInt64(context unsafe.Pointer, destinationHandle int32) int32 {
 
 //export v1_4_bigIntGetInt64
 func v1_4_bigIntGetInt64(context unsafe.Pointer, destinationHandle int32) int64 {
+	vmhost.TrackEICall(context, "bigIntGetInt64")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -675,6 +697,7 @@ func v1_4_bigIntGetInt64(context unsafe.Pointer, destinationHandle int32) int64
 
 //export v1_4_bigIntSetInt64
 func v1_4_bigIntSetInt64(context unsafe.Pointer, destinationHandle int32, value int64) {
+	vmhost.TrackEICall(context, "bigIntSetInt64")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -690,6 +713,7 @@ func v1_4_bigIntSetInt64(context unsafe.Pointer, destinationHandle int32, value
 
 //export v1_4_bigIntAdd
 func v1_4_bigIntAdd(context unsafe.Pointer, destinationHandle, op1Handle, op2Handle int32) {
+	vmhost.TrackEICall(context, "bigIntAdd")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -712,6 +736,7 @@ func v1_4_bigIntAdd(context unsafe.Pointer, destinationHandle, op1Handle, op2Han
 
 //export v1_4_bigIntSub
 func v1_4_bigIntSub(context unsafe.Pointer, destinationHandle, op1Handle, op2Handle int32) {
+	vmhost.TrackEICall(context, "bigIntSub")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -731,6 +756,7 @@ func v1_4_bigIntSub(context unsafe.Pointer, destinationHandle, op1Handle, op2Han
 
 //export v1_4_bigIntMul
 func v1_4_bigIntMul(context unsafe.Pointer, destinationHandle, op1Handle, op2Handle int32) {
+	vmhost.TrackEICall(context, "bigIntMul")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -751,6 +777,7 @@ func v1_4_bigIntMul(context unsafe.Pointer, destinationHandle, op1Handle, op2Han
 
 //export v1_4_bigIntTDiv
 func v1_4_bigIntTDiv(context unsafe.Pointer, destinationHandle, op1Handle, op2Handle int32) {
+	vmhost.TrackEICall(context, "bigIntTDiv")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -774,6 +801,7 @@ func v1_4_bigIntTDiv(context unsafe.Pointer, destinationHandle, op1Handle, op2Ha
 
 //export v1_4_bigIntTMod
 func v1_4_bigIntTMod(context unsafe.Pointer, destinationHandle, op1Handle, op2Handle int32) {
+	vmhost.TrackEICall(context, "bigIntTMod")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -800,6 +828,7 @@ func v1_4_bigIntTMod(context unsafe.Pointer, destinationHandle, op1Handle, op2Ha
 
 //export v1_4_bigIntEDiv
 func v1_4_bigIntEDiv(context unsafe.Pointer, destinationHandle, op1Handle, op2Handle int32) {
+	vmhost.TrackEICall(context, "bigIntEDiv")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -826,6 +855,7 @@ func v1_4_bigIntEDiv(context unsafe.Pointer, destinationHandle, op1Handle, op2Ha
 
 //export v1_4_bigIntEMod
 func v1_4_bigIntEMod(context unsafe.Pointer, destinationHandle, op1Handle, op2Handle int32) {
+	vmhost.TrackEICall(context, "bigIntEMod")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -852,6 +882,7 @@ func v1_4_bigIntEMod(context unsafe.Pointer, destinationHandle, op1Handle, op2Ha
 
 //export v1_4_bigIntSqrt
 func v1_4_bigIntSqrt(context unsafe.Pointer, destinationHandle, opHandle int32) {
+	vmhost.TrackEICall(context, "bigIntSqrt")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -875,6 +906,7 @@ func v1_4_bigIntSqrt(context unsafe.Pointer, destinationHandle, opHandle int32)
 
 //export v1_4_bigIntPow
 func v1_4_bigIntPow(context unsafe.Pointer, destinationHandle, op1Handle, op2Handle int32) {
+	vmhost.TrackEICall(context, "bigIntPow")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -905,6 +937,7 @@ func v1_4_bigIntPow(context unsafe.Pointer, destinationHandle, op1Handle, op2Han
 
 //export v1_4_bigIntLog2
 func v1_4_bigIntLog2(context unsafe.Pointer, op1Handle int32) int32 {
+	vmhost.TrackEICall(context, "bigIntLog2")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -928,6 +961,7 @@ func v1_4_bigIntLog2(context unsafe.Pointer, op1Handle int32) int32 {
 
 //export v1_4_bigIntAbs
 func v1_4_bigIntAbs(context unsafe.Pointer, destinationHandle, opHandle int32) {
+	vmhost.TrackEICall(context, "bigIntAbs")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -950,6 +984,7 @@ func v1_4_bigIntAbs(context unsafe.Pointer, destinationHandle, opHandle int32) {
 
 //export v1_4_bigIntNeg
 func v1_4_bigIntNeg(context unsafe.Pointer, destinationHandle, opHandle int32) {
+	vmhost.TrackEICall(context, "bigIntNeg")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -972,6 +1007,7 @@ func v1_4_bigIntNeg(context unsafe.Pointer, destinationHandle, opHandle int32) {
 
 //export v1_4_bigIntSign
 func v1_4_bigIntSign(context unsafe.Pointer, opHandle int32) int32 {
+	vmhost.TrackEICall(context, "bigIntSign")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -990,6 +1026,7 @@ func v1_4_bigIntSign(context unsafe.Pointer, opHandle int32) int32 {
 
 //export v1_4_bigIntCmp
 func v1_4_bigIntCmp(context unsafe.Pointer, op1Handle, op2Handle int32) int32 {
+	vmhost.TrackEICall(context, "bigIntCmp")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -1008,6 +1045,7 @@ func v1_4_bigIntCmp(context unsafe.Pointer, op1Handle, op2Handle int32) int32 {
 
 //export v1_4_bigIntNot
 func v1_4_bigIntNot(context unsafe.Pointer, destinationHandle, opHandle int32) {
+	vmhost.TrackEICall(context, "bigIntNot")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -1034,6 +1072,7 @@ func v1_4_bigIntNot(context unsafe.Pointer, destinationHandle, opHandle int32) {
 
 //export v1_4_bigIntAnd
 func v1_4_bigIntAnd(context unsafe.Pointer, destinationHandle, op1Handle, op2Handle int32) {
+	vmhost.TrackEICall(context, "bigIntAnd")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -1060,6 +1099,7 @@ func v1_4_bigIntAnd(context unsafe.Pointer, destinationHandle, op1Handle, op2Han
 
 //export v1_4_bigIntOr
 func v1_4_bigIntOr(context unsafe.Pointer, destinationHandle, op1Handle, op2Handle int32) {
+	vmhost.TrackEICall(context, "bigIntOr")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -1086,6 +1126,7 @@ func v1_4_bigIntOr(context unsafe.Pointer, destinationHandle, op1Handle, op2Hand
 
 //export v1_4_bigIntXor
 func v1_4_bigIntXor(context unsafe.Pointer, destinationHandle, op1Handle, op2Handle int32) {
+	vmhost.TrackEICall(context, "bigIntXor")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -1112,6 +1153,7 @@ func v1_4_bigIntXor(context unsafe.Pointer, destinationHandle, op1Handle, op2Han
 
 //export v1_4_bigIntShr
 func v1_4_bigIntShr(context unsafe.Pointer, destinationHandle, opHandle, bits int32) {
+	vmhost.TrackEICall(context, "bigIntShr")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -1139,6 +1181,7 @@ func v1_4_bigIntShr(context unsafe.Pointer, destinationHandle, opHandle, bits in
 
 //export v1_4_bigIntShl
 func v1_4_bigIntShl(context unsafe.Pointer, destinationHandle, opHandle, bits int32) {
+	vmhost.TrackEICall(context, "bigIntShl")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -1167,6 +1210,7 @@ func v1_4_bigIntShl(context unsafe.Pointer, destinationHandle, opHandle, bits in
 
 //export v1_4_bigIntFinishUnsigned
 func v1_4_bigIntFinishUnsigned(context unsafe.Pointer, referenceHandle int32) {
+	vmhost.TrackEICall(context, "bigIntFinishUnsigned")
 	managedType := vmhost.GetManagedTypesContext(context)
 	output := vmhost.GetOutputContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -1189,6 +1233,7 @@ func v1_4_bigIntFinishUnsigned(context unsafe.Pointer, referenceHandle int32) {
 
 //export v1_4_bigIntFinishSigned
 func v1_4_bigIntFinishSigned(context unsafe.Pointer, referenceHandle int32) {
+	vmhost.TrackEICall(context, "bigIntFinishSigned")
 	managedType := vmhost.GetManagedTypesContext(context)
 	output := vmhost.GetOutputContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -1211,6 +1256,7 @@ func v1_4_bigIntFinishSigned(context unsafe.Pointer, referenceHandle int32) {
 
 //export v1_4_bigIntToString
 func v1_4_bigIntToString(context unsafe.Pointer, bigIntHandle int32, destinationHandle int32) {
+	vmhost.TrackEICall(context, "bigIntToString")
 	host := vmhost.GetVMHost(context)
 	BigIntToStringWithHost(host, bigIntHandle, destinationHandle)
 }
@@ -194,6 +194,7 @@ func ManagedBufferImports(imports vmhooksmeta.EIFunctionReceiver) error {
 
 //export v1_4_mBufferNew
 func v1_4_mBufferNew(context unsafe.Pointer) int32 {
+	vmhost.TrackEICall(context, "mBufferNew")
 	managedType := vmhost.GetManagedTypesContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -205,6 +206,7 @@ func v1_4_mBufferNew(context unsafe.Pointer) int32 {
 
 //export v1_4_mBufferNewFromBytes
 func v1_4_mBufferNewFromBytes(context unsafe.Pointer, dataOffset int32, dataLength int32) int32 {
+	vmhost.TrackEICall(context, "mBufferNewFromBytes")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -222,6 +224,7 @@ func v1_4_mBufferNewFromBytes(context unsafe.Pointer, dataOffset int32, dataLeng
 
 //export v1_4_mBufferGetLength
 func v1_4_mBufferGetLength(context unsafe.Pointer, mBufferHandle int32) int32 {
+	vmhost.TrackEICall(context, "mBufferGetLength")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -240,6 +243,7 @@ func v1_4_mBufferGetLength(context unsafe.Pointer, mBufferHandle int32) int32 {
 
 //export v1_4_mBufferGetBytes
 func v1_4_mBufferGetBytes(context unsafe.Pointer, mBufferHandle int32, resultOffset int32) int32 {
+	vmhost.TrackEICall(context, "mBufferGetBytes")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -270,6 +274,7 @@ func v1_4_mBufferGetBytes(context unsafe.Pointer, mBufferHandle int32, resultOff
 
 //export v1_4_mBufferGetByteSlice
 func v1_4_mBufferGetByteSlice(context unsafe.Pointer, sourceHandle int32, startingPosition int32, sliceLength int32, resultOffset int32) int32 {
+	vmhost.TrackEICall(context, "mBufferGetByteSlice")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -306,6 +311,7 @@ func v1_4_mBufferGetByteSlice(context unsafe.Pointer, sourceHandle int32, starti
 
 //export v1_4_mBufferCopyByteSlice
 func v1_4_mBufferCopyByteSlice(context unsafe.Pointer, sourceHandle int32, startingPosition int32, sliceLength int32, destinationHandle int32) int32 {
+	vmhost.TrackEICall(context, "mBufferCopyByteSlice")
 	host := vmhost.GetVMHost(context)
 	return ManagedBufferCopyByteSliceWithHost(host, sourceHandle, startingPosition, sliceLength, destinationHandle)
 }
@@ -341,6 +347,7 @@ func ManagedBufferCopyByteSliceWithHost(host vmhost.VMHost, sourceHandle int32,
 
 //export v1_4_mBufferEq
 func v1_4_mBufferEq(context unsafe.Pointer, mBufferHandle1 int32, mBufferHandle2 int32) int32 {
+	vmhost.TrackEICall(context, "mBufferEq")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -370,6 +377,7 @@ func v1_4_mBufferEq(context unsafe.Pointer, mBufferHandle1 int32, mBufferHandle2
 
 //export v1_4_mBufferSetBytes
 func v1_4_mBufferSetBytes(context unsafe.Pointer, mBufferHandle int32, dataOffset int32, dataLength int32) int32 {
+	vmhost.TrackEICall(context, "mBufferSetBytes")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -396,6 +404,7 @@ func v1_4_mBufferSetBytes(context unsafe.Pointer, mBufferHandle int32, dataOffse
 
 //export v1_4_mBufferSetByteSlice
 func v1_4_mBufferSetByteSlice(context unsafe.Pointer, mBufferHandle int32, startingPosition int32, dataLength int32, dataOffset int32) int32 {
+	vmhost.TrackEICall(context, "mBufferSetByteSlice")
 	host := vmhost.GetVMHost(context)
 	return ManagedBufferSetByteSliceWithHost(host, mBufferHandle, startingPosition, dataLength, dataOffset)
 }
@@ -447,6 +456,7 @@ func ManagedBufferSetByteSliceWithTypedArgs(host vmhost.VMHost, mBufferHandle in
 
 //export v1_4_mBufferAppend
 func v1_4_mBufferAppend(context unsafe.Pointer, accumulatorHandle int32, dataHandle int32) int32 {
+	vmhost.TrackEICall(context, "mBufferAppend")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -478,6 +488,7 @@ func v1_4_mBufferAppend(context unsafe.Pointer, accumulatorHandle int32, dataHan
 
 //export v1_4_mBufferAppendBytes
 func v1_4_mBufferAppendBytes(context unsafe.Pointer, accumulatorHandle int32, dataOffset int32, dataLength int32) int32 {
+	vmhost.TrackEICall(context, "mBufferAppendBytes")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -505,6 +516,7 @@ func v1_4_mBufferAppendBytes(context unsafe.Pointer, accumulatorHandle int32, da
 
 //export v1_4_mBufferToBigIntUnsigned
 func v1_4_mBufferToBigIntUnsigned(context unsafe.Pointer, mBufferHandle int32, bigIntHandle int32) int32 {
+	vmhost.TrackEICall(context, "mBufferToBigIntUnsigned")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -525,6 +537,7 @@ func v1_4_mBufferToBigIntUnsigned(context unsafe.Pointer, mBufferHandle int32, b
 
 //export v1_4_mBufferToBigIntSigned
 func v1_4_mBufferToBigIntSigned(context unsafe.Pointer, mBufferHandle int32, bigIntHandle int32) int32 {
+	vmhost.TrackEICall(context, "mBufferToBigIntSigned")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -545,6 +558,7 @@ func v1_4_mBufferToBigIntSigned(context unsafe.Pointer, mBufferHandle int32, big
 
 //export v1_4_mBufferFromBigIntUnsigned
 func v1_4_mBufferFromBigIntUnsigned(context unsafe.Pointer, mBufferHandle int32, bigIntHandle int32) int32 {
+	vmhost.TrackEICall(context, "mBufferFromBigIntUnsigned")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -564,6 +578,7 @@ func v1_4_mBufferFromBigIntUnsigned(context unsafe.Pointer, mBufferHandle int32,
 
 //export v1_4_mBufferFromBigIntSigned
 func v1_4_mBufferFromBigIntSigned(context unsafe.Pointer, mBufferHandle int32, bigIntHandle int32) int32 {
+	vmhost.TrackEICall(context, "mBufferFromBigIntSigned")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -582,6 +597,7 @@ func v1_4_mBufferFromBigIntSigned(context unsafe.Pointer, mBufferHandle int32, b
 
 //export v1_4_mBufferToBigFloat
 func v1_4_mBufferToBigFloat(context unsafe.Pointer, mBufferHandle, bigFloatHandle int32) int32 {
+	vmhost.TrackEICall(context, "mBufferToBigFloat")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -622,6 +638,7 @@ func v1_4_mBufferToBigFloat(context unsafe.Pointer, mBufferHandle, bigFloatHandl
 
 //export v1_4_mBufferFromBigFloat
 func v1_4_mBufferFromBigFloat(context unsafe.Pointer, mBufferHandle, bigFloatHandle int32) int32 {
+	vmhost.TrackEICall(context, "mBufferFromBigFloat")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -648,6 +665,7 @@ func v1_4_mBufferFromBigFloat(context unsafe.Pointer, mBufferHandle, bigFloatHan
 
 //export v1_4_mBufferStorageStore
 func v1_4_mBufferStorageStore(context unsafe.Pointer, keyHandle int32, sourceHandle int32) int32 {
+	vmhost.TrackEICall(context, "mBufferStorageStore")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	storage := vmhost.GetStorageContext(context)
@@ -676,6 +694,7 @@ func v1_4_mBufferStorageStore(context unsafe.Pointer, keyHandle int32, sourceHan
 
 //export v1_4_mBufferStorageLoad
 func v1_4_mBufferStorageLoad(context unsafe.Pointer, keyHandle int32, destinationHandle int32) int32 {
+	vmhost.TrackEICall(context, "mBufferStorageLoad")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	storage := vmhost.GetStorageContext(context)
@@ -699,6 +718,7 @@ func v1_4_mBufferStorageLoad(context unsafe.Pointer, keyHandle int32, destinatio
 
 //export v1_4_mBufferStorageLoadFromAddress
 func v1_4_mBufferStorageLoadFromAddress(context unsafe.Pointer, addressHandle, keyHandle, destinationHandle int32) {
+	vmhost.TrackEICall(context, "mBufferStorageLoadFromAddress")
 	host := vmhost.GetVMHost(context)
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -724,6 +744,7 @@ func v1_4_mBufferStorageLoadFromAddress(context unsafe.Pointer, addressHandle, k
 
 //export v1_4_mBufferGetArgument
 func v1_4_mBufferGetArgument(context unsafe.Pointer, id int32, destinationHandle int32) int32 {
+	vmhost.TrackEICall(context, "mBufferGetArgument")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -742,6 +763,7 @@ func v1_4_mBufferGetArgument(context unsafe.Pointer, id int32, destinationHandle
 
 //export v1_4_mBufferFinish
 func v1_4_mBufferFinish(context unsafe.Pointer, sourceHandle int32) int32 {
+	vmhost.TrackEICall(context, "mBufferFinish")
 	managedType := vmhost.GetManagedTypesContext(context)
 	output := vmhost.GetOutputContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -769,6 +791,7 @@ func v1_4_mBufferFinish(context unsafe.Pointer, sourceHandle int32) int32 {
 
 //export v1_4_mBufferSetRandom
 func v1_4_mBufferSetRandom(context unsafe.Pointer, destinationHandle int32, length int32) int32 {
+	vmhost.TrackEICall(context, "mBufferSetRandom")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -233,6 +233,7 @@ func ManagedEIImports(imports vmhooksmeta.EIFunctionReceiver) error {
 
 //export v1_4_managedSCAddress
 func v1_4_managedSCAddress(context unsafe.Pointer, destinationHandle int32) {
+	vmhost.TrackEICall(context, "managedSCAddress")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -247,6 +248,7 @@ func v1_4_managedSCAddress(context unsafe.Pointer, destinationHandle int32) {
 
 //export v1_4_managedOwnerAddress
 func v1_4_managedOwnerAddress(context unsafe.Pointer, destinationHandle int32) {
+	vmhost.TrackEICall(context, "managedOwnerAddress")
 	managedType := vmhost.GetManagedTypesContext(context)
 	blockchain := vmhost.GetBlockchainContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -265,6 +267,7 @@ func v1_4_managedOwnerAddress(context unsafe.Pointer, destinationHandle int32) {
 
 //export v1_4_managedCaller
 func v1_4_managedCaller(context unsafe.Pointer, destinationHandle int32) {
+	vmhost.TrackEICall(context, "managedCaller")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -278,6 +281,7 @@ func v1_4_managedCaller(context unsafe.Pointer, destinationHandle int32) {
 
 //export v1_4_managedSignalError
 func v1_4_managedSignalError(context unsafe.Pointer, errHandle int32) {
+	vmhost.TrackEICall(context, "managedSignalError")
 	managedType := vmhost.GetManagedTypesContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -308,6 +312,7 @@ func v1_4_managedWriteLog(
 	topicsHandle int32,
 	dataHandle int32,
 ) {
+	vmhost.TrackEICall(context, "managedWriteLog")
 	runtime := vmhost.GetRuntimeContext(context)
 	output := vmhost.GetOutputContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -338,6 +343,7 @@ func v1_4_managedWriteLog(
 
 //export v1_4_managedGetOriginalTxHash
 func v1_4_managedGetOriginalTxHash(context unsafe.Pointer, resultHandle int32) {
+	vmhost.TrackEICall(context, "managedGetOriginalTxHash")
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	managedType := vmhost.GetManagedTypesContext(context)
@@ -350,6 +356,7 @@ func v1_4_managedGetOriginalTxHash(context unsafe.Pointer, resultHandle int32) {
 
 //export v1_4_managedGetStateRootHash
 func v1_4_managedGetStateRootHash(context unsafe.Pointer, resultHandle int32) {
+	vmhost.TrackEICall(context, "managedGetStateRootHash")
 	blockchain := vmhost.GetBlockchainContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	managedType := vmhost.GetManagedTypesContext(context)
@@ -362,6 +369,7 @@ func v1_4_managedGetStateRootHash(context unsafe.Pointer, resultHandle int32) {
 
 //export v1_4_managedGetBlockRandomSeed
 func v1_4_managedGetBlockRandomSeed(context unsafe.Pointer, resultHandle int32) {
+	vmhost.TrackEICall(context, "managedGetBlockRandomSeed")
 	blockchain := vmhost.GetBlockchainContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	managedType := vmhost.GetManagedTypesContext(context)
@@ -374,6 +382,7 @@ func v1_4_managedGetBlockRandomSeed(context unsafe.Pointer, resultHandle int32)
 
 //export v1_4_managedGetPrevBlockRandomSeed
 func v1_4_managedGetPrevBlockRandomSeed(context unsafe.Pointer, resultHandle int32) {
+	vmhost.TrackEICall(context, "managedGetPrevBlockRandomSeed")
 	blockchain := vmhost.GetBlockchainContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	managedType := vmhost.GetManagedTypesContext(context)
@@ -386,6 +395,7 @@ func v1_4_managedGetPrevBlockRandomSeed(context unsafe.Pointer, resultHandle int
 
 //export v1_4_managedGetReturnData
 func v1_4_managedGetReturnData(context unsafe.Pointer, resultID int32, resultHandle int32) {
+	vmhost.TrackEICall(context, "managedGetReturnData")
 	runtime := vmhost.GetRuntimeContext(context)
 	output := vmhost.GetOutputContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -405,6 +415,7 @@ func v1_4_managedGetReturnData(context unsafe.Pointer, resultID int32, resultHan
 
 //export v1_4_managedGetMultiESDTCallValue
 func v1_4_managedGetMultiESDTCallValue(context unsafe.Pointer, multiCallValueHandle int32) {
+	vmhost.TrackEICall(context, "managedGetMultiESDTCallValue")
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	managedType := vmhost.GetManagedTypesContext(context)
@@ -421,6 +432,7 @@ func v1_4_managedGetMultiESDTCallValue(context unsafe.Pointer, multiCallValueHan
 
 //export v1_4_managedGetESDTBalance
 func v1_4_managedGetESDTBalance(context unsafe.Pointer, addressHandle int32, tokenIDHandle int32, nonce int64, valueHandle int32) {
+	vmhost.TrackEICall(context, "managedGetESDTBalance")
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	blockchain := vmhost.GetBlockchainContext(context)
@@ -453,6 +465,7 @@ func v1_4_managedGetESDTBalance(context unsafe.Pointer, addressHandle int32, tok
 //export v1_4_managedGetESDTTokenData
 func v1_4_managedGetESDTTokenData(context unsafe.Pointer, addressHandle int32, tokenIDHandle int32, nonce int64,
 	valueHandle, propertiesHandle, hashHandle, nameHandle, attributesHandle, creatorHandle, royaltiesHandle, urisHandle int32) {
+	vmhost.TrackEICall(context, "managedGetESDTTokenData")
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	blockchain := vmhost.GetBlockchainContext(context)
@@ -507,6 +520,7 @@ func v1_4_managedAsyncCall(
 	valueHandle int32,
 	functionHandle int32,
 	argumentsHandle int32) {
+	vmhost.TrackEICall(context, "managedAsyncCall")
 	host := vmhost.GetVMHost(context)
 	ManagedAsyncCallWithHost(
 		host,
@@ -568,6 +582,7 @@ func v1_4_managedUpgradeFromSourceContract(
 	argumentsHandle int32,
 	resultHandle int32,
 ) {
+	vmhost.TrackEICall(context, "managedUpgradeFromSourceContract")
 	host := vmhost.GetVMHost(context)
 	runtime := host.Runtime()
 	metering := host.Metering()
@@ -617,6 +632,7 @@ func v1_4_managedUpgradeContract(
 	argumentsHandle int32,
 	resultHandle int32,
 ) {
+	vmhost.TrackEICall(context, "managedUpgradeContract")
 	host := vmhost.GetVMHost(context)
 	runtime := host.Runtime()
 	metering := host.Metering()
@@ -662,6 +678,7 @@ func v1_4_managedDeployFromSourceContract(
 	resultAddressHandle int32,
 	resultHandle int32,
 ) int32 {
+	vmhost.TrackEICall(context, "managedDeployFromSourceContract")
 	host := vmhost.GetVMHost(context)
 	runtime := host.Runtime()
 	metering := host.Metering()
@@ -712,6 +729,7 @@ func v1_4_managedCreateContract(
 	resultAddressHandle int32,
 	resultHandle int32,
 ) int32 {
+	vmhost.TrackEICall(context, "managedCreateContract")
 	host := vmhost.GetVMHost(context)
 	runtime := host.Runtime()
 	metering := host.Metering()
@@ -779,6 +797,7 @@ func v1_4_managedExecuteReadOnly(
 	argumentsHandle int32,
 	resultHandle int32,
 ) int32 {
+	vmhost.TrackEICall(context, "managedExecuteReadOnly")
 	host := vmhost.GetVMHost(context)
 	metering := host.Metering()
 	metering.StartGasTracing(managedExecuteReadOnlyName)
@@ -810,6 +829,7 @@ func v1_4_managedExecuteOnSameContext(
 	argumentsHandle int32,
 	resultHandle int32,
 ) int32 {
+	vmhost.TrackEICall(context, "managedExecuteOnSameContext")
 	host := vmhost.GetVMHost(context)
 	metering := host.Metering()
 	metering.StartGasTracing(managedExecuteOnSameContextName)
@@ -842,6 +862,7 @@ func v1_4_managedExecuteOnDestContextByCaller(
 	argumentsHandle int32,
 	resultHandle int32,
 ) int32 {
+	vmhost.TrackEICall(context, "managedExecuteOnDestContextByCaller")
 	host := vmhost.GetVMHost(context)
 	metering := host.Metering()
 	metering.StartGasTracing(managedExecuteOnDestContextByCallerName)
@@ -874,6 +895,7 @@ func v1_4_managedExecuteOnDestContext(
 	argumentsHandle int32,
 	resultHandle int32,
 ) int32 {
+	vmhost.TrackEICall(context, "managedExecuteOnDestContext")
 	host := vmhost.GetVMHost(context)
 	metering := host.Metering()
 	metering.StartGasTracing(managedExecuteOnDestContextName)
@@ -905,6 +927,7 @@ func v1_4_managedMultiTransferESDTNFTExecute(
 	functionHandle int32,
 	argumentsHandle int32,
 ) int32 {
+	vmhost.TrackEICall(context, "managedMultiTransferESDTNFTExecute")
 	host := vmhost.GetVMHost(context)
 	managedType := host.ManagedTypes()
 	runtime := host.Runtime()
@@ -940,6 +963,7 @@ func v1_4_managedTransferValueExecute(
 	functionHandle int32,
 	argumentsHandle int32,
 ) int32 {
+	vmhost.TrackEICall(context, "managedTransferValueExecute")
 	host := vmhost.GetVMHost(context)
 	metering := host.Metering()
 	metering.StartGasTracing(managedTransferValueExecuteName)
@@ -965,6 +989,7 @@ func v1_4_managedIsESDTFrozen(
 	addressHandle int32,
 	tokenIDHandle int32,
 	nonce int64) int32 {
+	vmhost.TrackEICall(context, "managedIsESDTFrozen")
 	host := vmhost.GetVMHost(context)
 	return ManagedIsESDTFrozenWithHost(host, addressHandle, tokenIDHandle, nonce)
 }
@@ -1008,6 +1033,7 @@ func ManagedIsESDTFrozenWithHost(
 
 //export v1_4_managedIsESDTLimitedTransfer
 func v1_4_managedIsESDTLimitedTransfer(context unsafe.Pointer, tokenIDHandle int32) int32 {
+	vmhost.TrackEICall(context, "managedIsESDTLimitedTransfer")
 	host := vmhost.GetVMHost(context)
 	return ManagedIsESDTLimitedTransferWithHost(host, tokenIDHandle)
 }
@@ -1036,6 +1062,7 @@ func ManagedIsESDTLimitedTransferWithHost(host vmhost.VMHost, tokenIDHandle int3
 
 //export v1_4_managedIsESDTPaused
 func v1_4_managedIsESDTPaused(context unsafe.Pointer, tokenIDHandle int32) int32 {
+	vmhost.TrackEICall(context, "managedIsESDTPaused")
 	host := vmhost.GetVMHost(context)
 	return ManagedIsESDTPausedWithHost(host, tokenIDHandle)
 }
@@ -1064,6 +1091,7 @@ func ManagedIsESDTPausedWithHost(host vmhost.VMHost, tokenIDHandle int32) int32
 
 //export v1_4_managedBufferToHex
 func v1_4_managedBufferToHex(context unsafe.Pointer, sourceHandle int32, destHandle int32) {
+	vmhost.TrackEICall(context, "managedBufferToHex")
 	host := vmhost.GetVMHost(context)
 	ManagedBufferToHexWithHost(host, sourceHandle, destHandle)
 }
@@ -119,6 +119,7 @@ func SmallIntImports(imports vmhooksmeta.EIFunctionReceiver) error {
 
 //export v1_4_smallIntGetUnsignedArgument
 func v1_4_smallIntGetUnsignedArgument(context unsafe.Pointer, id int32) int64 {
+	vmhost.TrackEICall(context, "smallIntGetUnsignedArgument")
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -142,6 +143,11 @@ func v1_4_smallIntGetUnsignedArgument(context unsafe.Pointer, id int32) int64 {
 
 //export v1_4_smallIntGetSignedArgument
 func v1_4_smallIntGetSignedArgument(context unsafe.Pointer, id int32) int64 {
+	vmhost.TrackEICall(context, "smallIntGetSignedArgument")
+	return smallIntGetSignedArgument(context, id)
+}
+
+func smallIntGetSignedArgument(context unsafe.Pointer, id int32) int64 {
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -165,6 +171,7 @@ func v1_4_smallIntGetSignedArgument(context unsafe.Pointer, id int32) int64 {
 
 //export v1_4_smallIntFinishUnsigned
 func v1_4_smallIntFinishUnsigned(context unsafe.Pointer, value int64) {
+	vmhost.TrackEICall(context, "smallIntFinishUnsigned")
 	output := vmhost.GetOutputContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -177,6 +184,11 @@ func v1_4_smallIntFinishUnsigned(context unsafe.Pointer, value int64) {
 
 //export v1_4_smallIntFinishSigned
 func v1_4_smallIntFinishSigned(context unsafe.Pointer, value int64) {
+	vmhost.TrackEICall(context, "smallIntFinishSigned")
+	smallIntFinishSigned(context, value)
+}
+
+func smallIntFinishSigned(context unsafe.Pointer, value int64) {
 	output := vmhost.GetOutputContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -189,6 +201,11 @@ func v1_4_smallIntFinishSigned(context unsafe.Pointer, value int64) {
 
 //export v1_4_smallIntStorageStoreUnsigned
 func v1_4_smallIntStorageStoreUnsigned(context unsafe.Pointer, keyOffset int32, keyLength int32, value int64) int32 {
+	vmhost.TrackEICall(context, "smallIntStorageStoreUnsigned")
+	return smallIntStorageStoreUnsigned(context, keyOffset, keyLength, value)
+}
+
+func smallIntStorageStoreUnsigned(context unsafe.Pointer, keyOffset int32, keyLength int32, value int64) int32 {
 	runtime := vmhost.GetRuntimeContext(context)
 	storage := vmhost.GetStorageContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -212,6 +229,7 @@ func v1_4_smallIntStorageStoreUnsigned(context unsafe.Pointer, keyOffset int32,
 
 //export v1_4_smallIntStorageStoreSigned
 func v1_4_smallIntStorageStoreSigned(context unsafe.Pointer, keyOffset int32, keyLength int32, value int64) int32 {
+	vmhost.TrackEICall(context, "smallIntStorageStoreSigned")
 	runtime := vmhost.GetRuntimeContext(context)
 	storage := vmhost.GetStorageContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -235,6 +253,11 @@ func v1_4_smallIntStorageStoreSigned(context unsafe.Pointer, keyOffset int32, ke
 
 //export v1_4_smallIntStorageLoadUnsigned
 func v1_4_smallIntStorageLoadUnsigned(context unsafe.Pointer, keyOffset int32, keyLength int32) int64 {
+	vmhost.TrackEICall(context, "smallIntStorageLoadUnsigned")
+	return smallIntStorageLoadUnsigned(context, keyOffset, keyLength)
+}
+
+func smallIntStorageLoadUnsigned(context unsafe.Pointer, keyOffset int32, keyLength int32) int64 {
 	runtime := vmhost.GetRuntimeContext(context)
 	storage := vmhost.GetStorageContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -261,6 +284,7 @@ func v1_4_smallIntStorageLoadUnsigned(context unsafe.Pointer, keyOffset int32, k
 
 //export v1_4_smallIntStorageLoadSigned
 func v1_4_smallIntStorageLoadSigned(context unsafe.Pointer, keyOffset int32, keyLength int32) int64 {
+	vmhost.TrackEICall(context, "smallIntStorageLoadSigned")
 	runtime := vmhost.GetRuntimeContext(context)
 	storage := vmhost.GetStorageContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -287,24 +311,28 @@ func v1_4_smallIntStorageLoadSigned(context unsafe.Pointer, keyOffset int32, key
 
 //export v1_4_int64getArgument
 func v1_4_int64getArgument(context unsafe.Pointer, id int32) int64 {
+	vmhost.TrackEICall(context, "int64getArgument")
 	// backwards compatibility
-	return v1_4_smallIntGetSignedArgument(context, id)
+	return smallIntGetSignedArgument(context, id)
 }
 
 //export v1_4_int64finish
 func v1_4_int64finish(context unsafe.Pointer, value int64) {
+	vmhost.TrackEICall(context, "int64finish")
 	// backwards compatibility
-	v1_4_smallIntFinishSigned(context, value)
+	smallIntFinishSigned(context, value)
 }
 
 //export v1_4_int64storageStore
 func v1_4_int64storageStore(context unsafe.Pointer, keyOffset int32, keyLength int32, value int64) int32 {
+	vmhost.TrackEICall(context, "int64storageStore")
 	// backwards compatibility
-	return v1_4_smallIntStorageStoreUnsigned(context, keyOffset, keyLength, value)
+	return smallIntStorageStoreUnsigned(context, keyOffset, keyLength, value)
 }
 
 //export v1_4_int64storageLoad
 func v1_4_int64storageLoad(context unsafe.Pointer, keyOffset int32, keyLength int32) int64 {
+	vmhost.TrackEICall(context, "int64storageLoad")
 	// backwards compatibility
-	return v1_4_smallIntStorageLoadUnsigned(context, keyOffset, keyLength)
+	return smallIntStorageLoadUnsigned(context, keyOffset, keyLength)
 }
@@ -0,0 +1,71 @@
+package vmhooksmeta
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// EIFunctionBinding makes an EI function callable directly from Go, for the engines
+// which execute the contracts without going through cgo.
+type EIFunctionBinding struct {
+	// The name of the imported function.
+	Name string
+
+	// The EI function, as registered in the `EIFunctions`.
+	Function EIFunction
+
+	implementation reflect.Value
+}
+
+// NewEIFunctionBindings creates the bindings of all the EI functions, indexed by namespace and name.
+func NewEIFunctionBindings(imports *EIFunctions) map[string]map[string]*EIFunctionBinding {
+	bindings := make(map[string]map[string]*EIFunctionBinding)
+	for name, function := range imports.FunctionMap {
+		if bindings[function.Namespace] == nil {
+			bindings[function.Namespace] = make(map[string]*EIFunctionBinding)
+		}
+
+		bindings[function.Namespace][name] = &EIFunctionBinding{
+			Name:           name,
+			Function:       function,
+			implementation: reflect.ValueOf(function.Implementation),
+		}
+	}
+
+	return bindings
+}
+
+// Call calls the EI function with the given instance context. The arguments and the results
+// are encoded as on the WASM value stack, the 32 bit values being held by the lower bits.
+func (binding *EIFunctionBinding) Call(context unsafe.Pointer, arguments ...uint64) ([]uint64, error) {
+	if len(arguments) != len(binding.Function.FunctionInputs) {
+		return nil, NewImportedFunctionError(binding.Name, fmt.Sprintf(
+			"The `%%s` imported function expects %d arguments; given %d.",
+			len(binding.Function.FunctionInputs), len(arguments)))
+	}
+
+	var inputs = make([]reflect.Value, 1+len(arguments))
+	inputs[0] = reflect.ValueOf(context)
+	for nth, argument := range arguments {
+		switch binding.Function.FunctionInputs[nth] {
+		case EIFunctionValueInt32:
+			inputs[nth+1] = reflect.ValueOf(int32(argument))
+		case EIFunctionValueInt64:
+			inputs[nth+1] = reflect.ValueOf(int64(argument))
+		}
+	}
+
+	var outputs = binding.implementation.Call(inputs)
+	var results = make([]uint64, len(outputs))
+	for nth, output := range outputs {
+		switch binding.Function.FunctionOutputs[nth] {
+		case EIFunctionValueInt32:
+			results[nth] = uint64(uint32(output.Int()))
+		case EIFunctionValueInt64:
+			results[nth] = uint64(output.Int())
+		}
+	}
+
+	return results, nil
+}
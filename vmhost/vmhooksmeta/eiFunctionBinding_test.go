@@ -0,0 +1,48 @@
+package vmhooksmeta
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEIFunctionBinding_Call(t *testing.T) {
+	var receivedContext unsafe.Pointer
+	add := func(context unsafe.Pointer, a int32, b int64) int64 {
+		receivedContext = context
+		return int64(a) + b
+	}
+	negate := func(_ unsafe.Pointer, a int32) int32 {
+		return -a
+	}
+	noResult := func(_ unsafe.Pointer) {}
+
+	imports := NewEIFunctions()
+	require.Nil(t, imports.Append("add", add, nil))
+	imports.Namespace("other")
+	require.Nil(t, imports.Append("negate", negate, nil))
+	require.Nil(t, imports.Append("noResult", noResult, nil))
+
+	bindings := NewEIFunctionBindings(imports)
+	require.Len(t, bindings["env"], 1)
+	require.Len(t, bindings["other"], 2)
+
+	minusTwo := int32(-2)
+	context := unsafe.Pointer(&imports)
+	results, err := bindings["env"]["add"].Call(context, uint64(uint32(minusTwo)), 5)
+	require.Nil(t, err)
+	require.Equal(t, []uint64{3}, results)
+	require.Equal(t, context, receivedContext)
+
+	results, err = bindings["other"]["negate"].Call(context, 2)
+	require.Nil(t, err)
+	require.Equal(t, []uint64{uint64(uint32(minusTwo))}, results)
+
+	results, err = bindings["other"]["noResult"].Call(context)
+	require.Nil(t, err)
+	require.Empty(t, results)
+
+	_, err = bindings["env"]["add"].Call(context, 1)
+	require.NotNil(t, err)
+}
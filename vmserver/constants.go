@@ -2,3 +2,9 @@ package vmserver
 
 // DefaultGasPrice is the default gas price for debugging
 const DefaultGasPrice = 200000000000
+
+// compiledCodeMaxEntrySize is the size limit of a compiled contract kept in the database
+const compiledCodeMaxEntrySize = 16 * 1024 * 1024
+
+// compiledCodeMaxTotalSize is the size limit of all the compiled contracts kept in the database
+const compiledCodeMaxTotalSize = 512 * 1024 * 1024
@@ -5,19 +5,38 @@ import (
 	"fmt"
 	"os"
 	"path"
+
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/compiledstore"
 )
 
 type database struct {
-	rootPath string
+	rootPath          string
+	compiledCodeStore vmhost.CompiledCodeStore
 }
 
-// newDatabase creates a new debugging database (basically, a folder with JSON files)
+// newDatabase creates a new debugging database (basically, a folder with JSON files, and the compiled contracts)
 func newDatabase(rootPath string) *database {
 	db := &database{rootPath: rootPath}
 	db.initFolders()
+	db.initCompiledCodeStore()
 	return db
 }
 
+func (db *database) initCompiledCodeStore() {
+	compiledCodeStore, err := compiledstore.NewFileCompiledCodeStore(compiledstore.ArgsFileCompiledCodeStore{
+		Directory:    path.Join(db.rootPath, "compiled"),
+		MaxEntrySize: compiledCodeMaxEntrySize,
+		MaxTotalSize: compiledCodeMaxTotalSize,
+	})
+	if err != nil {
+		log.Error("database.initCompiledCodeStore", "err", err)
+		return
+	}
+
+	db.compiledCodeStore = compiledCodeStore
+}
+
 func (db *database) initFolders() {
 	err := os.MkdirAll(path.Join(db.rootPath, "worlds"), os.ModePerm)
 	if err != nil {
@@ -42,7 +61,7 @@ func (db *database) loadWorld(worldID string) (*world, error) {
 		}
 	}
 
-	world, err := newWorld(dataModel)
+	world, err := newWorld(dataModel, db.compiledCodeStore)
 	if err != nil {
 		return nil, err
 	}
@@ -4,6 +4,7 @@ import (
 	"math/big"
 
 	"github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost"
 )
 
 // RequestBase is a CLI / REST request message
@@ -78,9 +79,11 @@ type ContractResponseBase struct {
 	Input            *vmcommon.VMInput
 	Output           *vmcommon.VMOutput
 	ReturnCodeString string
+	PeakMemoryPages  uint32
+	CallMemoryUsage  []*vmhost.CallMemoryUsage
 }
 
-func createContractResponseBase(input *vmcommon.VMInput, output *vmcommon.VMOutput) ContractResponseBase {
+func createContractResponseBase(input *vmcommon.VMInput, output *vmcommon.VMOutput, memoryUsage *vmhost.MemoryUsage) ContractResponseBase {
 	response := ContractResponseBase{
 		Input:  input,
 		Output: output,
@@ -90,5 +93,10 @@ func createContractResponseBase(input *vmcommon.VMInput, output *vmcommon.VMOutp
 		response.ReturnCodeString = output.ReturnCode.String()
 	}
 
+	if memoryUsage != nil {
+		response.PeakMemoryPages = memoryUsage.PeakPages()
+		response.CallMemoryUsage = memoryUsage.Calls
+	}
+
 	return response
 }
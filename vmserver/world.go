@@ -9,7 +9,6 @@ import (
 	"github.com/multiversx/mx-chain-vm-v1_4-go/vmhost/mock"
 
 	worldmock "github.com/multiversx/mx-chain-scenario-go/worldmock"
-	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
 	"github.com/multiversx/mx-chain-vm-common-go/parsers"
 )
@@ -22,7 +21,7 @@ type worldDataModel struct {
 type world struct {
 	id             string
 	blockchainHook *worldmock.MockWorld
-	vm             vmcommon.VMExecutionHandler
+	vm             vmhost.VMHost
 }
 
 func newWorldDataModel(worldID string) *worldDataModel {
@@ -33,13 +32,13 @@ func newWorldDataModel(worldID string) *worldDataModel {
 }
 
 // newWorld creates a new debugging world
-func newWorld(dataModel *worldDataModel) (*world, error) {
+func newWorld(dataModel *worldDataModel, compiledCodeStore vmhost.CompiledCodeStore) (*world, error) {
 	blockchainHook := mock.NewMockWorldVM14()
 	blockchainHook.AcctMap = dataModel.Accounts
 
 	vm, err := hostCore.NewVMHost(
 		blockchainHook,
-		getHostParameters(),
+		getHostParameters(compiledCodeStore),
 	)
 	if err != nil {
 		return nil, err
@@ -52,7 +51,7 @@ func newWorld(dataModel *worldDataModel) (*world, error) {
 	}, nil
 }
 
-func getHostParameters() *vmhost.VMHostParameters {
+func getHostParameters(compiledCodeStore vmhost.CompiledCodeStore) *vmhost.VMHostParameters {
 	esdtTransferParser, _ := parsers.NewESDTTransferParser(worldmock.WorldMarshalizer)
 	return &vmhost.VMHostParameters{
 		VMType:                   []byte{5, 0},
@@ -65,6 +64,7 @@ func getHostParameters() *vmhost.VMHostParameters {
 		EnableEpochsHandler:      &mock.EnableEpochsHandlerStub{},
 		WasmerSIGSEGVPassthrough: false,
 		Hasher:                   worldmock.DefaultHasher,
+		CompiledCodeStore:        compiledCodeStore,
 	}
 }
 
@@ -78,7 +78,7 @@ func (w *world) deploySmartContract(request DeployRequest) *DeployResponse {
 	}
 
 	response := &DeployResponse{}
-	response.ContractResponseBase = createContractResponseBase(&input.VMInput, vmOutput)
+	response.ContractResponseBase = createContractResponseBase(&input.VMInput, vmOutput, w.vm.GetMemoryUsage())
 	response.Error = err
 	response.ContractAddress = w.blockchainHook.LastCreatedContractAddress
 	response.ContractAddressHex = toHex(response.ContractAddress)
@@ -95,7 +95,7 @@ func (w *world) upgradeSmartContract(request UpgradeRequest) *UpgradeResponse {
 	}
 
 	response := &UpgradeResponse{}
-	response.ContractResponseBase = createContractResponseBase(&input.VMInput, vmOutput)
+	response.ContractResponseBase = createContractResponseBase(&input.VMInput, vmOutput, w.vm.GetMemoryUsage())
 	response.Error = err
 
 	return response
@@ -111,7 +111,7 @@ func (w *world) runSmartContract(request RunRequest) *RunResponse {
 	}
 
 	response := &RunResponse{}
-	response.ContractResponseBase = createContractResponseBase(&input.VMInput, vmOutput)
+	response.ContractResponseBase = createContractResponseBase(&input.VMInput, vmOutput, w.vm.GetMemoryUsage())
 	response.Error = err
 
 	return response
@@ -124,7 +124,7 @@ func (w *world) querySmartContract(request QueryRequest) *QueryResponse {
 	vmOutput, err := w.vm.RunSmartContractCall(input)
 
 	response := &QueryResponse{}
-	response.ContractResponseBase = createContractResponseBase(&input.VMInput, vmOutput)
+	response.ContractResponseBase = createContractResponseBase(&input.VMInput, vmOutput, w.vm.GetMemoryUsage())
 	response.Error = err
 
 	return response
@@ -13,6 +13,10 @@ import (
 // OpcodeCount is the total number of WASM opcodes currently supported by Wasmer
 const OpcodeCount = 448
 
+// CompiledCodeFormatVersion identifies the format of the compiled code produced by the linked
+// Wasmer library; it must be increased whenever a new Wasmer build is linked
+const CompiledCodeFormatVersion = 1
+
 var logWasmer = logger.GetOrCreate("vm/wasmer")
 
 // InstanceError represents any kind of errors related to a WebAssembly instance. It
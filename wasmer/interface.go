@@ -34,3 +34,10 @@ type MemoryHandler interface {
 	Destroy()
 	IsInterfaceNil() bool
 }
+
+// GlobalsHandler is implemented by the instances giving access to the values of their globals;
+// the instances created by Wasmer do not implement it, their globals being reset by Reset only
+type GlobalsHandler interface {
+	GetGlobals() []uint64
+	SetGlobals(values []uint64) bool
+}